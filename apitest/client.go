@@ -0,0 +1,158 @@
+package apitest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Client is used to test http.Handler by a sequence of dependent requests (for example, a login flow).
+// It holds a base path, default request options and a cookie jar. Cookies received
+// in each response are automatically sent with the following requests.
+type Client struct {
+	handler  http.Handler
+	basePath string
+	options  []RequestOption
+	jar      http.CookieJar
+}
+
+// NewClient creates a Client for the handler. By default, it uses an in-memory cookie jar.
+func NewClient(handler http.Handler) *Client {
+	jar, _ := cookiejar.New(nil)
+
+	return &Client{handler: handler, jar: jar}
+}
+
+// WithBasePath sets the path that is prepended to the url of each request built by the client.
+func (c *Client) WithBasePath(path string) *Client {
+	c.basePath = strings.TrimSuffix(path, "/")
+	return c
+}
+
+// WithDefaultOptions appends request options that are applied to each request
+// before the options passed to the specific request.
+func (c *Client) WithDefaultOptions(options ...RequestOption) *Client {
+	c.options = append(c.options, options...)
+	return c
+}
+
+// WithCookieJar replaces the cookie jar of the client. Nil value disables cookies handling.
+func (c *Client) WithCookieJar(jar http.CookieJar) *Client {
+	c.jar = jar
+	return c
+}
+
+// CookieJar returns cookie jar of the client.
+func (c *Client) CookieJar() http.CookieJar {
+	return c.jar
+}
+
+// Do applies default options and stored cookies to the request and passes it to the handler.
+// Headers set explicitly on the request take precedence over the headers of default options,
+// the same way as options of the specific request do for GET, POST and other methods.
+// Cookies from the response are saved in the cookie jar.
+// This function returns ResponseAssertion struct as a helper to build assertions on the response.
+func (c *Client) Do(t TestingT, request *http.Request) *ResponseAssertion {
+	t.Helper()
+	explicit := request.Header.Clone()
+	for _, setUpRequest := range c.options {
+		setUpRequest(request)
+	}
+	for key, values := range explicit {
+		request.Header[key] = values
+	}
+	return c.handle(t, request)
+}
+
+// Handle builds the request by the builder and passes it to the handler the same way as Do.
+// The url of the builder is relative to the base path. Default options are applied before
+// the content type and the options of the builder.
+func (c *Client) Handle(t TestingT, builder *RequestBuilder) *ResponseAssertion {
+	t.Helper()
+	request, err := builder.buildServerRequest(c.basePath, c.options...)
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("failed to build request %s %s: %s", builder.method, builder.url, err.Error()))
+		return newFailedResponseAssertion(t)
+	}
+	return c.handle(t, request)
+}
+
+// GET builds the GET request from url (relative to the base path) and options and passes it to the handler.
+func (c *Client) GET(t TestingT, url string, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	return c.request(t, http.MethodGet, url, nil, options...)
+}
+
+// POST builds the POST request from url (relative to the base path), body and options and passes it to the handler.
+func (c *Client) POST(t TestingT, url string, body io.Reader, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	return c.request(t, http.MethodPost, url, body, options...)
+}
+
+// PUT builds the PUT request from url (relative to the base path), body and options and passes it to the handler.
+func (c *Client) PUT(t TestingT, url string, body io.Reader, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	return c.request(t, http.MethodPut, url, body, options...)
+}
+
+// PATCH builds the PATCH request from url (relative to the base path), body and options and passes it to the handler.
+func (c *Client) PATCH(t TestingT, url string, body io.Reader, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	return c.request(t, http.MethodPatch, url, body, options...)
+}
+
+// DELETE builds the DELETE request from url (relative to the base path) and options and passes it to the handler.
+func (c *Client) DELETE(t TestingT, url string, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	return c.request(t, http.MethodDelete, url, nil, options...)
+}
+
+func (c *Client) request(t TestingT, method, url string, body io.Reader, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	request := httptest.NewRequest(method, c.basePath+url, body)
+	for _, setUpRequest := range c.options {
+		setUpRequest(request)
+	}
+	for _, setUpRequest := range options {
+		setUpRequest(request)
+	}
+	return c.handle(t, request)
+}
+
+func (c *Client) handle(t TestingT, request *http.Request) *ResponseAssertion {
+	t.Helper()
+	if c.jar == nil {
+		return HandleRequest(t, c.handler, request)
+	}
+
+	u := cookieURL(request)
+	for _, cookie := range c.jar.Cookies(u) {
+		request.AddCookie(cookie)
+	}
+	response := HandleRequest(t, c.handler, request)
+	c.jar.SetCookies(u, response.Cookies())
+
+	return response
+}
+
+// cookieURL returns an absolute URL of the request, required by the http.CookieJar.
+func cookieURL(request *http.Request) *url.URL {
+	u := *request.URL
+	if u.Host == "" {
+		u.Host = request.Host
+	}
+	if u.Scheme == "" {
+		u.Scheme = "http"
+		if request.TLS != nil {
+			u.Scheme = "https"
+		}
+	}
+
+	return &u
+}
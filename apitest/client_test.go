@@ -0,0 +1,111 @@
+package apitest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/muonsoft/api-testing/apitest"
+	"github.com/muonsoft/api-testing/apitesttest"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_LoginFlow(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/login", func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "Bearer token", request.Header.Get("Authorization"))
+		http.SetCookie(writer, &http.Cookie{Name: "session", Value: "secret", Path: "/"})
+		writer.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/profile", func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "Bearer token", request.Header.Get("Authorization"))
+		assert.Equal(t, "application/json; charset=utf-8", request.Header.Get("Content-Type"))
+		cookie, err := request.Cookie("session")
+		if err != nil || cookie.Value != "secret" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+	tester := &mock.Tester{}
+	client := apitest.NewClient(mux).
+		WithBasePath("/api/").
+		WithDefaultOptions(apitest.WithHeader("Authorization", "Bearer token"))
+
+	client.GET(tester, "/profile", apitest.WithJSONContentType()).IsUnauthorized()
+	client.POST(tester, "/login", strings.NewReader("{}")).HasNoContent()
+	client.GET(tester, "/profile", apitest.WithJSONContentType()).IsOK()
+	request := httptest.NewRequest(http.MethodGet, "/api/profile", nil)
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+	client.Do(tester, request).IsOK()
+
+	tester.AssertContains(t, nil)
+}
+
+func TestClient_WithoutCookieJar(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if _, err := request.Cookie("session"); err == nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		http.SetCookie(writer, &http.Cookie{Name: "session", Value: "secret"})
+		writer.WriteHeader(http.StatusOK)
+	})
+	tester := &mock.Tester{}
+	client := apitest.NewClient(handler).WithCookieJar(nil)
+
+	client.GET(tester, "/").IsOK()
+	client.PUT(tester, "/", nil).IsOK()
+	client.PATCH(tester, "/", nil).IsOK()
+	client.DELETE(tester, "/").IsOK()
+
+	tester.AssertContains(t, nil)
+}
+
+func TestClient_Handle_WhenDefaultContentType_ExpectOverriddenByBuilder(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "Bearer token", request.Header.Get("Authorization"))
+		assert.Equal(t, "user", request.Header.Get("X-Role"))
+		if err := request.ParseMultipartForm(1 << 20); err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, "john", request.FormValue("name"))
+		writer.WriteHeader(http.StatusCreated)
+	})
+	tester := &mock.Tester{}
+	client := apitest.NewClient(handler).WithDefaultOptions(
+		apitest.WithJSONContentType(),
+		apitest.WithHeader("Authorization", "Bearer token"),
+		apitest.WithHeader("X-Role", "admin"),
+	)
+
+	client.Handle(tester, apitest.NewRequest(http.MethodPost, "/users").
+		WithMultipartField("name", "john").
+		WithOptions(apitest.WithHeader("X-Role", "user")),
+	).IsCreated()
+
+	tester.AssertContains(t, nil)
+}
+
+func TestClient_Do_WhenHeaderIsSetExplicitly_ExpectNotOverriddenByDefaults(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "Bearer admin", request.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", request.Header.Get("Accept"))
+		writer.WriteHeader(http.StatusOK)
+	})
+	tester := &apitesttest.Recorder{}
+	client := apitest.NewClient(handler).WithDefaultOptions(
+		apitest.WithHeader("Authorization", "Bearer token"),
+		apitest.WithHeader("Accept", "application/json"),
+	)
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Authorization", "Bearer admin")
+
+	client.Do(tester, request).IsOK()
+
+	tester.AssertPassed(t)
+}
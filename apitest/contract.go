@@ -0,0 +1,84 @@
+package apitest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/muonsoft/api-testing/internal/failfast"
+	"github.com/muonsoft/api-testing/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+type contractKey struct{}
+
+// WithRequestContract option enables validation of the request against the OpenAPI document.
+// The request is validated by HandleRequest and SendRequest before it is passed to the handler
+// (or sent to the server), each violation is reported as a separate failure.
+func WithRequestContract(document *openapi.Document) RequestOption {
+	return func(r *http.Request) {
+		*r = *r.WithContext(context.WithValue(r.Context(), contractKey{}, document))
+	}
+}
+
+// MatchesContract asserts that the response matches the OpenAPI document:
+// the status code is documented for the requested operation, headers,
+// content type and body match the response definition. Each violation is reported
+// as a separate failure, violations of the JSON body contain the path to the invalid node.
+func (r *ResponseAssertion) MatchesContract(document *openapi.Document) {
+	r.t.Helper()
+	if r.request == nil {
+		assert.Fail(r.t, "failed asserting that response matches OpenAPI contract: request is not available for contract validation")
+		return
+	}
+	violations := document.ValidateResponse(r.request, r.recorder.Code, r.recorder.Header(), r.recorder.Body.Bytes())
+	if len(violations) == 0 {
+		return
+	}
+	failfast.Postpone(r.t, func() {
+		r.t.Helper()
+		for _, violation := range violations {
+			assert.Fail(r.t, fmt.Sprintf(
+				`failed asserting that response of "%s %s" matches OpenAPI contract: %s`,
+				r.request.Method, r.request.URL.Path, violation.String(),
+			))
+		}
+		r.logResponse()
+	})
+}
+
+func assertRequestContract(t TestingT, request *http.Request) {
+	t.Helper()
+	document, ok := request.Context().Value(contractKey{}).(*openapi.Document)
+	if !ok || document == nil {
+		return
+	}
+	body, err := readRequestBody(request)
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("failed to read request body: %s", err.Error()))
+		return
+	}
+	for _, violation := range document.ValidateRequest(request, body) {
+		assert.Fail(t, fmt.Sprintf(
+			`failed asserting that request "%s %s" matches OpenAPI contract: %s`,
+			request.Method, request.URL.Path, violation.String(),
+		))
+	}
+}
+
+// readRequestBody reads the request body and restores it, so it can be read again by the handler.
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	request.Body.Close()
+	request.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
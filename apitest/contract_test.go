@@ -0,0 +1,140 @@
+package apitest_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/muonsoft/api-testing/apitest"
+	"github.com/muonsoft/api-testing/apitesttest"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/muonsoft/api-testing/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContract = `
+openapi: 3.1.0
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+`
+
+func TestResponseAssertion_MatchesContract(t *testing.T) {
+	document, err := openapi.Parse([]byte(testContract))
+	require.NoError(t, err)
+	tests := []struct {
+		name         string
+		code         int
+		body         string
+		wantMessages []string
+	}{
+		{
+			name: "valid response",
+			code: http.StatusCreated,
+			body: `{"id":1,"name":"John"}`,
+		},
+		{
+			name: "invalid body",
+			code: http.StatusCreated,
+			body: `{"id":"1"}`,
+			wantMessages: []string{
+				`failed asserting that response of "POST /users" matches OpenAPI contract: body: required property "name" is missing`,
+				`failed asserting that response of "POST /users" matches OpenAPI contract: body at "id": must be of type integer, actual is string`,
+				`HTTP/1.1 201 Created`,
+			},
+		},
+		{
+			name: "undocumented status code",
+			code: http.StatusOK,
+			body: `{}`,
+			wantMessages: []string{
+				`failed asserting that response of "POST /users" matches OpenAPI contract: status code 200 is not documented`,
+				`HTTP/1.1 200 OK`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				writer.Header().Set("Content-Type", "application/json")
+				writer.WriteHeader(test.code)
+				_, _ = writer.Write([]byte(test.body))
+			})
+			tester := &mock.Tester{}
+
+			response := apitest.HandlePOST(tester, handler, "/users", strings.NewReader(`{"name":"John"}`), apitest.WithJSONContentType())
+			response.MatchesContract(document)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestResponseAssertion_MatchesContract_WhenRequestIsNotSent_ExpectFailure(t *testing.T) {
+	document, err := openapi.Parse([]byte(testContract))
+	require.NoError(t, err)
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	tester := &apitesttest.Recorder{}
+
+	response := apitest.NewTestServer(server).POST(tester, "/users", strings.NewReader(`{"name":"John"}`))
+	response.MatchesContract(document)
+
+	tester.AssertFailedWithMessages(t,
+		"failed to send request POST "+server.URL+"/users",
+		"failed asserting that response matches OpenAPI contract: request is not available for contract validation",
+	)
+}
+
+func TestWithRequestContract(t *testing.T) {
+	document, err := openapi.Parse([]byte(testContract))
+	require.NoError(t, err)
+	var handledBody string
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		handledBody = string(body)
+		writer.WriteHeader(http.StatusCreated)
+	})
+	tester := &mock.Tester{}
+
+	apitest.HandlePOST(
+		tester, handler, "/users", strings.NewReader(`{"name":1}`),
+		apitest.WithJSONContentType(),
+		apitest.WithRequestContract(document),
+	).IsCreated()
+
+	tester.AssertContains(t, []string{
+		`failed asserting that request "POST /users" matches OpenAPI contract: body at "name": must be of type string, actual is integer`,
+	})
+	assert.Equal(t, `{"name":1}`, handledBody)
+}
@@ -1,3 +1,5 @@
 // Package apitest provides methods for testing client-server communication.
 // It can be used to test http.Handler to build complex assertions on the HTTP responses.
+// Also, it can send real HTTP requests to the running server (see Server and SendRequest),
+// the same assertions are used for both cases.
 package apitest
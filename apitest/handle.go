@@ -42,9 +42,10 @@ func WithCookie(cookie *http.Cookie) RequestOption {
 // This function returns ResponseAssertion struct as a helper to build assertions on the response.
 func HandleRequest(t TestingT, handler http.Handler, request *http.Request) *ResponseAssertion {
 	t.Helper()
+	assertRequestContract(t, request)
 	recorder := httptest.NewRecorder()
 	handler.ServeHTTP(recorder, request)
-	return &ResponseAssertion{t: t, recorder: recorder}
+	return &ResponseAssertion{t: t, recorder: recorder, request: request}
 }
 
 // HandleGET is an alias for HandleRequest that builds the GET request from url and options.
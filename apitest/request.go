@@ -0,0 +1,236 @@
+package apitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RequestBuilder is used to build a request with encoded body and query parameters.
+// The body can be set by one of WithBody, WithJSON, WithForm or multipart methods,
+// the last one wins.
+type RequestBuilder struct {
+	method  string
+	url     string
+	query   url.Values
+	body    func() (io.Reader, string, error)
+	parts   []multipartPart
+	options []RequestOption
+}
+
+type multipartPart struct {
+	field    string
+	filename string
+	value    string
+	content  io.Reader
+}
+
+// NewRequest creates a RequestBuilder for the request with the method and target url.
+func NewRequest(method, target string) *RequestBuilder {
+	return &RequestBuilder{method: method, url: target, query: make(url.Values)}
+}
+
+// WithQuery adds query parameter to the request url. Parameters are merged with the query
+// from the url.
+func (b *RequestBuilder) WithQuery(key, value string) *RequestBuilder {
+	b.query.Add(key, value)
+	return b
+}
+
+// WithQueryValues adds query parameters to the request url. Parameters are merged with the query
+// from the url.
+func (b *RequestBuilder) WithQueryValues(values url.Values) *RequestBuilder {
+	for key, vs := range values {
+		for _, value := range vs {
+			b.query.Add(key, value)
+		}
+	}
+	return b
+}
+
+// WithHeader overrides specific header of the request.
+func (b *RequestBuilder) WithHeader(key, value string) *RequestBuilder {
+	return b.WithOptions(WithHeader(key, value))
+}
+
+// WithOptions appends request options that are applied after the request is built.
+func (b *RequestBuilder) WithOptions(options ...RequestOption) *RequestBuilder {
+	b.options = append(b.options, options...)
+	return b
+}
+
+// WithBody sets raw request body.
+func (b *RequestBuilder) WithBody(body io.Reader) *RequestBuilder {
+	b.parts = nil
+	b.body = func() (io.Reader, string, error) {
+		return body, "", nil
+	}
+	return b
+}
+
+// WithJSON sets request body with JSON and "application/json" content type. String, byte slice
+// and json.RawMessage values are used as JSON as is, other values are marshaled by json.Marshal.
+func (b *RequestBuilder) WithJSON(value interface{}) *RequestBuilder {
+	b.parts = nil
+	b.body = func() (io.Reader, string, error) {
+		var data []byte
+		switch v := value.(type) {
+		case string:
+			data = []byte(v)
+		case []byte:
+			data = v
+		case json.RawMessage:
+			data = v
+		default:
+			var err error
+			data, err = json.Marshal(value)
+			if err != nil {
+				return nil, "", fmt.Errorf("marshal JSON: %w", err)
+			}
+		}
+		return bytes.NewReader(data), "application/json; charset=utf-8", nil
+	}
+	return b
+}
+
+// WithForm sets request body with URL encoded form values and "application/x-www-form-urlencoded" content type.
+func (b *RequestBuilder) WithForm(values url.Values) *RequestBuilder {
+	b.parts = nil
+	b.body = func() (io.Reader, string, error) {
+		return bytes.NewBufferString(values.Encode()), "application/x-www-form-urlencoded", nil
+	}
+	return b
+}
+
+// WithMultipartField adds form field to the "multipart/form-data" request body.
+func (b *RequestBuilder) WithMultipartField(field, value string) *RequestBuilder {
+	b.parts = append(b.parts, multipartPart{field: field, value: value})
+	b.body = b.encodeMultipart
+	return b
+}
+
+// WithMultipartFile adds file part to the "multipart/form-data" request body.
+func (b *RequestBuilder) WithMultipartFile(field, filename string, content io.Reader) *RequestBuilder {
+	b.parts = append(b.parts, multipartPart{field: field, filename: filename, content: content})
+	b.body = b.encodeMultipart
+	return b
+}
+
+// Build builds the server request (the same as httptest.NewRequest) that can be passed to http.Handler.
+func (b *RequestBuilder) Build() (*http.Request, error) {
+	return b.buildServerRequest("")
+}
+
+// Handle builds the request and passes it to the handler by HandleRequest.
+// This function returns ResponseAssertion struct as a helper to build assertions on the response.
+func (b *RequestBuilder) Handle(t TestingT, handler http.Handler) *ResponseAssertion {
+	t.Helper()
+	request, err := b.Build()
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("failed to build request %s %s: %s", b.method, b.url, err.Error()))
+		return newFailedResponseAssertion(t)
+	}
+	return HandleRequest(t, handler, request)
+}
+
+// Send builds the client request and sends it by SendRequest. The url of the request must be absolute.
+// This function returns ResponseAssertion struct as a helper to build assertions on the response.
+func (b *RequestBuilder) Send(t TestingT, client *http.Client) *ResponseAssertion {
+	t.Helper()
+	request, err := b.build("", nil, func(method, target string, body io.Reader) (*http.Request, error) {
+		return http.NewRequestWithContext(context.Background(), method, target, body)
+	})
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("failed to build request %s %s: %s", b.method, b.url, err.Error()))
+		return newFailedResponseAssertion(t)
+	}
+	return SendRequest(t, client, request)
+}
+
+// buildServerRequest builds the server request. Default options are applied before the content type
+// and the options of the builder, so they can be overridden by the specific request.
+func (b *RequestBuilder) buildServerRequest(basePath string, defaults ...RequestOption) (*http.Request, error) {
+	return b.build(basePath, defaults, func(method, target string, body io.Reader) (*http.Request, error) {
+		return httptest.NewRequest(method, target, body), nil
+	})
+}
+
+func (b *RequestBuilder) build(
+	basePath string,
+	defaults []RequestOption,
+	newRequest func(method, target string, body io.Reader) (*http.Request, error),
+) (*http.Request, error) {
+	u, err := url.Parse(basePath + b.url)
+	if err != nil {
+		return nil, fmt.Errorf("parse url: %w", err)
+	}
+	if len(b.query) > 0 {
+		query := u.Query()
+		for key, values := range b.query {
+			for _, value := range values {
+				query.Add(key, value)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	var body io.Reader
+	contentType := ""
+	if b.body != nil {
+		body, contentType, err = b.body()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	request, err := newRequest(b.method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for _, setUpRequest := range defaults {
+		setUpRequest(request)
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	for _, setUpRequest := range b.options {
+		setUpRequest(request)
+	}
+
+	return request, nil
+}
+
+func (b *RequestBuilder) encodeMultipart() (io.Reader, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for _, part := range b.parts {
+		if part.content == nil {
+			if err := writer.WriteField(part.field, part.value); err != nil {
+				return nil, "", fmt.Errorf("write multipart field %q: %w", part.field, err)
+			}
+			continue
+		}
+		file, err := writer.CreateFormFile(part.field, part.filename)
+		if err != nil {
+			return nil, "", fmt.Errorf("create multipart file %q: %w", part.field, err)
+		}
+		if _, err := io.Copy(file, part.content); err != nil {
+			return nil, "", fmt.Errorf("write multipart file %q: %w", part.field, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("close multipart writer: %w", err)
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
@@ -0,0 +1,168 @@
+package apitest_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/muonsoft/api-testing/apitest"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestBuilder(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *apitest.RequestBuilder
+		assertRequest func(t *testing.T, request *http.Request)
+	}{
+		{
+			name:    "raw body",
+			request: apitest.NewRequest(http.MethodPost, testURL).WithBody(strings.NewReader("testBody")),
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.Equal(t, http.MethodPost, request.Method)
+				assert.Equal(t, "", request.Header.Get("Content-Type"))
+				body, _ := io.ReadAll(request.Body)
+				assert.Equal(t, "testBody", string(body))
+			},
+		},
+		{
+			name:    "JSON from string",
+			request: apitest.NewRequest(http.MethodPost, testURL).WithJSON(`{"key":"value"}`),
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.Equal(t, "application/json; charset=utf-8", request.Header.Get("Content-Type"))
+				body, _ := io.ReadAll(request.Body)
+				assert.Equal(t, `{"key":"value"}`, string(body))
+			},
+		},
+		{
+			name: "JSON from value",
+			request: apitest.NewRequest(http.MethodPut, testURL).WithJSON(struct {
+				Key string `json:"key"`
+			}{Key: "value"}),
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.Equal(t, "application/json; charset=utf-8", request.Header.Get("Content-Type"))
+				body, _ := io.ReadAll(request.Body)
+				assert.Equal(t, `{"key":"value"}`, string(body))
+			},
+		},
+		{
+			name: "form",
+			request: apitest.NewRequest(http.MethodPost, testURL).
+				WithForm(url.Values{"name": {"value"}}),
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.Equal(t, "application/x-www-form-urlencoded", request.Header.Get("Content-Type"))
+				require.NoError(t, request.ParseForm())
+				assert.Equal(t, "value", request.PostForm.Get("name"))
+			},
+		},
+		{
+			name: "multipart",
+			request: apitest.NewRequest(http.MethodPost, testURL).
+				WithMultipartField("name", "value").
+				WithMultipartFile("file", "file.txt", strings.NewReader("content")),
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.True(t, strings.HasPrefix(request.Header.Get("Content-Type"), "multipart/form-data; boundary="))
+				require.NoError(t, request.ParseMultipartForm(1024))
+				assert.Equal(t, "value", request.FormValue("name"))
+				file, header, err := request.FormFile("file")
+				require.NoError(t, err)
+				content, _ := io.ReadAll(file)
+				assert.Equal(t, "file.txt", header.Filename)
+				assert.Equal(t, "content", string(content))
+			},
+		},
+		{
+			name: "query",
+			request: apitest.NewRequest(http.MethodGet, testURL+"?page=1").
+				WithQuery("filter", "a").
+				WithQueryValues(url.Values{"filter": {"b"}}),
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.Equal(t, "1", request.URL.Query().Get("page"))
+				assert.Equal(t, []string{"a", "b"}, request.URL.Query()["filter"])
+			},
+		},
+		{
+			name: "headers and options",
+			request: apitest.NewRequest(http.MethodGet, testURL).
+				WithHeader("X-Test", "value").
+				WithOptions(apitest.WithCookie(&http.Cookie{Name: "name", Value: "value"})),
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.Equal(t, "value", request.Header.Get("X-Test"))
+				cookie, err := request.Cookie("name")
+				require.NoError(t, err)
+				assert.Equal(t, "value", cookie.Value)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+			handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				test.assertRequest(t, request)
+				writer.WriteHeader(http.StatusOK)
+			})
+
+			test.request.Handle(tester, handler).IsOK()
+
+			tester.AssertContains(t, nil)
+		})
+	}
+}
+
+func TestRequestBuilder_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "application/json; charset=utf-8", request.Header.Get("Content-Type"))
+		assert.Equal(t, "value", request.URL.Query().Get("key"))
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	tester := &mock.Tester{}
+
+	apitest.NewRequest(http.MethodPost, server.URL+testURL).
+		WithQuery("key", "value").
+		WithJSON([]int{1, 2}).
+		Send(tester, server.Client()).
+		IsOK()
+
+	tester.AssertContains(t, nil)
+}
+
+func TestRequestBuilder_WhenInvalidJSONValue_ExpectFailure(t *testing.T) {
+	tester := &mock.Tester{}
+
+	apitest.NewRequest(http.MethodPost, testURL).
+		WithJSON(func() {}).
+		Handle(tester, http.NotFoundHandler())
+
+	tester.AssertContains(t, []string{
+		"failed to build request POST /users: marshal JSON: json: unsupported type: func()",
+	})
+}
+
+func TestClient_Handle(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/api/users?page=2", request.URL.String())
+		assert.Equal(t, "value", request.Header.Get("X-Default"))
+		writer.WriteHeader(http.StatusOK)
+	})
+	tester := &mock.Tester{}
+	client := apitest.NewClient(handler).
+		WithBasePath("/api").
+		WithDefaultOptions(apitest.WithHeader("X-Default", "value"))
+
+	client.Handle(tester, apitest.NewRequest(http.MethodGet, testURL).WithQuery("page", "2")).IsOK()
+
+	tester.AssertContains(t, nil)
+}
@@ -0,0 +1,41 @@
+package apitest
+
+import (
+	"net/http"
+
+	"github.com/muonsoft/api-testing/internal/failfast"
+)
+
+// RequireT is a TestingT that can stop the test, it is implemented by *testing.T.
+type RequireT interface {
+	TestingT
+	FailNow()
+}
+
+// RequireHandleRequest works like HandleRequest, but the test is stopped by calling FailNow
+// on the first failed assertion of the request contract or of the returned response.
+func RequireHandleRequest(t RequireT, handler http.Handler, request *http.Request) *ResponseAssertion {
+	t.Helper()
+	return HandleRequest(failfast.New(t), handler, request)
+}
+
+// Require returns the response assertion that stops the test by calling FailNow on the first
+// failed assertion, including assertions of the JSON and XML body. If the underlying TestingT
+// cannot stop the test, the response assertion is returned as is.
+func (r *ResponseAssertion) Require() *ResponseAssertion {
+	r.t.Helper()
+	if t, ok := r.t.(RequireT); ok {
+		required := *r
+		required.t = failfast.New(t)
+		return &required
+	}
+
+	return r
+}
+
+// Require wraps the test so that it is stopped by calling FailNow on the first failed assertion.
+// It can be passed to any function of apitest, assertjson, assertxml and soap packages,
+// for example to the methods of Client and Server.
+func Require(t RequireT) TestingT {
+	return failfast.New(t)
+}
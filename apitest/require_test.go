@@ -0,0 +1,77 @@
+package apitest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/muonsoft/api-testing/apitest"
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireHandleRequest(t *testing.T) {
+	tester := &mock.Tester{}
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+		writer.Write([]byte(`{"error":"not found"}`))
+	})
+	executed := false
+
+	tester.Run(func() {
+		response := apitest.RequireHandleRequest(tester, handler, httptest.NewRequest(http.MethodGet, "/", nil))
+		response.HasJSON(func(json *assertjson.AssertJSON) {
+			json.Node("id").IsInteger()
+		})
+		executed = true
+	})
+
+	assert.True(t, tester.Stopped())
+	assert.False(t, executed)
+	tester.AssertContains(t, []string{`failed to find JSON node "id": [id] not found`})
+}
+
+func TestResponseAssertion_Require(t *testing.T) {
+	tester := &mock.Tester{}
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+	})
+	executed := false
+
+	tester.Run(func() {
+		response := apitest.HandleRequest(tester, handler, httptest.NewRequest(http.MethodGet, "/", nil))
+		response.HasHeader("Content-Type", "application/json")
+		response.Require().IsOK()
+		executed = true
+	})
+
+	assert.True(t, tester.Stopped())
+	assert.False(t, executed)
+	tester.AssertContains(t, []string{
+		`response does not contain header "Content-Type"`,
+		"expected status code: 200 (OK), actual is: 404 (Not Found)",
+		"HTTP/1.1 404 Not Found",
+	})
+}
+
+func TestRequire(t *testing.T) {
+	tester := &mock.Tester{}
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+	})
+	client := apitest.NewClient(handler)
+	executed := false
+
+	tester.Run(func() {
+		client.GET(apitest.Require(tester), "/users").IsOK()
+		executed = true
+	})
+
+	assert.True(t, tester.Stopped())
+	assert.False(t, executed)
+	tester.AssertContains(t, []string{
+		"expected status code: 200 (OK), actual is: 404 (Not Found)",
+		"HTTP/1.1 404 Not Found",
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/muonsoft/api-testing/assertjson"
 	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/muonsoft/api-testing/internal/failfast"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,14 +17,30 @@ import (
 type ResponseAssertion struct {
 	t        TestingT
 	recorder *httptest.ResponseRecorder
+	request  *http.Request
+	response *http.Response
+	// soft is set for the response inside the Soft scope
+	soft bool
 }
 
-// Recorder returns underlying httptest.ResponseRecorder.
+// Recorder returns underlying httptest.ResponseRecorder. For the requests sent
+// to the running server it holds a copy of the received response.
 func (r *ResponseAssertion) Recorder() *httptest.ResponseRecorder {
 	r.t.Helper()
 	return r.recorder
 }
 
+// Response returns received HTTP response. For the requests sent by SendRequest
+// it is an original response with already consumed body (use Recorder to access body),
+// otherwise it is a result of httptest.ResponseRecorder.
+func (r *ResponseAssertion) Response() *http.Response {
+	r.t.Helper()
+	if r.response != nil {
+		return r.response
+	}
+	return r.recorder.Result()
+}
+
 // Code returns HTTP status code of the response.
 func (r *ResponseAssertion) Code() int {
 	r.t.Helper()
@@ -47,14 +64,17 @@ func (r *ResponseAssertion) Cookies() []*http.Cookie {
 func (r *ResponseAssertion) HasCode(code int) {
 	r.t.Helper()
 	if r.recorder.Code != code {
-		assert.Fail(r.t, fmt.Sprintf(
-			"expected status code: %d (%s), actual is: %d (%s)",
-			code,
-			http.StatusText(code),
-			r.recorder.Code,
-			http.StatusText(r.recorder.Code),
-		))
-		r.logResponse()
+		failfast.Postpone(r.t, func() {
+			r.t.Helper()
+			assert.Fail(r.t, fmt.Sprintf(
+				"expected status code: %d (%s), actual is: %d (%s)",
+				code,
+				http.StatusText(code),
+				r.recorder.Code,
+				http.StatusText(r.recorder.Code),
+			))
+			r.logResponse()
+		})
 	}
 }
 
@@ -173,9 +193,10 @@ func (r *ResponseAssertion) HasContentType(contentType string) {
 }
 
 // HasJSON asserts that the response body contains JSON and runs JSON assertions by callback function.
-func (r *ResponseAssertion) HasJSON(jsonAssert assertjson.JSONAssertFunc) {
+// Parsing of JSON can be set up by options like assertjson.UseNumber().
+func (r *ResponseAssertion) HasJSON(jsonAssert assertjson.JSONAssertFunc, options ...assertjson.ParseOption) {
 	r.t.Helper()
-	assertjson.Has(r.t, r.recorder.Body.Bytes(), jsonAssert)
+	assertjson.Has(r.t, r.recorder.Body.Bytes(), jsonAssert, options...)
 }
 
 // HasXML asserts that the response body contains XML and runs XML assertions by callback function.
@@ -202,6 +223,22 @@ func (r *ResponseAssertion) HasCookie(name string) *CookieAssertion {
 	return nil
 }
 
+// Soft runs assertions on the response in soft mode: failures are collected instead of being
+// reported immediately. When the callback returns, all failures are reported as one error
+// with the summary and the response dump.
+func (r *ResponseAssertion) Soft(assertResponse func(response *ResponseAssertion)) {
+	r.t.Helper()
+	soft := assertjson.NewSoftAssertions(r.t)
+	assertResponse(&ResponseAssertion{
+		t:        soft,
+		recorder: r.recorder,
+		request:  r.request,
+		response: r.response,
+		soft:     true,
+	})
+	soft.Report(r.dump())
+}
+
 // Print prints response headers and body to console. Use it for debug purposes.
 func (r *ResponseAssertion) Print() {
 	r.t.Helper()
@@ -225,23 +262,31 @@ func (r *ResponseAssertion) PrintJSON() {
 }
 
 func (r *ResponseAssertion) logResponse() {
+	r.t.Helper()
+	// the response is dumped once by the summary of the soft assertions
+	if r.soft {
+		return
+	}
+	r.t.Log(r.dump())
+}
+
+func (r *ResponseAssertion) dump() string {
 	r.t.Helper()
 	headers := r.formatHeaders()
 	var body interface{}
 	err := json.Unmarshal(r.recorder.Body.Bytes(), &body)
 	if err != nil {
-		r.t.Log(headers + r.recorder.Body.String())
-		return
+		return headers + r.recorder.Body.String()
 	}
 	printableJSON, _ := json.MarshalIndent(body, "", "\t")
-	r.t.Log(headers + string(printableJSON))
+	return headers + string(printableJSON)
 }
 
 func (r *ResponseAssertion) formatHeaders() string {
 	r.t.Helper()
 	s := &strings.Builder{}
 	s.WriteString("\n")
-	fmt.Fprintln(s, r.recorder.Result().Proto, r.recorder.Result().Status)
+	fmt.Fprintf(s, "%s %03d %s\n", r.Response().Proto, r.recorder.Code, http.StatusText(r.recorder.Code))
 	for name, values := range r.recorder.Header() {
 		fmt.Fprintf(s, "%s: %s\n", name, strings.Join(values, "; "))
 	}
@@ -309,6 +309,22 @@ func TestAssertResponse(t *testing.T) {
 				`failed asserting that JSON node "ok" is false`,
 			},
 		},
+		{
+			name: "HasJSON with strict parsing failed",
+			writeResponse: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"ok":true,"ok":false}`))
+				w.WriteHeader(http.StatusOK)
+			},
+			assert: func(response *apitest.ResponseAssertion) {
+				response.HasJSON(func(json *assertjson.AssertJSON) {
+					json.Node("ok").IsFalse()
+				}, assertjson.StrictParsing())
+			},
+			wantMessages: []string{
+				`data has invalid JSON: duplicate key at "ok"`,
+			},
+		},
 		{
 			name: "HasCookie passed",
 			writeResponse: func(w http.ResponseWriter) {
@@ -371,6 +387,40 @@ func TestAssertResponse(t *testing.T) {
 				`failed asserting that cookie "testCookie" value equal to "testValue", actual is "invalid"`,
 			},
 		},
+		{
+			name: "Soft passed",
+			writeResponse: func(w http.ResponseWriter) {
+				w.Write([]byte(`{"ok":true}`))
+			},
+			assert: func(response *apitest.ResponseAssertion) {
+				response.Soft(func(response *apitest.ResponseAssertion) {
+					response.IsOK()
+					response.HasJSON(func(json *assertjson.AssertJSON) {
+						json.Node("ok").IsTrue()
+					})
+				})
+			},
+		},
+		{
+			name: "Soft failed",
+			writeResponse: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"ok":false}`))
+			},
+			assert: func(response *apitest.ResponseAssertion) {
+				response.Soft(func(response *apitest.ResponseAssertion) {
+					response.IsOK()
+					response.HasContentType("text/html")
+					response.HasJSON(func(json *assertjson.AssertJSON) {
+						json.Node("ok").IsTrue()
+					})
+				})
+			},
+			wantMessages: []string{
+				"soft assertions: 3 failed\n#1:\n",
+			},
+		},
 		{
 			name: "Print",
 			writeResponse: func(w http.ResponseWriter) {
@@ -420,3 +470,24 @@ const tokenSecret = "your-256-bit-secret"
 func getJWTSecret(_ *jwt.Token) (interface{}, error) {
 	return []byte(tokenSecret), nil
 }
+
+func TestResponseAssertion_Soft(t *testing.T) {
+	tester := &mock.Tester{}
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusBadRequest)
+		writer.Write([]byte(`{"ok":false}`))
+	})
+	response := apitest.HandleRequest(tester, handler, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	response.Soft(func(response *apitest.ResponseAssertion) {
+		response.IsOK()
+		response.HasJSON(func(json *assertjson.AssertJSON) {
+			json.Node("ok").IsTrue()
+		})
+	})
+
+	tester.AssertContains(t, []string{"expected status code: 200 (OK), actual is: 400 (Bad Request)"})
+	tester.AssertContains(t, []string{`#2 at "ok":`})
+	tester.AssertContains(t, []string{"HTTP/1.1 400 Bad Request\nContent-Type: application/json\n{\n\t\"ok\": false\n}"})
+}
@@ -0,0 +1,141 @@
+package apitest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Server is used to test a running HTTP server by sending real requests over the network.
+// Unlike HandleRequest, requests pass through the whole HTTP stack, so it is possible
+// to test TLS, transport headers (like "Content-Length"), connection reuse and middlewares
+// that depend on the remote address.
+type Server struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewServer creates a Server that sends requests to the base URL by the HTTP client.
+// If the client is nil, then http.DefaultClient is used.
+func NewServer(baseURL string, client *http.Client) *Server {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Server{baseURL: strings.TrimSuffix(baseURL, "/"), client: client}
+}
+
+// NewTestServer creates a Server for the locally started httptest.Server.
+// It uses the client returned by httptest.Server.Client(), so TLS servers are supported as well.
+func NewTestServer(server *httptest.Server) *Server {
+	return NewServer(server.URL, server.Client())
+}
+
+// URL returns base URL of the server.
+func (s *Server) URL() string {
+	return s.baseURL
+}
+
+// Client returns underlying HTTP client.
+func (s *Server) Client() *http.Client {
+	return s.client
+}
+
+// Send sends the request to the server. The request must have an absolute URL.
+// This function returns ResponseAssertion struct as a helper to build assertions on the response.
+func (s *Server) Send(t TestingT, request *http.Request) *ResponseAssertion {
+	t.Helper()
+	return SendRequest(t, s.client, request)
+}
+
+// GET builds the GET request from url (relative to the base URL) and options and sends it to the server.
+func (s *Server) GET(t TestingT, url string, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	return s.send(t, http.MethodGet, url, nil, options...)
+}
+
+// POST builds the POST request from url (relative to the base URL), body and options and sends it to the server.
+func (s *Server) POST(t TestingT, url string, body io.Reader, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	return s.send(t, http.MethodPost, url, body, options...)
+}
+
+// PUT builds the PUT request from url (relative to the base URL), body and options and sends it to the server.
+func (s *Server) PUT(t TestingT, url string, body io.Reader, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	return s.send(t, http.MethodPut, url, body, options...)
+}
+
+// PATCH builds the PATCH request from url (relative to the base URL), body and options and sends it to the server.
+func (s *Server) PATCH(t TestingT, url string, body io.Reader, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	return s.send(t, http.MethodPatch, url, body, options...)
+}
+
+// DELETE builds the DELETE request from url (relative to the base URL) and options and sends it to the server.
+func (s *Server) DELETE(t TestingT, url string, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	return s.send(t, http.MethodDelete, url, nil, options...)
+}
+
+func (s *Server) send(t TestingT, method, url string, body io.Reader, options ...RequestOption) *ResponseAssertion {
+	t.Helper()
+	request, err := http.NewRequestWithContext(context.Background(), method, s.baseURL+url, body)
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("failed to create request %s %s: %s", method, url, err.Error()))
+		return newFailedResponseAssertion(t)
+	}
+	for _, setUpRequest := range options {
+		setUpRequest(request)
+	}
+	return SendRequest(t, s.client, request)
+}
+
+// SendRequest is used to test a running HTTP server by sending the request with the HTTP client.
+// If the client is nil, then http.DefaultClient is used. The request must have an absolute URL.
+// This function returns ResponseAssertion struct as a helper to build assertions on the response.
+// Received response is copied into httptest.ResponseRecorder, so all assertions work
+// the same way as for HandleRequest.
+func SendRequest(t TestingT, client *http.Client, request *http.Request) *ResponseAssertion {
+	t.Helper()
+	if client == nil {
+		client = http.DefaultClient
+	}
+	assertRequestContract(t, request)
+
+	response, err := client.Do(request)
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("failed to send request %s %s: %s", request.Method, request.URL, err.Error()))
+		return newFailedResponseAssertion(t)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("failed to read response body: %s", err.Error()))
+	}
+
+	recorder := httptest.NewRecorder()
+	for key, values := range response.Header {
+		recorder.Header()[key] = values
+	}
+	recorder.WriteHeader(response.StatusCode)
+	recorder.Body.Write(body)
+
+	return &ResponseAssertion{t: t, recorder: recorder, request: request, response: response}
+}
+
+// newFailedResponseAssertion creates an assertion for a request that got no response.
+// It has a zero status code, so all status code assertions will fail.
+func newFailedResponseAssertion(t TestingT) *ResponseAssertion {
+	return &ResponseAssertion{
+		t:        t,
+		recorder: &httptest.ResponseRecorder{HeaderMap: make(http.Header), Body: new(bytes.Buffer)},
+	}
+}
@@ -0,0 +1,128 @@
+package apitest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/muonsoft/api-testing/apitest"
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name          string
+		send          func(t *mock.Tester, server *apitest.Server) *apitest.ResponseAssertion
+		assertRequest func(t *testing.T, request *http.Request)
+	}{
+		{
+			name: "GET",
+			send: func(t *mock.Tester, server *apitest.Server) *apitest.ResponseAssertion {
+				return server.GET(t, testURL, apitest.WithHeader("X-Test", "value"))
+			},
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.Equal(t, http.MethodGet, request.Method)
+				assert.Equal(t, testURL, request.URL.String())
+				assert.Equal(t, "value", request.Header.Get("X-Test"))
+				assert.NotEmpty(t, request.RemoteAddr)
+			},
+		},
+		{
+			name: "POST",
+			send: func(t *mock.Tester, server *apitest.Server) *apitest.ResponseAssertion {
+				return server.POST(t, testURL, strings.NewReader("testBody"))
+			},
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.Equal(t, http.MethodPost, request.Method)
+				assert.Equal(t, int64(8), request.ContentLength)
+			},
+		},
+		{
+			name: "PUT",
+			send: func(t *mock.Tester, server *apitest.Server) *apitest.ResponseAssertion {
+				return server.PUT(t, testURL, strings.NewReader("testBody"))
+			},
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.Equal(t, http.MethodPut, request.Method)
+			},
+		},
+		{
+			name: "PATCH",
+			send: func(t *mock.Tester, server *apitest.Server) *apitest.ResponseAssertion {
+				return server.PATCH(t, testURL, strings.NewReader("testBody"))
+			},
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.Equal(t, http.MethodPatch, request.Method)
+			},
+		},
+		{
+			name: "DELETE",
+			send: func(t *mock.Tester, server *apitest.Server) *apitest.ResponseAssertion {
+				return server.DELETE(t, testURL)
+			},
+			assertRequest: func(t *testing.T, request *http.Request) {
+				t.Helper()
+				assert.Equal(t, http.MethodDelete, request.Method)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				test.assertRequest(t, request)
+				writer.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			test.send(tester, apitest.NewTestServer(server)).IsOK()
+
+			tester.AssertContains(t, nil)
+		})
+	}
+}
+
+func TestSendRequest(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusCreated)
+		writer.Write([]byte(`{"tls":true}`))
+	}))
+	defer server.Close()
+	tester := &mock.Tester{}
+	request := httptest.NewRequest(http.MethodGet, server.URL+testURL, nil)
+	request.RequestURI = ""
+
+	response := apitest.SendRequest(tester, server.Client(), request)
+
+	response.IsCreated()
+	response.HasContentType("application/json")
+	response.HasHeader("Content-Length", "12")
+	response.HasJSON(func(json *assertjson.AssertJSON) {
+		json.Node("tls").IsTrue()
+	})
+	assert.NotNil(t, response.Response().TLS)
+	tester.AssertContains(t, nil)
+}
+
+func TestSendRequest_WhenServerIsUnavailable_ExpectFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	tester := &mock.Tester{}
+
+	response := apitest.NewTestServer(server).GET(tester, testURL)
+	response.IsOK()
+
+	tester.AssertContains(t, []string{
+		"failed to send request GET " + server.URL + testURL,
+		"expected status code: 200 (OK), actual is: 0 ()",
+		"HTTP/1.1 000 ",
+	})
+}
@@ -0,0 +1,115 @@
+package apitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/jsondiff"
+	"github.com/muonsoft/api-testing/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+// WithSnapshotHeaders sets the list of HTTP headers stored in the response snapshot.
+// By default, only "Content-Type" header is stored.
+func WithSnapshotHeaders(names ...string) assertjson.SnapshotOption {
+	return func(options *snapshot.Options) {
+		options.Headers = names
+	}
+}
+
+// MatchesSnapshot asserts that the response matches the snapshot stored in "testdata/{name}.snapshot" file.
+// Snapshot contains status code, selected headers (see WithSnapshotHeaders) and the body.
+// JSON body is normalized (indented with sorted keys) and volatile values are replaced by masks
+// (see assertjson.MaskNode, assertjson.MaskUUIDs, etc.). Run tests with UPDATE_SNAPSHOTS=1
+// environment variable to rewrite the snapshot file.
+func (r *ResponseAssertion) MatchesSnapshot(name string, options ...assertjson.SnapshotOption) {
+	r.t.Helper()
+	opts := &snapshot.Options{Headers: []string{"Content-Type"}}
+	for _, setOption := range options {
+		setOption(opts)
+	}
+	if len(opts.Errors) > 0 {
+		for _, err := range opts.Errors {
+			assert.Fail(r.t, err.Error())
+		}
+		return
+	}
+
+	actual, err := r.formatSnapshot(opts)
+	if err != nil {
+		assert.Fail(r.t, fmt.Sprintf("failed to normalize response: %s", err.Error()))
+		return
+	}
+
+	filename := snapshot.Filename(name, ".snapshot")
+	if snapshot.IsUpdate() {
+		if err := snapshot.Save(filename, actual); err != nil {
+			assert.Fail(r.t, err.Error())
+		}
+		return
+	}
+
+	expected, err := snapshot.Load(filename)
+	if err != nil {
+		assert.Fail(r.t, err.Error())
+		return
+	}
+	if !bytes.Equal(expected, actual) {
+		r.failSnapshot(fmt.Sprintf(`failed asserting that response matches snapshot "%s"`, filename), expected, actual)
+	}
+}
+
+// failSnapshot reports the difference between the snapshots. Status line and headers are compared
+// as text, JSON bodies are compared structurally.
+func (r *ResponseAssertion) failSnapshot(message string, expected, actual []byte) {
+	r.t.Helper()
+	expectedHead, expectedBody := splitSnapshot(expected)
+	actualHead, actualBody := splitSnapshot(actual)
+	if !bytes.Equal(expectedHead, actualHead) {
+		assert.Equal(r.t, string(expected), string(actual), message)
+		return
+	}
+
+	differences, ok := snapshot.CompareJSON(expectedBody, actualBody)
+	if !ok {
+		assert.Equal(r.t, string(expected), string(actual), message)
+	} else if len(differences) > 0 {
+		assert.Fail(r.t, message+", differences in body:\n"+jsondiff.Format(differences))
+	}
+}
+
+func splitSnapshot(data []byte) ([]byte, []byte) {
+	i := bytes.Index(data, []byte("\n\n"))
+	if i < 0 {
+		return data, nil
+	}
+	return data[:i], data[i+2:]
+}
+
+func (r *ResponseAssertion) formatSnapshot(options *snapshot.Options) ([]byte, error) {
+	s := &bytes.Buffer{}
+	fmt.Fprintf(s, "%d %s\n", r.recorder.Code, http.StatusText(r.recorder.Code))
+	for _, name := range options.Headers {
+		if values := r.recorder.Header().Values(name); len(values) > 0 {
+			fmt.Fprintf(s, "%s: %s\n", http.CanonicalHeaderKey(name), strings.Join(values, ", "))
+		}
+	}
+	s.WriteString("\n")
+
+	var body interface{}
+	if err := json.Unmarshal(r.recorder.Body.Bytes(), &body); err != nil {
+		s.Write(r.recorder.Body.Bytes())
+		return s.Bytes(), nil
+	}
+	normalized, err := snapshot.NormalizeJSON(body, options.Masks)
+	if err != nil {
+		return nil, err
+	}
+	s.Write(normalized)
+
+	return s.Bytes(), nil
+}
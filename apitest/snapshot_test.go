@@ -0,0 +1,106 @@
+package apitest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/muonsoft/api-testing/apitest"
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+)
+
+func TestResponseAssertion_MatchesSnapshot(t *testing.T) {
+	tests := []struct {
+		name          string
+		writeResponse func(w http.ResponseWriter)
+		assert        func(*apitest.ResponseAssertion)
+		wantMessages  []string
+	}{
+		{
+			name: "JSON response matches",
+			writeResponse: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("X-Request-Id", "ignored")
+				w.Header().Set("Cache-Control", "no-cache")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"name":"John","id":"a1f0c5d6-3b4e-4f5a-8b6c-7d8e9f0a1b2c"}`))
+			},
+			assert: func(response *apitest.ResponseAssertion) {
+				response.MatchesSnapshot(
+					"created",
+					apitest.WithSnapshotHeaders("Content-Type", "cache-control"),
+					assertjson.MaskUUIDs(),
+				)
+			},
+		},
+		{
+			name: "text response matches",
+			writeResponse: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.Write([]byte("plain text\n"))
+			},
+			assert: func(response *apitest.ResponseAssertion) {
+				response.MatchesSnapshot("text")
+			},
+		},
+		{
+			name: "status does not match",
+			writeResponse: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte("plain text\n"))
+			},
+			assert: func(response *apitest.ResponseAssertion) {
+				response.MatchesSnapshot("text")
+			},
+			wantMessages: []string{
+				`failed asserting that response matches snapshot "testdata/text.snapshot"`,
+			},
+		},
+		{
+			name: "JSON body does not match",
+			writeResponse: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Cache-Control", "no-cache")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"name":"Jane","id":"a1f0c5d6-3b4e-4f5a-8b6c-7d8e9f0a1b2c"}`))
+			},
+			assert: func(response *apitest.ResponseAssertion) {
+				response.MatchesSnapshot(
+					"created",
+					apitest.WithSnapshotHeaders("Content-Type", "Cache-Control"),
+					assertjson.MaskUUIDs(),
+				)
+			},
+			wantMessages: []string{
+				`failed asserting that response matches snapshot "testdata/created.snapshot", differences in body:`,
+			},
+		},
+		{
+			name: "snapshot does not exist",
+			writeResponse: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusOK)
+			},
+			assert: func(response *apitest.ResponseAssertion) {
+				response.MatchesSnapshot("missing")
+			},
+			wantMessages: []string{
+				`snapshot does not exist: "testdata/missing.snapshot"`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+			handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				test.writeResponse(writer)
+			})
+			response := apitest.HandleRequest(tester, handler, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			test.assert(response)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
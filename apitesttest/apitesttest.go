@@ -0,0 +1,157 @@
+// Package apitesttest provides a recording TestingT to test custom assertion helpers
+// (for example, reusable assertjson.JSONAssertFunc callbacks) built on top of the api-testing packages.
+// Recorder implements TestingT interfaces of apitest, assertjson, assertxml and assertions packages
+// and RequireT interfaces used by fail-fast variants.
+package apitesttest
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// Recorder is a TestingT that records errors, logs and helper calls instead of reporting them.
+// The zero value is ready to use.
+type Recorder struct {
+	errors      []string
+	logs        []string
+	helperCalls int
+	stopped     bool
+}
+
+// Helper records the call of the helper.
+func (r *Recorder) Helper() {
+	r.helperCalls++
+}
+
+// Error records the error.
+func (r *Recorder) Error(args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+// Errorf records the formatted error.
+func (r *Recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// Log records the log message.
+func (r *Recorder) Log(args ...interface{}) {
+	r.logs = append(r.logs, fmt.Sprint(args...))
+}
+
+// FailNow marks the recorder as stopped and stops the current goroutine.
+// Assertions that can call FailNow should be run by the Run method.
+func (r *Recorder) FailNow() {
+	r.stopped = true
+	runtime.Goexit()
+}
+
+// Run runs the function in a separate goroutine and waits for it, so the function
+// can be stopped by FailNow without stopping the test itself.
+func (r *Recorder) Run(f func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	<-done
+}
+
+// Errors returns the recorded errors.
+func (r *Recorder) Errors() []string {
+	return r.errors
+}
+
+// Logs returns the recorded log messages.
+func (r *Recorder) Logs() []string {
+	return r.logs
+}
+
+// HelperCalls returns the number of Helper calls.
+func (r *Recorder) HelperCalls() int {
+	return r.helperCalls
+}
+
+// Failed reports whether any error was recorded.
+func (r *Recorder) Failed() bool {
+	return len(r.errors) > 0
+}
+
+// Stopped reports whether FailNow was called.
+func (r *Recorder) Stopped() bool {
+	return r.stopped
+}
+
+// AssertPassed asserts that no errors were recorded.
+func (r *Recorder) AssertPassed(t testing.TB) {
+	t.Helper()
+	if len(r.errors) > 0 {
+		t.Errorf("failed asserting that no errors were recorded, actual errors:\n%s", r.formatErrors())
+	}
+}
+
+// AssertFailedWith asserts that at least one of the recorded errors contains the substring.
+func (r *Recorder) AssertFailedWith(t testing.TB, substring string) {
+	t.Helper()
+	for _, message := range r.errors {
+		if strings.Contains(message, substring) {
+			return
+		}
+	}
+	t.Errorf(
+		"failed asserting that recorded errors contain \"%s\", actual errors:\n%s",
+		substring,
+		r.formatErrors(),
+	)
+}
+
+// AssertFailedWithMessages asserts that the number of recorded errors is equal to the number
+// of substrings and each of the errors contains the substring at the same position.
+func (r *Recorder) AssertFailedWithMessages(t testing.TB, substrings ...string) {
+	t.Helper()
+	if len(r.errors) != len(substrings) {
+		t.Errorf(
+			"failed asserting that recorder has %d errors, actual count is %d:\n%s",
+			len(substrings),
+			len(r.errors),
+			r.formatErrors(),
+		)
+		return
+	}
+	for i, substring := range substrings {
+		if !strings.Contains(r.errors[i], substring) {
+			t.Errorf("failed asserting that recorded error %d contains \"%s\", actual:\n%s", i, substring, r.errors[i])
+		}
+	}
+}
+
+// AssertLogged asserts that at least one of the recorded log messages contains the substring.
+func (r *Recorder) AssertLogged(t testing.TB, substring string) {
+	t.Helper()
+	for _, message := range r.logs {
+		if strings.Contains(message, substring) {
+			return
+		}
+	}
+	t.Errorf(
+		"failed asserting that recorded logs contain \"%s\", actual logs:\n%s",
+		substring,
+		strings.Join(r.logs, "\n"),
+	)
+}
+
+// AssertStopped asserts that the test was stopped by FailNow.
+func (r *Recorder) AssertStopped(t testing.TB) {
+	t.Helper()
+	if !r.stopped {
+		t.Error("failed asserting that FailNow was called")
+	}
+}
+
+func (r *Recorder) formatErrors() string {
+	if len(r.errors) == 0 {
+		return "(none)"
+	}
+	return strings.Join(r.errors, "\n")
+}
@@ -0,0 +1,79 @@
+package apitesttest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/muonsoft/api-testing/apitest"
+	"github.com/muonsoft/api-testing/apitesttest"
+	"github.com/muonsoft/api-testing/assertions"
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ apitest.TestingT    = (*apitesttest.Recorder)(nil)
+	_ apitest.RequireT    = (*apitesttest.Recorder)(nil)
+	_ assertjson.TestingT = (*apitesttest.Recorder)(nil)
+	_ assertjson.RequireT = (*apitesttest.Recorder)(nil)
+	_ assertxml.TestingT  = (*apitesttest.Recorder)(nil)
+	_ assertxml.RequireT  = (*apitesttest.Recorder)(nil)
+	_ assertions.TestingT = (*apitesttest.Recorder)(nil)
+	_ assertions.RequireT = (*apitesttest.Recorder)(nil)
+)
+
+// hasUser is an example of the reusable helper under test.
+func hasUser(name string) assertjson.JSONAssertFunc {
+	return func(json *assertjson.AssertJSON) {
+		json.Node("id").IsInteger().GreaterThan(0)
+		json.Node("name").IsString().EqualTo(name)
+	}
+}
+
+func TestRecorder_JSONHelper(t *testing.T) {
+	recorder := &apitesttest.Recorder{}
+
+	assertjson.Has(recorder, []byte(`{"id":0,"name":"Alice"}`), hasUser("Bob"))
+
+	assert.True(t, recorder.Failed())
+	assert.Greater(t, recorder.HelperCalls(), 0)
+	recorder.AssertFailedWith(t, `failed asserting that JSON node "name": equal to "Bob", actual is "Alice"`)
+	recorder.AssertFailedWithMessages(
+		t,
+		`failed asserting that JSON node "id": greater than 0, actual is 0`,
+		`failed asserting that JSON node "name": equal to "Bob", actual is "Alice"`,
+	)
+}
+
+func TestRecorder_Passed(t *testing.T) {
+	recorder := &apitesttest.Recorder{}
+
+	assertjson.Has(recorder, []byte(`{"id":1,"name":"Bob"}`), hasUser("Bob"))
+
+	assert.False(t, recorder.Failed())
+	recorder.AssertPassed(t)
+}
+
+func TestRecorder_LogsAndFailNow(t *testing.T) {
+	recorder := &apitesttest.Recorder{}
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+	})
+	executed := false
+
+	recorder.Run(func() {
+		response := apitest.HandleRequest(recorder, handler, httptest.NewRequest(http.MethodGet, "/", nil))
+		response.Print()
+		response.Require().IsOK()
+		executed = true
+	})
+
+	assert.False(t, executed)
+	recorder.AssertStopped(t)
+	recorder.AssertLogged(t, "HTTP/1.1 404 Not Found")
+	recorder.AssertFailedWith(t, "expected status code: 200 (OK), actual is: 404 (Not Found)")
+	assert.Len(t, recorder.Logs(), 2)
+	assert.Len(t, recorder.Errors(), 1)
+}
@@ -0,0 +1,64 @@
+package assertions
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/fluent"
+)
+
+// WithInteger asserts that the string value is a decimal integer (for example, "-12").
+// It returns IntegerAssertion to execute a chain of assertions for the parsed value.
+func (a *StringAssertion) WithInteger(msgAndArgs ...interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	if value, ok := new(big.Int).SetString(strings.TrimSpace(a.value), 10); ok {
+		return NewIntegerAssertion(a.t, a.messagePrefix, value)
+	}
+
+	a.fail(fmt.Sprintf(`is integer, actual is "%s"`, a.value), msgAndArgs...)
+
+	return nil
+}
+
+// WithNumber asserts that the string value is a decimal number (for example, "-1.5e3").
+// It returns NumberAssertion to execute a chain of assertions for the parsed value.
+func (a *StringAssertion) WithNumber(msgAndArgs ...interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	s := strings.TrimSpace(a.value)
+	value, err := strconv.ParseFloat(s, 64)
+	if err == nil {
+		exact, _ := new(big.Rat).SetString(s)
+		return NewNumberAssertion(a.t, a.messagePrefix, value, exact)
+	}
+
+	a.fail(fmt.Sprintf(`is number, actual is "%s"`, a.value), msgAndArgs...)
+
+	return nil
+}
+
+// IntegerAssertion is used to build a chain of assertions for the integer value.
+type IntegerAssertion = fluent.IntegerAssertion
+
+// NewIntegerAssertion creates IntegerAssertion for the exact integer value. Values out of range
+// of int are compared without loss of precision, but Value returns 0 for them.
+func NewIntegerAssertion(t TestingT, messagePrefix string, value *big.Int) *IntegerAssertion {
+	return fluent.NewIntegerAssertion(t, messagePrefix, value)
+}
+
+// NumberAssertion is used to build a chain of assertions for the numeric value.
+type NumberAssertion = fluent.NumberAssertion
+
+// NewNumberAssertion creates NumberAssertion for the numeric value. The exact value is used
+// by EqualToDecimal assertion, if it is nil, then it is calculated by the shortest decimal
+// representation of the value.
+func NewNumberAssertion(t TestingT, messagePrefix string, value float64, exact *big.Rat) *NumberAssertion {
+	return fluent.NewNumberAssertion(t, messagePrefix, value, exact)
+}
@@ -0,0 +1,25 @@
+package assertions
+
+import (
+	"time"
+
+	"github.com/muonsoft/api-testing/internal/failfast"
+)
+
+// RequireT is a TestingT that can stop the test, it is implemented by *testing.T.
+type RequireT interface {
+	TestingT
+	FailNow()
+}
+
+// RequireString works like NewStringAssertion, but the test is stopped by calling FailNow
+// on the first failed assertion of the chain.
+func RequireString(t RequireT, messagePrefix string, value string) *StringAssertion {
+	return NewStringAssertion(failfast.New(t), messagePrefix, value)
+}
+
+// RequireTime works like NewTimeAssertion, but the test is stopped by calling FailNow
+// on the first failed assertion of the chain.
+func RequireTime(t RequireT, message string, value time.Time, layout string) *TimeAssertion {
+	return NewTimeAssertion(failfast.New(t), message, value, layout)
+}
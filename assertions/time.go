@@ -22,7 +22,7 @@ func (a *StringAssertion) WithTimeWithLayout(layout string, msgAndArgs ...interf
 	a.t.Helper()
 	t, err := time.Parse(layout, a.value)
 	if err == nil {
-		return &TimeAssertion{t: a.t, layout: layout, value: t}
+		return NewTimeAssertion(a.t, a.messagePrefix, t, layout)
 	}
 
 	a.fail(
@@ -0,0 +1,74 @@
+package assertions
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/muonsoft/api-testing/internal/fluent"
+	"github.com/muonsoft/api-testing/internal/is"
+)
+
+// WithEmail asserts that the string value is an email. Validation is based on simplified pattern.
+// It allows all values with an "@" symbol in, and a "." in the second host part of the email address.
+func (a *StringAssertion) WithEmail(msgAndArgs ...interface{}) *StringAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	if !is.Email(a.value) {
+		a.fail(fmt.Sprintf(`is email, actual is "%s"`, a.value), msgAndArgs...)
+	}
+
+	return a
+}
+
+// WithURL asserts that the string value is an URL.
+// It returns URLAssertion to execute a chain of assertions for the parsed value.
+func (a *StringAssertion) WithURL(msgAndArgs ...interface{}) *URLAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	u, err := url.Parse(a.value)
+	if err == nil && is.URL(a.value) {
+		return NewURLAssertion(a.t, a.messagePrefix, u)
+	}
+
+	a.fail(fmt.Sprintf(`is URL, actual is "%s"`, a.value), msgAndArgs...)
+
+	return nil
+}
+
+// WithUUID asserts that the string value is an UUID.
+// It returns UUIDAssertion to execute a chain of assertions for the parsed value.
+func (a *StringAssertion) WithUUID(msgAndArgs ...interface{}) *UUIDAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	id, err := uuid.FromString(a.value)
+	if err == nil {
+		return NewUUIDAssertion(a.t, a.messagePrefix, id)
+	}
+
+	a.fail(fmt.Sprintf(`is UUID, actual is "%s"`, a.value), msgAndArgs...)
+
+	return nil
+}
+
+// UUIDAssertion is used to build a chain of assertions for the UUID value.
+type UUIDAssertion = fluent.UUIDAssertion
+
+// NewUUIDAssertion creates UUIDAssertion for the UUID value.
+func NewUUIDAssertion(t TestingT, messagePrefix string, value uuid.UUID) *UUIDAssertion {
+	return fluent.NewUUIDAssertion(t, messagePrefix, value)
+}
+
+// URLAssertion is used to build a chain of assertions for the URL value.
+type URLAssertion = fluent.URLAssertion
+
+// NewURLAssertion creates URLAssertion for the parsed URL.
+func NewURLAssertion(t TestingT, messagePrefix string, value *url.URL) *URLAssertion {
+	return fluent.NewURLAssertion(t, messagePrefix, value)
+}
@@ -2,13 +2,36 @@ package assertjson
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/muonsoft/api-testing/internal/js"
+	"github.com/muonsoft/api-testing/internal/jsondiff"
 	"github.com/stretchr/testify/assert"
 )
 
+// SortOrder is used to set up the expected order of array elements by ArrayAssertion.IsSortedBy.
+type SortOrder int
+
+const (
+	// Ascending order: each element is less than or equal to the next one.
+	Ascending SortOrder = iota
+	// Descending order: each element is greater than or equal to the next one.
+	Descending
+)
+
+// String returns the name of the order.
+func (order SortOrder) String() string {
+	if order == Descending {
+		return "descending"
+	}
+	return "ascending"
+}
+
+var errNoMatchingElement = errors.New("no element matches the predicate")
+
 // IsArrayWithElementsCount asserts that the JSON node is an array with given elements count.
 // Deprecated: use IsArray().WithLength() instead.
 func (node *AssertNode) IsArrayWithElementsCount(count int, msgAndArgs ...interface{}) {
@@ -27,6 +50,7 @@ func (node *AssertNode) IsArray(msgAndArgs ...interface{}) *ArrayAssertion {
 				message: fmt.Sprintf(`%sfailed asserting that JSON node "%s": `, node.message, node.path.String()),
 				path:    node.path.String(),
 				value:   array,
+				node:    node,
 			}
 		}
 		node.fail(
@@ -44,6 +68,7 @@ type ArrayAssertion struct {
 	message string
 	path    string
 	value   []interface{}
+	node    *AssertNode
 }
 
 // WithLength asserts that the JSON node is an array with length equal to the given value.
@@ -197,6 +222,225 @@ func (a *ArrayAssertion) WithUniqueElements(msgAndArgs ...interface{}) *ArrayAss
 	return a
 }
 
+// ContainsElement asserts that the JSON node is an array containing the element
+// structurally equal to the expected JSON.
+func (a *ArrayAssertion) ContainsElement(expected string, msgAndArgs ...interface{}) *ArrayAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	var element interface{}
+	if err := unmarshal([]byte(expected), &element, true); err != nil {
+		a.fail(fmt.Sprintf("expected element is not valid JSON: %s", err.Error()), msgAndArgs...)
+		return a
+	}
+	for _, value := range a.value {
+		if len(jsondiff.Compare(nil, element, value)) == 0 {
+			return a
+		}
+	}
+	compact, _ := json.Marshal(element)
+	a.fail(fmt.Sprintf("contains element %s", compact), msgAndArgs...)
+
+	return a
+}
+
+// ContainsElementMatching asserts that the JSON node is an array containing at least one element
+// passing the assertions of the callback function. Failures of the other elements are not reported.
+func (a *ArrayAssertion) ContainsElementMatching(jsonAssert JSONAssertFunc, msgAndArgs ...interface{}) *ArrayAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	if a.indexOf(jsonAssert) < 0 {
+		a.fail(
+			fmt.Sprintf("contains element matching the assertion, none of %d elements passed", len(a.value)),
+			msgAndArgs...,
+		)
+	}
+
+	return a
+}
+
+// Any asserts that at least one element of the array passes the assertions of the callback function.
+// It is an alias for ContainsElementMatching.
+func (a *ArrayAssertion) Any(jsonAssert JSONAssertFunc, msgAndArgs ...interface{}) *ArrayAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	return a.ContainsElementMatching(jsonAssert, msgAndArgs...)
+}
+
+// Every executes the assertions of the callback function on each element of the array.
+// Failures are reported with the paths of the elements, the message (if it is set)
+// is prepended to them.
+func (a *ArrayAssertion) Every(jsonAssert JSONAssertFunc, msgAndArgs ...interface{}) *ArrayAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	message := a.node.message + formatMessage(msgAndArgs)
+	for i := range a.value {
+		element := a.element(a.t, i)
+		element.message = message
+		jsonAssert(element)
+	}
+
+	return a
+}
+
+// None asserts that none of the array elements passes the assertions of the callback function.
+func (a *ArrayAssertion) None(jsonAssert JSONAssertFunc, msgAndArgs ...interface{}) *ArrayAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	var passed []int
+	for i := range a.value {
+		r := &recorder{}
+		jsonAssert(a.element(r, i))
+		if !r.failed() {
+			passed = append(passed, i)
+		}
+	}
+	if len(passed) > 0 {
+		a.fail(
+			fmt.Sprintf(
+				"has no elements matching the assertion, matched elements: %s",
+				strings.Join(intsToStrings(passed), ", "),
+			),
+			msgAndArgs...,
+		)
+	}
+
+	return a
+}
+
+// IsSortedBy asserts that the JSON node is an array sorted in the given order by the values
+// at the path inside each element. The path is set the same way as for AssertJSON.Node(),
+// an empty path means that elements themselves are compared. Numbers and strings can be compared.
+func (a *ArrayAssertion) IsSortedBy(order SortOrder, path ...interface{}) *ArrayAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	path = preprocessPath(path)
+	relative, err := js.PathFromAny(path...)
+	if err != nil {
+		a.fail(fmt.Sprintf("parse path: %s", err.Error()))
+		return a
+	}
+
+	sortedBy := "is array sorted"
+	if relative != nil {
+		sortedBy = fmt.Sprintf(`is array sorted by "%s"`, relative.String())
+	}
+
+	var previous interface{}
+	for i := range a.value {
+		elementPath := a.node.path.WithIndex(i).With(relative)
+		value, err := getValueByPath(a.value[i], path...)
+		if err != nil {
+			a.fail(fmt.Sprintf(`%s, but failed to find JSON node "%s": %v`, sortedBy, elementPath.String(), err))
+			return a
+		}
+		if i > 0 {
+			cmp, ok := compareValues(previous, value)
+			if !ok {
+				a.fail(fmt.Sprintf(
+					`%s, but value at "%s" is not comparable with the previous one`,
+					sortedBy, elementPath.String(),
+				))
+				return a
+			}
+			if order == Ascending && cmp > 0 || order == Descending && cmp < 0 {
+				a.fail(fmt.Sprintf(
+					`%s in %s order, but value at "%s" is out of order: %s after %s`,
+					sortedBy, order, elementPath.String(), formatJSON(value), formatJSON(previous),
+				))
+				return a
+			}
+		}
+		previous = value
+	}
+
+	return a
+}
+
+// FindFirst returns the first element of the array passing the assertions of the predicate.
+// If there is no such element, assertions on the returned node fail the same way
+// as for a missing JSON node.
+func (a *ArrayAssertion) FindFirst(predicate JSONAssertFunc) *AssertNode {
+	if a == nil {
+		// failure of the node type has already been reported
+		return &AssertNode{t: &recorder{}, err: errNoMatchingElement}
+	}
+	a.t.Helper()
+
+	if i := a.indexOf(predicate); i >= 0 {
+		return &AssertNode{
+			t:         atPath(a.t, a.node.path.WithIndex(i)),
+			message:   a.node.message,
+			path:      a.node.path.WithIndex(i),
+			value:     a.value[i],
+			keys:      a.node.keys,
+			useNumber: a.node.useNumber,
+		}
+	}
+
+	return &AssertNode{t: a.t, message: a.node.message, path: a.node.path, err: errNoMatchingElement}
+}
+
+func (a *ArrayAssertion) indexOf(jsonAssert JSONAssertFunc) int {
+	for i := range a.value {
+		r := &recorder{}
+		jsonAssert(a.element(r, i))
+		if !r.failed() {
+			return i
+		}
+	}
+	return -1
+}
+
+func (a *ArrayAssertion) element(t TestingT, i int) *AssertJSON {
+	return &AssertJSON{
+		t:         atPath(t, a.node.path.WithIndex(i)),
+		message:   a.node.message,
+		path:      a.node.path.WithIndex(i),
+		data:      a.value[i],
+		keys:      a.node.keys,
+		useNumber: a.node.useNumber,
+	}
+}
+
+// compareValues compares numbers by values and strings lexicographically.
+func compareValues(x, y interface{}) (int, bool) {
+	if a, ok := toDecimal(x); ok {
+		b, ok := toDecimal(y)
+		if !ok {
+			return 0, false
+		}
+		return a.Cmp(b), true
+	}
+	if a, ok := x.(string); ok {
+		b, ok := y.(string)
+		return strings.Compare(a, b), ok
+	}
+	return 0, false
+}
+
+func formatJSON(value interface{}) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
 // Length returns array underlying array length.
 func (a *ArrayAssertion) Length() int {
 	if a == nil {
@@ -225,3 +469,16 @@ func intsToStrings(ints []int) []string {
 	}
 	return s
 }
+
+// formatMessage formats message and arguments the same way as testify does
+// to use them as a prefix of failure messages.
+func formatMessage(msgAndArgs []interface{}) string {
+	if len(msgAndArgs) == 0 {
+		return ""
+	}
+	if format, ok := msgAndArgs[0].(string); ok {
+		return fmt.Sprintf(format, msgAndArgs[1:]...) + ": "
+	}
+
+	return fmt.Sprint(msgAndArgs...) + ": "
+}
@@ -0,0 +1,155 @@
+package assertjson_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const collectionJSON = `{
+	"users": [
+		{"id": 1, "name": "Alice", "role": "admin", "tags": ["a"]},
+		{"id": 2, "name": "Bob", "role": "user", "tags": []},
+		{"id": 5, "name": "Carol", "role": "user", "tags": ["b"]}
+	],
+	"numbers": [3, 2, 2, 1]
+}`
+
+func TestArrayAssertion_Collections(t *testing.T) {
+	isAdmin := func(json *assertjson.AssertJSON) {
+		json.Node("role").IsString().EqualTo("admin")
+	}
+	isGuest := func(json *assertjson.AssertJSON) {
+		json.Node("role").IsString().EqualTo("guest")
+	}
+
+	tests := []struct {
+		name         string
+		assert       func(json *assertjson.AssertJSON)
+		wantMessages []string
+	}{
+		{
+			name: "passed",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("users").IsArray().
+					ContainsElement(`{"tags": [], "role": "user", "name": "Bob", "id": 2.0}`).
+					ContainsElementMatching(isAdmin).
+					Any(isAdmin).
+					None(isGuest).
+					Every(func(json *assertjson.AssertJSON) {
+						json.Node("id").IsInteger().GreaterThan(0)
+					}).
+					IsSortedBy(assertjson.Ascending, "id").
+					IsSortedBy(assertjson.Ascending, "name")
+				json.Node("numbers").IsArray().IsSortedBy(assertjson.Descending)
+			},
+		},
+		{
+			name: "contains failed",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("users").IsArray().ContainsElement(`{"id": 3}`)
+				json.Node("users").IsArray().ContainsElementMatching(isGuest)
+				json.Node("users").IsArray().None(func(json *assertjson.AssertJSON) {
+					json.Node("role").IsString().EqualTo("user")
+				})
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "users": contains element {"id":3}`,
+				`failed asserting that JSON node "users": contains element matching the assertion, none of 3 elements passed`,
+				`failed asserting that JSON node "users": has no elements matching the assertion, matched elements: 1, 2`,
+			},
+		},
+		{
+			name: "every failed",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("users").IsArray().Every(func(json *assertjson.AssertJSON) {
+					json.Node("tags").IsArray().WithLength(1)
+				})
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "users[1].tags": is array with length is 1, actual is 0`,
+			},
+		},
+		{
+			name: "every failed with message",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("users").IsArray().Every(func(json *assertjson.AssertJSON) {
+					json.Node("id").IsInteger().LessThan(5)
+				}, "user %s", "ids")
+			},
+			wantMessages: []string{
+				`user ids: failed asserting that JSON node "users[2].id": less than 5, actual is 5`,
+			},
+		},
+		{
+			name: "sorting failed",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("users").IsArray().IsSortedBy(assertjson.Descending, "id")
+				json.Node("numbers").IsArray().IsSortedBy(assertjson.Ascending)
+				json.Node("users").IsArray().IsSortedBy(assertjson.Ascending, "tags")
+				json.Node("users").IsArray().IsSortedBy(assertjson.Ascending, "email")
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "users": is array sorted by "id" in descending order, but value at "users[1].id" is out of order: 2 after 1`,
+				`failed asserting that JSON node "numbers": is array sorted in ascending order, but value at "numbers[1]" is out of order: 2 after 3`,
+				`failed asserting that JSON node "users": is array sorted by "tags", but value at "users[1].tags" is not comparable with the previous one`,
+				`failed asserting that JSON node "users": is array sorted by "email", but failed to find JSON node "users[0].email": [email] not found`,
+			},
+		},
+		{
+			name: "find first",
+			assert: func(json *assertjson.AssertJSON) {
+				user := json.Node("users").IsArray().FindFirst(func(json *assertjson.AssertJSON) {
+					json.Node("role").IsString().EqualTo("user")
+				})
+				user.Assert(func(json *assertjson.AssertJSON) {
+					json.Node("name").IsString().EqualTo("Bob")
+				})
+				json.Node("users").IsArray().FindFirst(isGuest).IsObject()
+				json.Node("numbers", 0).IsArray().FindFirst(isGuest).IsObject()
+			},
+			wantMessages: []string{
+				`failed to find JSON node "users": no element matches the predicate`,
+				`failed asserting that JSON node "numbers[0]" is array`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertjson.Has(tester, []byte(collectionJSON), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestArrayAssertion_FindFirst(t *testing.T) {
+	assertjson.Has(t, []byte(collectionJSON), func(json *assertjson.AssertJSON) {
+		user := json.Node("users").IsArray().FindFirst(func(json *assertjson.AssertJSON) {
+			json.Node("name").IsString().EqualTo("Carol")
+		})
+
+		user.Assert(func(json *assertjson.AssertJSON) {
+			assert.Equal(t, 5, json.Node("id").Integer())
+		})
+	})
+}
+
+func TestArrayAssertion_WhenPredicateRequiresNode_ExpectTestNotStopped(t *testing.T) {
+	isGuest := func(json *assertjson.AssertJSON) {
+		json.RequireNode("role").IsString().EqualTo("guest")
+	}
+
+	assertjson.Has(t, []byte(collectionJSON), func(json *assertjson.AssertJSON) {
+		users := json.Node("users").IsArray().None(isGuest)
+		users.FindFirst(func(json *assertjson.AssertJSON) {
+			json.RequireNode("role").IsString().EqualTo("user")
+		}).Assert(func(json *assertjson.AssertJSON) {
+			json.Node("name").IsString().EqualTo("Bob")
+		})
+	})
+}
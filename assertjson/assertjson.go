@@ -3,14 +3,15 @@
 package assertjson
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 
-	jsoniter "github.com/json-iterator/go"
 	"github.com/muonsoft/api-testing/internal/js"
+	"github.com/muonsoft/api-testing/internal/jsonstrict"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,6 +28,9 @@ type AssertJSON struct {
 	message string
 	path    *js.Path
 	data    interface{}
+	keys    objectKeys
+	// useNumber is set when the document is parsed with UseNumber option
+	useNumber bool
 }
 
 func NewAssertJSON(t TestingT, message string, data interface{}) *AssertJSON {
@@ -36,29 +40,60 @@ func NewAssertJSON(t TestingT, message string, data interface{}) *AssertJSON {
 // JSONAssertFunc - callback function used for asserting JSON nodes.
 type JSONAssertFunc func(json *AssertJSON)
 
+// ParseOption is used to set up parsing of JSON data by Has and FileHas functions.
+type ParseOption func(options *parseOptions)
+
+type parseOptions struct {
+	useNumber bool
+	strict    bool
+}
+
+// UseNumber decodes JSON numbers as json.Number instead of float64, so integers greater
+// than 2^53 do not lose precision and integer literals (1) are distinguished from float ones (1.0).
+// Use Int64(), BigInt() and Decimal() methods of AssertNode and EqualToDecimal() assertions
+// to access exact values.
+func UseNumber() ParseOption {
+	return func(options *parseOptions) {
+		options.useNumber = true
+	}
+}
+
+// StrictParsing makes parsing fail on problems that are silently accepted by encoding/json:
+// duplicate keys (each one is reported with its path), invalid UTF-8 and lone UTF-16 surrogates
+// in strings. Trailing data after the top-level value is always reported.
+func StrictParsing() ParseOption {
+	return func(options *parseOptions) {
+		options.strict = true
+	}
+}
+
 // FileHas loads JSON from file and runs user callback for testing its nodes.
-func FileHas(t TestingT, filename string, jsonAssert JSONAssertFunc) {
+func FileHas(t TestingT, filename string, jsonAssert JSONAssertFunc, options ...ParseOption) {
 	t.Helper()
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		assert.Fail(t, fmt.Sprintf(`failed to read file "%s": %s`, filename, err.Error()))
 	} else {
-		Has(t, data, jsonAssert)
+		Has(t, data, jsonAssert, options...)
 	}
 }
 
 // Has - loads JSON from byte slice and runs user callback for testing its nodes.
-func Has(t TestingT, data []byte, jsonAssert JSONAssertFunc) {
+func Has(t TestingT, data []byte, jsonAssert JSONAssertFunc, options ...ParseOption) {
 	t.Helper()
+	opts := &parseOptions{}
+	for _, option := range options {
+		option(opts)
+	}
 	body := &AssertJSON{t: t}
-	body.assert(data, jsonAssert)
+	body.assert(data, jsonAssert, opts)
 }
 
 // Node searches for JSON node by JSON Path Syntax. Returns struct for asserting the node values.
 func (j *AssertJSON) Node(path ...interface{}) *AssertNode {
 	j.t.Helper()
 
-	node := &AssertNode{t: j.t, message: j.message}
+	node := &AssertNode{t: j.t, message: j.message, keys: j.keys, useNumber: j.useNumber}
 
 	path = preprocessPath(path)
 	jspath, err := js.PathFromAny(path...)
@@ -66,6 +101,7 @@ func (j *AssertJSON) Node(path ...interface{}) *AssertNode {
 		node.fail(fmt.Sprintf("parse path: %s", err.Error()))
 	} else {
 		node.path = j.path.With(jspath)
+		node.t = atPath(j.t, node.path)
 		node.value, node.err = getValueByPath(j.data, path...)
 	}
 
@@ -83,7 +119,7 @@ func (j *AssertJSON) Nodef(format string, a ...interface{}) *AssertNode {
 // At is used to test assertions on some node in a batch. It returns AssertJSON object on that node.
 func (j *AssertJSON) At(path ...interface{}) *AssertJSON {
 	j.t.Helper()
-	a := &AssertJSON{t: j.t}
+	a := &AssertJSON{t: j.t, keys: j.keys, useNumber: j.useNumber}
 
 	path = preprocessPath(path)
 	jsPath, err := js.PathFromAny(path...)
@@ -91,10 +127,12 @@ func (j *AssertJSON) At(path ...interface{}) *AssertJSON {
 		a.fail(fmt.Sprintf("parse path: %s", err.Error()))
 	}
 	a.path = j.path.With(jsPath)
+	a.t = atPath(j.t, a.path)
 
 	a.data, err = getValueByPath(j.data, path...)
 	if err != nil {
-		j.fail(fmt.Sprintf(`failed to find JSON node "%s": %v`, a.path.String(), err))
+		a.t.Helper()
+		assert.Fail(a.t, j.message+fmt.Sprintf(`failed to find JSON node "%s": %v`, a.path.String(), err))
 	}
 
 	return a
@@ -108,14 +146,42 @@ func (j *AssertJSON) Atf(format string, a ...interface{}) *AssertJSON {
 	return j.At(fmt.Sprintf(format, a...))
 }
 
-func (j *AssertJSON) assert(data []byte, jsonAssert JSONAssertFunc) {
+func (j *AssertJSON) assert(data []byte, jsonAssert JSONAssertFunc, options *parseOptions) {
 	j.t.Helper()
-	err := json.Unmarshal(data, &j.data)
+	err := unmarshal(data, &j.data, options.useNumber)
 	if err != nil {
 		j.fail(fmt.Sprintf("data has invalid JSON: %s", err.Error()))
-	} else {
-		jsonAssert(j)
+		return
+	}
+	j.keys = scanObjectKeys(data)
+	j.useNumber = options.useNumber
+	if options.strict {
+		violations := jsonstrict.Check(data)
+		for _, violation := range violations {
+			j.fail(fmt.Sprintf("data has invalid JSON: %s", violation.String()))
+		}
+		if len(violations) > 0 {
+			return
+		}
 	}
+	jsonAssert(j)
+}
+
+// unmarshal decodes data the same way as json.Unmarshal, optionally decoding numbers as json.Number.
+func unmarshal(data []byte, value interface{}, useNumber bool) error {
+	if !useNumber {
+		return json.Unmarshal(data, value)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(value); err != nil {
+		return err
+	}
+	if rest := bytes.TrimSpace(data[decoder.InputOffset():]); len(rest) > 0 {
+		return fmt.Errorf("invalid character %q after top-level value", rest[0])
+	}
+
+	return nil
 }
 
 func (j *AssertJSON) fail(message string, msgAndArgs ...interface{}) {
@@ -166,21 +232,35 @@ func pathFromJSONPointer(p string) []interface{} {
 }
 
 func getValueByPath(data interface{}, path ...interface{}) (interface{}, error) {
-	v := jsoniter.Wrap(data)
-	for _, e := range path {
-		if _, ok := e.(int); ok {
-			if _, ok := v.GetInterface().(map[string]interface{}); ok {
+	jsPath, err := js.PathFromAny(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	value := data
+	for _, element := range jsPath.Elements() {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if element.IsIndex() {
 				return nil, fmt.Errorf("value of type int is not assignable to type string")
 			}
-		}
-
-		v = v.Get(e)
-		if v.LastError() != nil {
-			return nil, v.LastError()
+			next, exists := v[element.String()]
+			if !exists {
+				return nil, fmt.Errorf("[%s] not found", element.String())
+			}
+			value = next
+		case []interface{}:
+			index, isIndex := element.(js.ArrayIndex)
+			if !isIndex || int(index) < 0 || int(index) >= len(v) {
+				return nil, fmt.Errorf("[%s] not found", element.String())
+			}
+			value = v[index]
+		default:
+			return nil, fmt.Errorf("[%s] not found", element.String())
 		}
 	}
 
-	return v.GetInterface(), nil
+	return value, nil
 }
 
 func decodeReferenceToken(token string) string {
@@ -481,8 +481,7 @@ func TestHas(t *testing.T) {
 				json.Node("key").EqualJSON(`{"key": "value"}`)
 			},
 			wantMessages: []string{
-				"Not equal",
-				`failed at JSON node "key"`,
+				`failed asserting that JSON node "key" is equal to JSON, differences:`,
 			},
 		},
 		{
@@ -1,11 +1,10 @@
 package assertjson
 
 import (
-	"encoding/json"
 	"fmt"
 	"reflect"
 
-	"github.com/stretchr/testify/assert"
+	"github.com/muonsoft/api-testing/internal/jsondiff"
 )
 
 // Exists asserts that the JSON node exists. Returns true if node exists.
@@ -99,13 +98,26 @@ func (node *AssertNode) IsFalse(msgAndArgs ...interface{}) {
 	}
 }
 
-// EqualJSON asserts that node is equal to JSON string.
+// EqualJSON asserts that node is equal to JSON string. On failure, it reports the list of
+// added, removed and changed nodes with their paths.
 func (node *AssertNode) EqualJSON(expected string, msgAndArgs ...interface{}) {
 	node.t.Helper()
 	if node.exists() {
-		data, _ := json.Marshal(node.value)
-		if !assert.JSONEq(node.t, expected, string(data), msgAndArgs...) {
-			node.fail(fmt.Sprintf(`failed at JSON node "%s"`, node.path.String()))
+		var expectedValue interface{}
+		if err := unmarshal([]byte(expected), &expectedValue, true); err != nil {
+			node.fail(fmt.Sprintf("expected value is not valid JSON: %s", err.Error()), msgAndArgs...)
+			return
+		}
+		differences := jsondiff.Compare(node.path, expectedValue, node.value)
+		if len(differences) > 0 {
+			node.fail(
+				fmt.Sprintf(
+					"failed asserting that JSON node \"%s\" is equal to JSON, differences:\n%s",
+					node.path.String(),
+					jsondiff.Format(differences),
+				),
+				msgAndArgs...,
+			)
 		}
 	}
 }
@@ -0,0 +1,277 @@
+package assertjson
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/js"
+)
+
+// Decode decodes the JSON node value into the target, which must be a non-nil pointer.
+// Values of the node that cannot be decoded into the types of the target are reported
+// with their paths (like "items[0].id").
+func (node *AssertNode) Decode(target interface{}, msgAndArgs ...interface{}) {
+	node.t.Helper()
+	node.decode(target, false, msgAndArgs)
+}
+
+// DecodeStrict decodes the JSON node value into the target, which must be a non-nil pointer.
+// In addition to the type mismatches it reports properties unknown to the target structs
+// and missing required fields. Fields of structs are required unless they are pointers,
+// interfaces or have "omitempty" option in the json tag.
+func (node *AssertNode) DecodeStrict(target interface{}, msgAndArgs ...interface{}) {
+	node.t.Helper()
+	node.decode(target, true, msgAndArgs)
+}
+
+func (node *AssertNode) decode(target interface{}, strict bool, msgAndArgs []interface{}) {
+	node.t.Helper()
+	if !node.exists() {
+		return
+	}
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		node.fail(fmt.Sprintf("decode target must be a non-nil pointer, actual is %T", target), msgAndArgs...)
+		return
+	}
+
+	d := &decoder{strict: strict}
+	d.check(node.path, node.value, v.Type().Elem())
+	for _, violation := range d.violations {
+		node.fail(violation, msgAndArgs...)
+	}
+	if len(d.violations) > 0 {
+		return
+	}
+
+	data, err := json.Marshal(node.value)
+	if err == nil {
+		err = json.Unmarshal(data, target)
+	}
+	if err != nil {
+		node.fail(
+			fmt.Sprintf(`failed asserting that JSON node "%s" can be decoded into %s: %s`, node.path.String(), v.Type().Elem(), err.Error()),
+			msgAndArgs...,
+		)
+	}
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// decoder checks that the value can be decoded into the type before calling json.Unmarshal,
+// because errors of encoding/json do not contain full paths to the invalid values.
+type decoder struct {
+	strict     bool
+	violations []string
+}
+
+//nolint:cyclop
+func (d *decoder) check(path *js.Path, value interface{}, t reflect.Type) {
+	if value == nil || t.Kind() == reflect.Interface {
+		return
+	}
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		d.expect(path, value, t, isString(value))
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		d.check(path, value, t.Elem())
+	case reflect.Bool:
+		_, ok := value.(bool)
+		d.expect(path, value, t, ok)
+	case reflect.String:
+		d.expect(path, value, t, isString(value))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f, ok := toFloat(value)
+		if number, isNumber := value.(json.Number); isNumber {
+			ok = ok && isIntegerLiteral(number)
+		}
+		d.expect(path, value, t, ok && f == math.Trunc(f))
+	case reflect.Float32, reflect.Float64:
+		_, ok := toFloat(value)
+		d.expect(path, value, t, ok)
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			d.expect(path, value, t, isString(value))
+			return
+		}
+		elements, ok := value.([]interface{})
+		if d.expect(path, value, t, ok) {
+			for i, element := range elements {
+				d.check(path.WithIndex(i), element, t.Elem())
+			}
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if d.expect(path, value, t, ok) {
+			for _, key := range sortedKeys(object) {
+				d.check(path.WithProperty(key), object[key], t.Elem())
+			}
+		}
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if d.expect(path, value, t, ok) {
+			d.checkStruct(path, object, t)
+		}
+	}
+}
+
+func (d *decoder) checkStruct(path *js.Path, object map[string]interface{}, t reflect.Type) {
+	fields := structFields(t)
+	known := make(map[string]bool, len(object))
+	for _, f := range fields {
+		key, exists := findKey(object, f.name)
+		if !exists {
+			if d.strict && f.required {
+				d.violations = append(d.violations, fmt.Sprintf(
+					`failed asserting that JSON node "%s" exists for required field %s of %s`,
+					path.WithProperty(f.name).String(), f.field, t,
+				))
+			}
+			continue
+		}
+		known[key] = true
+		if !f.quoted {
+			d.check(path.WithProperty(key), object[key], f.typ)
+		}
+	}
+	if !d.strict {
+		return
+	}
+	for _, key := range sortedKeys(object) {
+		if !known[key] {
+			d.violations = append(d.violations, fmt.Sprintf(
+				`failed asserting that JSON node "%s" is a known field of %s`,
+				path.WithProperty(key).String(), t,
+			))
+		}
+	}
+}
+
+func (d *decoder) expect(path *js.Path, value interface{}, t reflect.Type, ok bool) bool {
+	if !ok {
+		d.violations = append(d.violations, fmt.Sprintf(
+			`failed asserting that JSON node "%s" can be decoded into %s, actual is %s`,
+			path.String(), t, jsonTypeOf(value),
+		))
+	}
+	return ok
+}
+
+type structField struct {
+	name     string
+	field    string
+	typ      reflect.Type
+	required bool
+	quoted   bool
+}
+
+// structFields returns fields of the struct in the way encoding/json sees them:
+// fields of embedded structs without names are promoted to the parent.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options := tag, ""
+		if j := strings.Index(tag, ","); j >= 0 {
+			name, options = tag[:j], tag[j+1:]
+		}
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, structFields(ft)...)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, structField{
+			name:  name,
+			field: f.Name,
+			typ:   f.Type,
+			required: !hasOption(options, "omitempty") &&
+				f.Type.Kind() != reflect.Ptr && f.Type.Kind() != reflect.Interface,
+			quoted: hasOption(options, "string"),
+		})
+	}
+
+	return fields
+}
+
+func hasOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// findKey finds the property for the field name, as encoding/json it prefers
+// an exact match and falls back to a case-insensitive one.
+func findKey(object map[string]interface{}, name string) (string, bool) {
+	if _, exists := object[name]; exists {
+		return name, true
+	}
+	for _, key := range sortedKeys(object) {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func isString(value interface{}) bool {
+	_, ok := value.(string)
+	return ok
+}
+
+func jsonTypeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case json.Number:
+		if isIntegerLiteral(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
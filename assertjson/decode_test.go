@@ -0,0 +1,124 @@
+package assertjson_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type decodedTag struct {
+	Name string `json:"name"`
+}
+
+type decodedEntity struct {
+	ID int `json:"id"`
+}
+
+type decodedUser struct {
+	decodedEntity
+	Name      string       `json:"name"`
+	Email     *string      `json:"email"`
+	Age       int          `json:"age,omitempty"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Tags      []decodedTag `json:"tags"`
+}
+
+const decodeJSON = `{
+	"id": 1,
+	"name": "John",
+	"createdAt": "2022-10-16T12:00:00Z",
+	"tags": [{"name": "admin"}]
+}`
+
+func TestAssertNode_Decode(t *testing.T) {
+	var user decodedUser
+
+	assertjson.Has(t, []byte(decodeJSON), func(json *assertjson.AssertJSON) {
+		json.Node().DecodeStrict(&user)
+	})
+
+	assert.Equal(t, 1, user.ID)
+	assert.Equal(t, "John", user.Name)
+	assert.Nil(t, user.Email)
+	assert.Equal(t, time.Date(2022, time.October, 16, 12, 0, 0, 0, time.UTC), user.CreatedAt)
+	assert.Equal(t, []decodedTag{{Name: "admin"}}, user.Tags)
+}
+
+func TestAssertNode_Decode_Failures(t *testing.T) {
+	tests := []struct {
+		name         string
+		json         string
+		assert       func(json *assertjson.AssertJSON)
+		wantMessages []string
+	}{
+		{
+			name: "unknown fields are ignored by default",
+			json: `{"id": 1, "name": "John", "extra": true}`,
+			assert: func(json *assertjson.AssertJSON) {
+				var user decodedUser
+				json.Node().Decode(&user)
+			},
+		},
+		{
+			name: "type mismatch",
+			json: `{"id": "1", "name": "John", "tags": [{"name": 2}], "createdAt": "now"}`,
+			assert: func(json *assertjson.AssertJSON) {
+				var user decodedUser
+				json.Node().Decode(&user)
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "id" can be decoded into int, actual is string`,
+				`failed asserting that JSON node "tags[0].name" can be decoded into string, actual is integer`,
+			},
+		},
+		{
+			name: "invalid value of unmarshaler",
+			json: `{"id": 1, "name": "John", "createdAt": "now"}`,
+			assert: func(json *assertjson.AssertJSON) {
+				var user decodedUser
+				json.Node().Decode(&user)
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "" can be decoded into assertjson_test.decodedUser: parsing time`,
+			},
+		},
+		{
+			name: "strict decoding",
+			json: `{"user": {"id": 1.5, "tags": [{"name": "a", "color": "red"}], "extra": true}}`,
+			assert: func(json *assertjson.AssertJSON) {
+				var user decodedUser
+				json.Node("user").DecodeStrict(&user)
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "user.id" can be decoded into int, actual is number`,
+				`failed asserting that JSON node "user.name" exists for required field Name of assertjson_test.decodedUser`,
+				`failed asserting that JSON node "user.createdAt" exists for required field CreatedAt of assertjson_test.decodedUser`,
+				`failed asserting that JSON node "user.tags[0].color" is a known field of assertjson_test.decodedTag`,
+				`failed asserting that JSON node "user.extra" is a known field of assertjson_test.decodedUser`,
+			},
+		},
+		{
+			name: "invalid target",
+			json: `{}`,
+			assert: func(json *assertjson.AssertJSON) {
+				var user decodedUser
+				json.Node().Decode(user)
+			},
+			wantMessages: []string{
+				`decode target must be a non-nil pointer, actual is assertjson_test.decodedUser`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertjson.Has(tester, []byte(test.json), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
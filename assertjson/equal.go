@@ -0,0 +1,128 @@
+package assertjson
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/muonsoft/api-testing/internal/js"
+	"github.com/muonsoft/api-testing/internal/jsondiff"
+)
+
+// EqualOption is used to set up comparison of the JSON node with Go value by AssertNode.EqualTo.
+type EqualOption func(options *equalOptions)
+
+type equalOptions struct {
+	ignoredPaths  []*js.Path
+	missingAsZero bool
+	errors        []error
+}
+
+// IgnorePath excludes the JSON node at the path and all its descendants from comparison.
+// The path is relative to the compared node and is set the same way as for AssertJSON.Node().
+// Invalid path is reported as a failure of the comparison.
+func IgnorePath(path ...interface{}) EqualOption {
+	path = preprocessPath(path)
+	target, err := js.PathFromAny(path...)
+
+	return func(options *equalOptions) {
+		if err != nil {
+			options.errors = append(options.errors, fmt.Errorf("invalid path of ignored node: %w", err))
+			return
+		}
+		options.ignoredPaths = append(options.ignoredPaths, target)
+	}
+}
+
+// MissingAsZero treats missing properties as properties with zero values (null, false, 0, "", [] and {}).
+// It is useful to compare structs with the JSON produced by the fields with "omitempty" option.
+func MissingAsZero() EqualOption {
+	return func(options *equalOptions) {
+		options.missingAsZero = true
+	}
+}
+
+// EqualTo asserts that the JSON node is structurally equal to the Go value (struct, map, slice or scalar).
+// The value is encoded by encoding/json, so json tags of the structs are respected. If JSON is parsed
+// with UseNumber option, numbers of the value are compared without loss of precision.
+// On failure, it reports the list of added, removed and changed nodes with their paths.
+func (node *AssertNode) EqualTo(expected interface{}, options ...EqualOption) {
+	node.t.Helper()
+	if !node.exists() {
+		return
+	}
+
+	opts := &equalOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+	if len(opts.errors) > 0 {
+		for _, err := range opts.errors {
+			node.fail(err.Error())
+		}
+		return
+	}
+
+	data, err := json.Marshal(expected)
+	var expectedValue interface{}
+	if err == nil {
+		err = unmarshal(data, &expectedValue, node.useNumber)
+	}
+	if err != nil {
+		node.fail(fmt.Sprintf("expected value cannot be encoded into JSON: %s", err.Error()))
+		return
+	}
+
+	var differences []jsondiff.Difference
+	for _, difference := range jsondiff.Compare(node.path, expectedValue, node.value) {
+		if !opts.ignores(node.path, difference) {
+			differences = append(differences, difference)
+		}
+	}
+	if len(differences) > 0 {
+		node.fail(fmt.Sprintf(
+			"failed asserting that JSON node \"%s\" is equal to value of %T, differences:\n%s",
+			node.path.String(),
+			expected,
+			jsondiff.Format(differences),
+		))
+	}
+}
+
+func (options *equalOptions) ignores(root *js.Path, difference jsondiff.Difference) bool {
+	for _, path := range options.ignoredPaths {
+		if difference.Path.HasPrefix(root.With(path)) {
+			return true
+		}
+	}
+	if options.missingAsZero {
+		switch difference.Kind {
+		case jsondiff.Removed:
+			return isZero(difference.Expected)
+		case jsondiff.Added:
+			return isZero(difference.Actual)
+		}
+	}
+
+	return false
+}
+
+func isZero(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case json.Number:
+		d, ok := toDecimal(v)
+		return ok && d.Sign() == 0
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package assertjson_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+)
+
+type equalUser struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Roles   []string `json:"roles"`
+	Deleted bool     `json:"deleted"`
+}
+
+func TestAssertNode_EqualTo(t *testing.T) {
+	tests := []struct {
+		name         string
+		json         string
+		assert       func(json *assertjson.AssertJSON)
+		wantMessages []string
+	}{
+		{
+			name: "equal to struct",
+			json: `{"user": {"id": 1, "name": "John", "roles": ["admin"], "deleted": false}}`,
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("user").EqualTo(equalUser{ID: 1, Name: "John", Roles: []string{"admin"}})
+				json.Node("user", "roles").EqualTo([]string{"admin"})
+				json.Node("user", "id").EqualTo(1.0)
+			},
+		},
+		{
+			name: "not equal to struct",
+			json: `{"user": {"id": 2, "name": "John", "roles": ["admin", "user"], "deleted": false, "extra": 1}}`,
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("user").EqualTo(equalUser{ID: 1, Name: "John", Roles: []string{"admin"}})
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "user" is equal to value of assertjson_test.equalUser, differences:`,
+			},
+		},
+		{
+			name: "ignored paths",
+			json: `{"user": {"id": 2, "name": "John", "roles": ["admin", "user"], "deleted": false}}`,
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("user").EqualTo(
+					equalUser{ID: 1, Name: "John", Roles: []string{"admin"}},
+					assertjson.IgnorePath("id"),
+					assertjson.IgnorePath("roles"),
+				)
+			},
+		},
+		{
+			name: "missing as zero",
+			json: `[{"id": 1, "name": "John", "extra": null}]`,
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().EqualTo([]equalUser{{ID: 1, Name: "John"}}, assertjson.MissingAsZero())
+				json.Node().EqualTo([]equalUser{{ID: 1, Name: "John", Deleted: true}}, assertjson.MissingAsZero())
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "" is equal to value of []assertjson_test.equalUser, differences:`,
+			},
+		},
+		{
+			name: "invalid ignored path",
+			json: `{"id": 1}`,
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().EqualTo(map[string]int{"id": 2}, assertjson.IgnorePath(true))
+			},
+			wantMessages: []string{
+				`invalid path of ignored node: invalid path: should contain only strings and numbers`,
+			},
+		},
+		{
+			name: "invalid expected value",
+			json: `{}`,
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().EqualTo(func() {})
+			},
+			wantMessages: []string{
+				`expected value cannot be encoded into JSON: json: unsupported type: func()`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertjson.Has(tester, []byte(test.json), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestAssertNode_EqualTo_WhenUseNumber_ExpectExactComparison(t *testing.T) {
+	type item struct {
+		ID int64 `json:"id"`
+	}
+	tester := &mock.Tester{}
+
+	assertjson.Has(tester, []byte(`{"id": 9007199254740993}`), func(json *assertjson.AssertJSON) {
+		json.Node().EqualTo(item{ID: 9007199254740993})
+		json.Node().EqualTo(item{ID: 9007199254740992})
+	}, assertjson.UseNumber())
+
+	tester.AssertContains(t, []string{
+		`failed asserting that JSON node "" is equal to value of assertjson_test.item, differences:`,
+	})
+}
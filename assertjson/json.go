@@ -11,7 +11,7 @@ func (a *StringAssertion) WithJSON(jsonAssert JSONAssertFunc) *StringAssertion {
 		t:       a.t,
 		message: a.message + `is string with JSON: `,
 	}
-	body.assert([]byte(a.value), jsonAssert)
+	body.assert([]byte(a.value), jsonAssert, &parseOptions{})
 
 	return nil
 }
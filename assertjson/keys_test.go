@@ -0,0 +1,83 @@
+package assertjson_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+)
+
+const userJSON = `{"id": 1, "name": "Alice", "password": "secret", "CreatedAt": "2021"}`
+
+func TestObjectAssertion_KeySets(t *testing.T) {
+	tests := []struct {
+		name         string
+		assert       func(json *assertjson.AssertJSON)
+		wantMessages []string
+	}{
+		{
+			name: "passed",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().IsObject().
+					WithKeys([]string{"CreatedAt", "password", "name", "id"}).
+					WithRequiredKeys([]string{"id", "name"}).
+					WithoutKeys([]string{"email", "token"}).
+					WithOnlyKeys([]string{"id", "name", "password", "CreatedAt", "email"}).
+					KeysMatch("^[a-zA-Z]+$")
+			},
+		},
+		{
+			name: "exact keys failed",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().IsObject().WithKeys([]string{"id", "name", "email"})
+				json.Node().IsObject().WithKeys([]string{"id", "name", "password", "CreatedAt", "email"})
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "": has keys "id", "name", "email", missing keys: "email", unexpected keys: "password", "CreatedAt"`,
+				`failed asserting that JSON node "": has keys "id", "name", "password", "CreatedAt", "email", missing keys: "email"`,
+			},
+		},
+		{
+			name: "required, forbidden and only keys failed",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().IsObject().WithRequiredKeys([]string{"id", "email", "token"})
+				json.Node().IsObject().WithoutKeys([]string{"password", "email"})
+				json.Node().IsObject().WithOnlyKeys([]string{"id", "name"})
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "": has required keys "id", "email", "token", missing keys: "email", "token"`,
+				`failed asserting that JSON node "": has no keys "password", "email", unexpected keys: "password"`,
+				`failed asserting that JSON node "": has only keys "id", "name", unexpected keys: "password", "CreatedAt"`,
+			},
+		},
+		{
+			name: "failed with message",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().IsObject().WithKeys([]string{"id"}, "user keys")
+				json.Node().IsObject().WithRequiredKeys([]string{"email"}, "user keys")
+				json.Node().IsObject().WithoutKeys([]string{"password"}, "user keys")
+				json.Node().IsObject().WithOnlyKeys([]string{"id"}, "user keys")
+			},
+			wantMessages: []string{"user keys", "user keys", "user keys", "user keys"},
+		},
+		{
+			name: "keys match failed",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().IsObject().KeysMatch(regexp.MustCompile("^[a-z]+$"))
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "": has keys matching "^[a-z]+$", keys not matching: "CreatedAt"`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertjson.Has(tester, []byte(userJSON), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
 
 	"github.com/muonsoft/api-testing/internal/js"
@@ -17,6 +18,9 @@ type AssertNode struct {
 	message string
 	path    *js.Path
 	value   interface{}
+	keys    objectKeys
+	// useNumber is set when the document is parsed with UseNumber option
+	useNumber bool
 }
 
 // Value returns JSON node value as an interface. If node does not exist it returns nil.
@@ -39,6 +43,9 @@ func (node *AssertNode) String() string {
 		if s, ok := node.value.(string); ok {
 			return s
 		}
+		if n, ok := node.value.(json.Number); ok {
+			return n.String()
+		}
 		if f, ok := node.value.(float64); ok {
 			if n, f := math.Modf(f); f == 0 {
 				return strconv.Itoa(int(n))
@@ -56,7 +63,7 @@ func (node *AssertNode) String() string {
 func (node *AssertNode) Float() float64 {
 	node.t.Helper()
 	if node.exists() {
-		if f, ok := node.value.(float64); ok {
+		if f, ok := toFloat(node.value); ok {
 			return f
 		}
 		node.fail(fmt.Sprintf(`JSON node at "%s" cannot be converted into float`, node.path.String()))
@@ -70,7 +77,7 @@ func (node *AssertNode) Float() float64 {
 func (node *AssertNode) Integer() int {
 	node.t.Helper()
 	if node.exists() {
-		if f, ok := node.value.(float64); ok {
+		if f, ok := toFloat(node.value); ok {
 			if n, f := math.Modf(f); f == 0 {
 				return int(n)
 			}
@@ -83,6 +90,51 @@ func (node *AssertNode) Integer() int {
 	return 0
 }
 
+// Int64 returns the JSON node value as a 64-bit integer. If node does not exist it returns a zero.
+// If node value is not an integer or is out of range of int64, it logs the error and returns a zero value.
+// JSON should be parsed with UseNumber option to get integers greater than 2^53 without loss of precision.
+func (node *AssertNode) Int64() int64 {
+	node.t.Helper()
+	if n := node.BigInt(); n != nil {
+		if n.IsInt64() {
+			return n.Int64()
+		}
+		node.fail(fmt.Sprintf(`JSON node at "%s" is out of range of int64`, node.path.String()))
+	}
+
+	return 0
+}
+
+// BigInt returns the JSON node value as a big integer. If node does not exist it returns nil.
+// If node value is not an integer, it logs the error and returns nil.
+// JSON should be parsed with UseNumber option to get integers greater than 2^53 without loss of precision.
+func (node *AssertNode) BigInt() *big.Int {
+	node.t.Helper()
+	if d := node.Decimal(); d != nil {
+		if d.IsInt() {
+			return new(big.Int).Set(d.Num())
+		}
+		node.fail(fmt.Sprintf(`JSON node at "%s" is not an integer`, node.path.String()))
+	}
+
+	return nil
+}
+
+// Decimal returns the exact value of the JSON node number as a rational number.
+// If node does not exist it returns nil. If node value is not numeric, it logs the error and returns nil.
+// JSON should be parsed with UseNumber option to get decimals without rounding to float64.
+func (node *AssertNode) Decimal() *big.Rat {
+	node.t.Helper()
+	if node.exists() {
+		if d, ok := toDecimal(node.value); ok {
+			return d
+		}
+		node.fail(fmt.Sprintf(`JSON node at "%s" cannot be converted into decimal`, node.path.String()))
+	}
+
+	return nil
+}
+
 // JSON returns the JSON node value as a marshaled JSON.
 func (node *AssertNode) JSON() []byte {
 	node.t.Helper()
@@ -95,6 +147,7 @@ func (node *AssertNode) JSON() []byte {
 }
 
 // ForEach executes callback function for node assertion on each array or object node.
+// Object properties are iterated in the source order of the JSON document.
 func (node *AssertNode) ForEach(assertNode func(node *AssertNode)) {
 	node.t.Helper()
 	if !node.exists() {
@@ -104,21 +157,25 @@ func (node *AssertNode) ForEach(assertNode func(node *AssertNode)) {
 	if values, ok := node.value.([]interface{}); ok {
 		for i, value := range values {
 			assertNode(&AssertNode{
-				t:       node.t,
-				err:     node.err,
-				message: node.message,
-				path:    node.path.WithIndex(i),
-				value:   value,
+				t:         atPath(node.t, node.path.WithIndex(i)),
+				err:       node.err,
+				message:   node.message,
+				path:      node.path.WithIndex(i),
+				value:     value,
+				keys:      node.keys,
+				useNumber: node.useNumber,
 			})
 		}
 	} else if values, ok := node.value.(map[string]interface{}); ok {
-		for key, value := range values {
+		for _, key := range node.keys.of(node.path, values) {
 			assertNode(&AssertNode{
-				t:       node.t,
-				err:     node.err,
-				message: node.message,
-				path:    node.path.WithProperty(key),
-				value:   value,
+				t:         atPath(node.t, node.path.WithProperty(key)),
+				err:       node.err,
+				message:   node.message,
+				path:      node.path.WithProperty(key),
+				value:     values[key],
+				keys:      node.keys,
+				useNumber: node.useNumber,
 			})
 		}
 	} else {
@@ -134,10 +191,12 @@ func (node *AssertNode) Assert(jsonAssert JSONAssertFunc) {
 	node.t.Helper()
 
 	jsonAssert(&AssertJSON{
-		t:       node.t,
-		message: node.message,
-		path:    node.path,
-		data:    node.value,
+		t:         node.t,
+		message:   node.message,
+		path:      node.path,
+		data:      node.value,
+		keys:      node.keys,
+		useNumber: node.useNumber,
 	})
 }
 
@@ -0,0 +1,115 @@
+package assertjson_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const numbersJSON = `{
+	"id": 9007199254740993,
+	"huge": 123456789012345678901234567890,
+	"one": 1,
+	"oneFloat": 1.0,
+	"price": 0.1
+}`
+
+func TestUseNumber_Accessors(t *testing.T) {
+	assertjson.Has(t, []byte(numbersJSON), func(json *assertjson.AssertJSON) {
+		assert.Equal(t, int64(9007199254740993), json.Node("id").Int64())
+		assert.Equal(t, "123456789012345678901234567890", json.Node("huge").BigInt().String())
+		assert.Equal(t, big.NewRat(1, 10), json.Node("price").Decimal())
+		assert.Equal(t, "9007199254740993", json.Node("id").String())
+		assert.Equal(t, 1, json.Node("one").Integer())
+		assert.Equal(t, 0.1, json.Node("price").Float())
+	}, assertjson.UseNumber())
+}
+
+func TestUseNumber_Assertions(t *testing.T) {
+	tests := []struct {
+		name         string
+		options      []assertjson.ParseOption
+		assert       func(json *assertjson.AssertJSON)
+		wantMessages []string
+	}{
+		{
+			name:    "exact values",
+			options: []assertjson.ParseOption{assertjson.UseNumber()},
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("id").IsInteger().EqualToInt64(9007199254740993)
+				json.Node("huge").IsInteger().EqualToDecimal("123456789012345678901234567890")
+				json.Node("price").IsNumber().EqualToDecimal("0.10")
+				json.Node("one").IsInteger().EqualTo(1)
+				json.Node("oneFloat").IsNumber().EqualTo(1)
+			},
+		},
+		{
+			name:    "exact values failed",
+			options: []assertjson.ParseOption{assertjson.UseNumber()},
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("id").IsInteger().EqualToInt64(9007199254740992)
+				json.Node("price").IsNumber().EqualToDecimal("0.11")
+				json.Node("price").IsNumber().EqualToDecimal("abc")
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "id": equal to 9007199254740992, actual is 9007199254740993`,
+				`failed asserting that JSON node "price": equal to 0.11, actual is 0.1`,
+				`failed asserting that JSON node "price": equal to abc, but it is not a valid decimal number`,
+			},
+		},
+		{
+			name:    "float literal is not integer",
+			options: []assertjson.ParseOption{assertjson.UseNumber()},
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("oneFloat").IsInteger()
+				json.Node("price").BigInt()
+			},
+			wantMessages: []string{
+				`value at path "oneFloat" is float, not integer`,
+				`JSON node at "price" is not an integer`,
+			},
+		},
+		{
+			name: "precision is lost without precise mode",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("oneFloat").IsInteger()
+				json.Node("id").IsInteger().EqualToDecimal("9007199254740993")
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "id": equal to 9007199254740993, actual is 9007199254740992`,
+			},
+		},
+		{
+			name:    "integer out of range of int",
+			options: []assertjson.ParseOption{assertjson.UseNumber()},
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("huge").IsInteger().
+					GreaterThan(0).
+					GreaterThanOrEqual(1).
+					NotEqualTo(1).
+					IsNotZero().
+					EqualToDecimal("123456789012345678901234567890")
+				json.Node("huge").IsInteger().EqualTo(1)
+				json.Node("huge").IsInteger().LessThan(0)
+				json.Node("huge").IsInteger().EqualToInt64(1)
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "huge": equal to 1, actual is 123456789012345678901234567890`,
+				`failed asserting that JSON node "huge": less than 0, actual is 123456789012345678901234567890`,
+				`failed asserting that JSON node "huge": equal to 1, actual is 123456789012345678901234567890`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertjson.Has(tester, []byte(numbersJSON), test.assert, test.options...)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
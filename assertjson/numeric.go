@@ -1,10 +1,14 @@
 package assertjson
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
+	"strconv"
+	"strings"
 
-	"github.com/stretchr/testify/assert"
+	"github.com/muonsoft/api-testing/internal/fluent"
 )
 
 // IsInteger asserts that the JSON node has an integer value.
@@ -12,7 +16,7 @@ import (
 func (node *AssertNode) IsInteger(msgAndArgs ...interface{}) *IntegerAssertion {
 	node.t.Helper()
 	if node.exists() {
-		float, ok := node.value.(float64)
+		float, ok := toFloat(node.value)
 		if !ok {
 			node.fail(
 				fmt.Sprintf(`value at path "%s" is not numeric`, node.path.String()),
@@ -21,19 +25,19 @@ func (node *AssertNode) IsInteger(msgAndArgs ...interface{}) *IntegerAssertion {
 			return nil
 		}
 		_, fractional := math.Modf(float)
-		if fractional != 0 {
+		if number, isNumber := node.value.(json.Number); fractional != 0 || isNumber && !isIntegerLiteral(number) {
 			node.fail(
 				fmt.Sprintf(`value at path "%s" is float, not integer`, node.path.String()),
 				msgAndArgs...,
 			)
 			return nil
 		}
-		return &IntegerAssertion{
-			t:       node.t,
-			message: fmt.Sprintf(`%sfailed asserting that JSON node "%s": `, node.message, node.path.String()),
-			path:    node.path.String(),
-			value:   int(float),
-		}
+		exact, _ := toDecimal(node.value)
+		return fluent.NewIntegerAssertion(
+			node.t,
+			fmt.Sprintf(`%sfailed asserting that JSON node "%s": `, node.message, node.path.String()),
+			exact.Num(),
+		)
 	}
 
 	return nil
@@ -51,13 +55,14 @@ func (node *AssertNode) IsFloat(msgAndArgs ...interface{}) *NumberAssertion {
 func (node *AssertNode) IsNumber(msgAndArgs ...interface{}) *NumberAssertion {
 	node.t.Helper()
 	if node.exists() {
-		if f, ok := node.value.(float64); ok {
-			return &NumberAssertion{
-				t:       node.t,
-				message: fmt.Sprintf(`%sfailed asserting that JSON node "%s": `, node.message, node.path.String()),
-				path:    node.path.String(),
-				value:   f,
-			}
+		if f, ok := toFloat(node.value); ok {
+			exact, _ := toDecimal(node.value)
+			return fluent.NewNumberAssertion(
+				node.t,
+				fmt.Sprintf(`%sfailed asserting that JSON node "%s": `, node.message, node.path.String()),
+				f,
+				exact,
+			)
 		}
 		node.fail(
 			fmt.Sprintf(`value at path "%s" is not a number`, node.path.String()),
@@ -119,311 +124,35 @@ func (node *AssertNode) IsNumberInRange(vmin, vmax float64, msgAndArgs ...interf
 }
 
 // NumberAssertion is used to build a chain of assertions for the numeric node.
-type NumberAssertion struct {
-	t       TestingT
-	message string
-	path    string
-	value   float64
-}
-
-// IsZero asserts that the JSON node has a numeric value equals to zero.
-func (a *NumberAssertion) IsZero(msgAndArgs ...interface{}) *NumberAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value != 0 {
-		a.fail(
-			fmt.Sprintf(`is zero, actual is %f`, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// IsNotZero asserts that the JSON node has a numeric value not equals to zero.
-func (a *NumberAssertion) IsNotZero(msgAndArgs ...interface{}) *NumberAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value == 0 {
-		a.fail(`is not zero`, msgAndArgs...)
-	}
-
-	return nil
-}
-
-// EqualTo asserts that the JSON node has a numeric value equals to the given value.
-func (a *NumberAssertion) EqualTo(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value != expected {
-		a.fail(
-			fmt.Sprintf(`equal to %f, actual is %f`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// NotEqualTo asserts that the JSON node has a numeric value not equals to the given value.
-func (a *NumberAssertion) NotEqualTo(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value == expected {
-		a.fail(
-			fmt.Sprintf(`not equal to %f, actual is %f`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// EqualToWithDelta asserts that the JSON node has a numeric value equals to the given value with delta.
-func (a *NumberAssertion) EqualToWithDelta(expected, delta float64, msgAndArgs ...interface{}) *NumberAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	dt := a.value - expected
-	if dt < -delta || dt > delta {
-		a.fail(
-			fmt.Sprintf(`equal to %f with delta %f, actual is %f`, expected, delta, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// GreaterThan asserts that the JSON node has a numeric value greater than the given value.
-func (a *NumberAssertion) GreaterThan(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value <= expected {
-		a.fail(
-			fmt.Sprintf(`greater than %f, actual is %f`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// GreaterThanOrEqual asserts that the JSON node has a numeric value greater than or equal to the given value.
-func (a *NumberAssertion) GreaterThanOrEqual(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value < expected {
-		a.fail(
-			fmt.Sprintf(`greater than or equal %f, actual is %f`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// LessThan asserts that the JSON node has a numeric value less than the given value.
-func (a *NumberAssertion) LessThan(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value >= expected {
-		a.fail(
-			fmt.Sprintf(`less than %f, actual is %f`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// LessThanOrEqual asserts that the JSON node has a numeric value less than or equal to the given value.
-func (a *NumberAssertion) LessThanOrEqual(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value > expected {
-		a.fail(
-			fmt.Sprintf(`less than or equal %f, actual is %f`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-func (a *NumberAssertion) fail(message string, msgAndArgs ...interface{}) {
-	a.t.Helper()
-	assert.Fail(a.t, a.message+message, msgAndArgs...)
-}
+type NumberAssertion = fluent.NumberAssertion
 
 // IntegerAssertion is used to build a chain of assertions for the integer node.
-type IntegerAssertion struct {
-	t       TestingT
-	message string
-	path    string
-	value   int
-}
-
-// IsZero asserts that the JSON node has an integer value equals to 0.
-func (a *IntegerAssertion) IsZero(msgAndArgs ...interface{}) *IntegerAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value != 0 {
-		a.fail(
-			fmt.Sprintf(`is zero, actual is %d`, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// IsNotZero asserts that the JSON node has an integer value not equals to 0.
-func (a *IntegerAssertion) IsNotZero(msgAndArgs ...interface{}) *IntegerAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
+type IntegerAssertion = fluent.IntegerAssertion
 
-	if a.value == 0 {
-		a.fail(`is not zero`, msgAndArgs...)
+// toFloat returns the value of JSON number decoded as float64 or json.Number.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
 	}
-
-	return nil
+	return 0, false
 }
 
-// EqualTo asserts that the JSON node has an integer value equals to the given value.
-func (a *IntegerAssertion) EqualTo(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
-	if a == nil {
-		return nil
+// toDecimal returns the exact value of JSON number. Floats are converted by the shortest
+// decimal representation, so 0.1 is exactly 1/10.
+func toDecimal(value interface{}) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case float64:
+		return new(big.Rat).SetString(strconv.FormatFloat(v, 'g', -1, 64))
+	case json.Number:
+		return new(big.Rat).SetString(v.String())
 	}
-	a.t.Helper()
-
-	if a.value != expected {
-		a.fail(
-			fmt.Sprintf(`equal to %d, actual is %d`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// NotEqualTo asserts that the JSON node has an integer value not equals to the given value.
-func (a *IntegerAssertion) NotEqualTo(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value == expected {
-		a.fail(
-			fmt.Sprintf(`not equal to %d, actual is %d`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// GreaterThan asserts that the JSON node has an integer value greater than the given value.
-func (a *IntegerAssertion) GreaterThan(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value <= expected {
-		a.fail(
-			fmt.Sprintf(`greater than %d, actual is %d`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// GreaterThanOrEqual asserts that the JSON node has an integer value greater than or equal to the given value.
-func (a *IntegerAssertion) GreaterThanOrEqual(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value < expected {
-		a.fail(
-			fmt.Sprintf(`greater than or equal %d, actual is %d`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// LessThan asserts that the JSON node has an integer value less than the given value.
-func (a *IntegerAssertion) LessThan(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value >= expected {
-		a.fail(
-			fmt.Sprintf(`less than %d, actual is %d`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
-}
-
-// LessThanOrEqual asserts that the JSON node has an integer value less than or equal to the given value.
-func (a *IntegerAssertion) LessThanOrEqual(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
-	if a == nil {
-		return nil
-	}
-	a.t.Helper()
-
-	if a.value > expected {
-		a.fail(
-			fmt.Sprintf(`less than or equal %d, actual is %d`, expected, a.value),
-			msgAndArgs...,
-		)
-	}
-
-	return nil
+	return nil, false
 }
 
-func (a *IntegerAssertion) fail(message string, msgAndArgs ...interface{}) {
-	a.t.Helper()
-	assert.Fail(a.t, a.message+message, msgAndArgs...)
+func isIntegerLiteral(number json.Number) bool {
+	return !strings.ContainsAny(number.String(), ".eE")
 }
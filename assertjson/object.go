@@ -27,6 +27,7 @@ func (node *AssertNode) IsObject(msgAndArgs ...interface{}) *ObjectAssertion {
 				message: fmt.Sprintf(`%sfailed asserting that JSON node "%s": `, node.message, node.path.String()),
 				path:    node.path.String(),
 				value:   object,
+				keys:    node.keys.of(node.path, object),
 			}
 		}
 		node.fail(
@@ -44,6 +45,8 @@ type ObjectAssertion struct {
 	message string
 	path    string
 	value   map[string]interface{}
+	// keys of the object in the source order
+	keys []string
 }
 
 // WithPropertiesCount asserts that the JSON node is an object with properties count equal to the given value.
@@ -163,8 +166,8 @@ func (a *ObjectAssertion) WithUniqueElements(msgAndArgs ...interface{}) *ObjectA
 	uniques := make(map[string][]string, len(a.value))
 	keys := make([]string, 0, len(a.value))
 
-	for k, value := range a.value {
-		raw, _ := json.Marshal(value)
+	for _, k := range a.keys {
+		raw, _ := json.Marshal(a.value[k])
 		key := string(raw)
 		if _, exist := uniques[key]; !exist {
 			keys = append(keys, key)
@@ -196,6 +199,151 @@ func (a *ObjectAssertion) WithUniqueElements(msgAndArgs ...interface{}) *ObjectA
 	return a
 }
 
+// WithKeysInOrder asserts that the JSON node is an object with exactly the given keys
+// in the given order. The order of keys is taken from the source JSON document.
+func (a *ObjectAssertion) WithKeysInOrder(keys []string, msgAndArgs ...interface{}) *ObjectAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	if strings.Join(quoteAll(keys), ", ") != strings.Join(quoteAll(a.keys), ", ") {
+		a.fail(fmt.Sprintf(
+			"has keys in order %s, actual order is %s",
+			strings.Join(quoteAll(keys), ", "),
+			strings.Join(quoteAll(a.keys), ", "),
+		), msgAndArgs...)
+	}
+
+	return a
+}
+
+// WithKeys asserts that the JSON node is an object with exactly the given set of keys.
+// The order of keys is not checked, use WithKeysInOrder to check it.
+func (a *ObjectAssertion) WithKeys(keys []string, msgAndArgs ...interface{}) *ObjectAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	missing := a.missingKeys(keys)
+	unexpected := a.unexpectedKeys(keys)
+	if len(missing) > 0 || len(unexpected) > 0 {
+		a.fail(fmt.Sprintf("has keys %s%s", joinKeys(keys), describeKeys(missing, unexpected)), msgAndArgs...)
+	}
+
+	return a
+}
+
+// WithRequiredKeys asserts that the JSON node is an object containing all of the given keys.
+// Other keys are allowed.
+func (a *ObjectAssertion) WithRequiredKeys(keys []string, msgAndArgs ...interface{}) *ObjectAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	if missing := a.missingKeys(keys); len(missing) > 0 {
+		a.fail(fmt.Sprintf("has required keys %s%s", joinKeys(keys), describeKeys(missing, nil)), msgAndArgs...)
+	}
+
+	return a
+}
+
+// WithoutKeys asserts that the JSON node is an object containing none of the given keys.
+func (a *ObjectAssertion) WithoutKeys(keys []string, msgAndArgs ...interface{}) *ObjectAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	forbidden := make([]string, 0)
+	for _, key := range keys {
+		if _, exists := a.value[key]; exists {
+			forbidden = append(forbidden, key)
+		}
+	}
+	if len(forbidden) > 0 {
+		a.fail(fmt.Sprintf("has no keys %s%s", joinKeys(keys), describeKeys(nil, forbidden)), msgAndArgs...)
+	}
+
+	return a
+}
+
+// WithOnlyKeys asserts that the JSON node is an object without additional properties:
+// each of its keys must be one of the given keys. Some of the given keys may be missing.
+func (a *ObjectAssertion) WithOnlyKeys(keys []string, msgAndArgs ...interface{}) *ObjectAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	if unexpected := a.unexpectedKeys(keys); len(unexpected) > 0 {
+		a.fail(fmt.Sprintf("has only keys %s%s", joinKeys(keys), describeKeys(nil, unexpected)), msgAndArgs...)
+	}
+
+	return a
+}
+
+// KeysMatch asserts that all keys of the JSON node object match the regular expression.
+// Regexp can be a string or *regexp.Regexp.
+func (a *ObjectAssertion) KeysMatch(regexp interface{}, msgAndArgs ...interface{}) *ObjectAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	mismatched := make([]string, 0)
+	for _, key := range a.keys {
+		if !matchRegexp(regexp, key) {
+			mismatched = append(mismatched, key)
+		}
+	}
+	if len(mismatched) > 0 {
+		a.fail(
+			fmt.Sprintf(`has keys matching "%v", keys not matching: %s`, regexp, joinKeys(mismatched)),
+			msgAndArgs...,
+		)
+	}
+
+	return a
+}
+
+// missingKeys returns the given keys that are absent in the object.
+func (a *ObjectAssertion) missingKeys(keys []string) []string {
+	missing := make([]string, 0)
+	for _, key := range keys {
+		if _, exists := a.value[key]; !exists {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// unexpectedKeys returns keys of the object that are not in the given list, in the source order.
+func (a *ObjectAssertion) unexpectedKeys(keys []string) []string {
+	expected := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		expected[key] = true
+	}
+	unexpected := make([]string, 0)
+	for _, key := range a.keys {
+		if !expected[key] {
+			unexpected = append(unexpected, key)
+		}
+	}
+	return unexpected
+}
+
+// Keys returns keys of the object in the source order of the JSON document.
+func (a *ObjectAssertion) Keys() []string {
+	if a == nil {
+		return nil
+	}
+
+	return a.keys
+}
+
 // PropertiesCount returns array underlying object properties count.
 func (a *ObjectAssertion) PropertiesCount() int {
 	if a == nil {
@@ -224,3 +372,18 @@ func quoteAll(s []string) []string {
 	}
 	return ss
 }
+
+func joinKeys(keys []string) string {
+	return strings.Join(quoteAll(keys), ", ")
+}
+
+func describeKeys(missing, unexpected []string) string {
+	var description strings.Builder
+	if len(missing) > 0 {
+		description.WriteString(", missing keys: " + joinKeys(missing))
+	}
+	if len(unexpected) > 0 {
+		description.WriteString(", unexpected keys: " + joinKeys(unexpected))
+	}
+	return description.String()
+}
@@ -0,0 +1,80 @@
+package assertjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/muonsoft/api-testing/internal/js"
+)
+
+// objectKeys holds keys of JSON objects in the source order. Objects are identified by their paths
+// from the root of the document. Decoded objects are maps, so the order is scanned separately.
+type objectKeys map[string][]string
+
+// scanObjectKeys returns keys of all objects of the document in the source order.
+// Duplicate keys are listed once at the position of the first occurrence.
+func scanObjectKeys(data []byte) objectKeys {
+	keys := make(objectKeys)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := keys.scan(decoder, nil); err != nil {
+		return nil
+	}
+
+	return keys
+}
+
+func (keys objectKeys) scan(decoder *json.Decoder, path *js.Path) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		var list []string
+		seen := make(map[string]bool)
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := token.(string)
+			if !seen[key] {
+				seen[key] = true
+				list = append(list, key)
+			}
+			if err := keys.scan(decoder, path.WithProperty(key)); err != nil {
+				return err
+			}
+		}
+		keys[path.String()] = list
+		_, err = decoder.Token()
+	case json.Delim('['):
+		for i := 0; decoder.More(); i++ {
+			if err := keys.scan(decoder, path.WithIndex(i)); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+	}
+
+	return err
+}
+
+// of returns keys of the object at the path in the source order. If the order is unknown
+// (for example, data was not parsed from JSON), keys are returned in the sorted order.
+func (keys objectKeys) of(path *js.Path, object map[string]interface{}) []string {
+	if list, ok := keys[path.String()]; ok && len(list) == len(object) {
+		return list
+	}
+
+	list := make([]string, 0, len(object))
+	for key := range object {
+		list = append(list, key)
+	}
+	sort.Strings(list)
+
+	return list
+}
@@ -0,0 +1,79 @@
+package assertjson_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const orderedJSON = `{
+	"signature": {"z": 1, "a": 2, "m": {"y": true, "b": false}},
+	"items": [{"id": 1, "name": "a"}, {"name": "b", "id": 2}]
+}`
+
+func TestObjectAssertion_WithKeysInOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		assert       func(json *assertjson.AssertJSON)
+		wantMessages []string
+	}{
+		{
+			name: "keys in order",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().IsObject().WithKeysInOrder([]string{"signature", "items"})
+				json.Node("signature").IsObject().WithKeysInOrder([]string{"z", "a", "m"})
+				json.Node("signature", "m").IsObject().WithKeysInOrder([]string{"y", "b"})
+				json.At("items", 1).Node().IsObject().WithKeysInOrder([]string{"name", "id"})
+				json.Node("items").ForEach(func(node *assertjson.AssertNode) {
+					node.IsObject().WithKeysInOrder([]string{"id", "name"})
+				})
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "items[1]": has keys in order "id", "name", actual order is "name", "id"`,
+			},
+		},
+		{
+			name: "keys in different order",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("signature").IsObject().WithKeysInOrder([]string{"a", "m", "z"})
+				json.Node("signature").IsObject().WithKeysInOrder([]string{"z", "a"})
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "signature": has keys in order "a", "m", "z", actual order is "z", "a", "m"`,
+				`failed asserting that JSON node "signature": has keys in order "z", "a", actual order is "z", "a", "m"`,
+			},
+		},
+		{
+			name: "keys in different order with message",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("signature").IsObject().WithKeysInOrder([]string{"a", "m", "z"}, "signature keys")
+			},
+			wantMessages: []string{"signature keys"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertjson.Has(tester, []byte(orderedJSON), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestAssertNode_ForEach_SourceOrder(t *testing.T) {
+	assertjson.Has(t, []byte(orderedJSON), func(json *assertjson.AssertJSON) {
+		var values []string
+		json.Node("signature").Assert(func(json *assertjson.AssertJSON) {
+			json.Node().ForEach(func(node *assertjson.AssertNode) {
+				values = append(values, string(node.JSON()))
+			})
+		})
+
+		assert.Equal(t, []string{"1", "2", `{"b":false,"y":true}`}, values)
+		assert.Equal(t, []string{"z", "a", "m"}, json.Node("signature").IsObject().Keys())
+	})
+}
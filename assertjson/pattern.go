@@ -0,0 +1,509 @@
+package assertjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/muonsoft/api-testing/internal/js"
+)
+
+// Pattern placeholders used by MatchesJSON.
+const (
+	// IgnorePlaceholder matches any value.
+	IgnorePlaceholder = "@ignore@"
+	// RestPlaceholder allows other elements when used as the last element of an array
+	// or other properties when used as a property name.
+	RestPlaceholder = "@...@"
+)
+
+// MatchesJSON asserts that the JSON node matches the pattern. The pattern is a JSON document
+// that can contain placeholders instead of the exact values:
+//
+//   - "@string@", "@integer@", "@number@", "@boolean@", "@array@", "@object@", "@null@" - value of the type;
+//   - "@uuid@", "@datetime@" (RFC3339), "@date@" (YYYY-MM-DD), "@email@", "@url@" - string of the format;
+//   - "@ignore@" - any value;
+//   - "@...@" - as the last element of an array allows other elements, as a property name
+//     (for example, {"id": "@integer@", "@...@": ""}) allows other properties.
+//
+// Placeholders can be followed by expanders calling the same assertions as the fluent API:
+// "@string@.contains('x')", "@integer@.greaterThan(0).lessThan(10)", "@datetime@.after('2022-10-16T00:00:00Z')".
+// Supported expanders are: isEmpty(), isNotEmpty(), contains(s), notContains(s), startsWith(s), endsWith(s),
+// matchRegex(s), oneOf(s...), length(n), minLength(n), maxLength(n), greaterThan(n), greaterThanOrEqual(n),
+// lessThan(n), lessThanOrEqual(n), before(t), after(t), count(n).
+// Other values of the pattern must be equal to the values of the node.
+func (node *AssertNode) MatchesJSON(pattern string, msgAndArgs ...interface{}) {
+	node.t.Helper()
+	if !node.exists() {
+		return
+	}
+	var expected interface{}
+	if err := json.Unmarshal([]byte(pattern), &expected); err != nil {
+		node.fail(fmt.Sprintf("pattern is not valid JSON: %s", err.Error()), msgAndArgs...)
+		return
+	}
+	node.matchPattern(expected, msgAndArgs)
+}
+
+func (node *AssertNode) matchPattern(pattern interface{}, msgAndArgs []interface{}) {
+	node.t.Helper()
+	switch p := pattern.(type) {
+	case nil:
+		node.IsNull(msgAndArgs...)
+	case bool:
+		if p {
+			node.IsTrue(msgAndArgs...)
+		} else {
+			node.IsFalse(msgAndArgs...)
+		}
+	case float64:
+		node.IsNumber(msgAndArgs...).EqualTo(p, msgAndArgs...)
+	case string:
+		node.matchString(p, msgAndArgs)
+	case []interface{}:
+		node.matchArray(p, msgAndArgs)
+	case map[string]interface{}:
+		node.matchObject(p, msgAndArgs)
+	}
+}
+
+func (node *AssertNode) matchString(pattern string, msgAndArgs []interface{}) {
+	node.t.Helper()
+	if pattern == IgnorePlaceholder {
+		return
+	}
+	p, isPlaceholder, err := parsePlaceholder(pattern)
+	if err != nil {
+		node.fail(
+			fmt.Sprintf(`failed asserting that JSON node "%s" matches pattern: %s`, node.path.String(), err.Error()),
+			msgAndArgs...,
+		)
+		return
+	}
+	if !isPlaceholder {
+		node.IsString(msgAndArgs...).EqualTo(pattern, msgAndArgs...)
+		return
+	}
+	if err := node.matchPlaceholder(p, msgAndArgs); err != nil {
+		node.fail(
+			fmt.Sprintf(`failed asserting that JSON node "%s" matches pattern "%s": %s`, node.path.String(), pattern, err.Error()),
+			msgAndArgs...,
+		)
+	}
+}
+
+func (node *AssertNode) matchArray(pattern []interface{}, msgAndArgs []interface{}) {
+	node.t.Helper()
+	hasRest := len(pattern) > 0 && pattern[len(pattern)-1] == RestPlaceholder
+	array := node.IsArray(msgAndArgs...)
+	if array == nil {
+		return
+	}
+	if hasRest {
+		pattern = pattern[:len(pattern)-1]
+		array.WithLengthGreaterThanOrEqual(len(pattern), msgAndArgs...)
+	} else {
+		array.WithLength(len(pattern), msgAndArgs...)
+	}
+
+	for i, element := range pattern {
+		if i >= len(array.value) {
+			break
+		}
+		node.child(node.path.WithIndex(i), array.value[i]).matchPattern(element, msgAndArgs)
+	}
+}
+
+func (node *AssertNode) matchObject(pattern map[string]interface{}, msgAndArgs []interface{}) {
+	node.t.Helper()
+	object := node.IsObject(msgAndArgs...)
+	if object == nil {
+		return
+	}
+	_, hasRest := pattern[RestPlaceholder]
+
+	for _, key := range sortedKeys(pattern) {
+		if key == RestPlaceholder {
+			continue
+		}
+		child := node.child(node.path.WithProperty(key), object.value[key])
+		if _, exists := object.value[key]; !exists {
+			child.fail(fmt.Sprintf(`failed asserting that JSON node "%s" exists`, child.path.String()), msgAndArgs...)
+			continue
+		}
+		child.matchPattern(pattern[key], msgAndArgs)
+	}
+
+	if hasRest {
+		return
+	}
+	var unexpected []string
+	for _, key := range sortedKeys(object.value) {
+		if _, expected := pattern[key]; !expected {
+			unexpected = append(unexpected, key)
+		}
+	}
+	if len(unexpected) > 0 {
+		node.fail(
+			fmt.Sprintf(
+				`failed asserting that JSON node "%s" matches pattern: unexpected properties %s`,
+				node.path.String(),
+				strings.Join(quoteAll(unexpected), ", "),
+			),
+			msgAndArgs...,
+		)
+	}
+}
+
+//nolint:cyclop
+func (node *AssertNode) matchPlaceholder(p *placeholder, msgAndArgs []interface{}) error {
+	node.t.Helper()
+	switch p.name {
+	case "string":
+		return p.expand(node.IsString(msgAndArgs...), msgAndArgs)
+	case "integer":
+		return p.expand(node.IsInteger(msgAndArgs...), msgAndArgs)
+	case "number":
+		return p.expand(node.IsNumber(msgAndArgs...), msgAndArgs)
+	case "array":
+		return p.expand(node.IsArray(msgAndArgs...), msgAndArgs)
+	case "object":
+		return p.expand(node.IsObject(msgAndArgs...), msgAndArgs)
+	case "datetime":
+		return p.expand(node.IsTime(msgAndArgs...), msgAndArgs)
+	case "date":
+		return p.expand(node.IsDate(msgAndArgs...), msgAndArgs)
+	case "uuid":
+		node.IsUUID(msgAndArgs...)
+	case "email":
+		node.IsEmail(msgAndArgs...)
+	case "url":
+		node.IsURL(msgAndArgs...)
+	case "null":
+		node.IsNull(msgAndArgs...)
+	case "boolean":
+		if _, ok := node.value.(bool); !ok {
+			node.fail(fmt.Sprintf(`failed asserting that JSON node "%s" is boolean`, node.path.String()), msgAndArgs...)
+		}
+	}
+	if len(p.expanders) > 0 {
+		return fmt.Errorf(`placeholder "@%s@" does not support expanders`, p.name)
+	}
+
+	return nil
+}
+
+func (node *AssertNode) child(path *js.Path, value interface{}) *AssertNode {
+	return &AssertNode{t: atPath(node.t, path), message: node.message, path: path, value: value, keys: node.keys, useNumber: node.useNumber}
+}
+
+var placeholderNames = map[string]bool{
+	"string":   true,
+	"integer":  true,
+	"number":   true,
+	"boolean":  true,
+	"array":    true,
+	"object":   true,
+	"null":     true,
+	"uuid":     true,
+	"datetime": true,
+	"date":     true,
+	"email":    true,
+	"url":      true,
+}
+
+var placeholderPattern = regexp.MustCompile(`^@([a-z]+)@`)
+
+type placeholder struct {
+	name      string
+	expanders []expander
+}
+
+type expander struct {
+	name      string
+	arguments []interface{}
+}
+
+// parsePlaceholder parses placeholder expression like "@string@.contains('x')".
+// It returns false if the string is not a placeholder and must be compared as is.
+func parsePlaceholder(s string) (*placeholder, bool, error) {
+	match := placeholderPattern.FindStringSubmatch(s)
+	if match == nil || !placeholderNames[match[1]] {
+		return nil, false, nil
+	}
+	p := &placeholder{name: match[1]}
+	rest := s[len(match[0]):]
+	for rest != "" {
+		e, tail, err := parseExpander(rest)
+		if err != nil {
+			return nil, true, err
+		}
+		p.expanders = append(p.expanders, e)
+		rest = tail
+	}
+
+	return p, true, nil
+}
+
+var expanderPattern = regexp.MustCompile(`^\.([a-zA-Z]+)\(`)
+
+func parseExpander(s string) (expander, string, error) {
+	match := expanderPattern.FindStringSubmatch(s)
+	if match == nil {
+		return expander{}, "", fmt.Errorf(`invalid expander "%s"`, s)
+	}
+	e := expander{name: match[1]}
+	s = strings.TrimLeft(s[len(match[0]):], " ")
+	for !strings.HasPrefix(s, ")") {
+		argument, tail, err := parseArgument(s)
+		if err != nil {
+			return expander{}, "", fmt.Errorf(`invalid arguments of expander "%s": %w`, e.name, err)
+		}
+		e.arguments = append(e.arguments, argument)
+		s = strings.TrimLeft(tail, " ")
+		if strings.HasPrefix(s, ",") {
+			s = strings.TrimLeft(s[1:], " ")
+		} else if !strings.HasPrefix(s, ")") {
+			return expander{}, "", fmt.Errorf(`invalid arguments of expander "%s"`, e.name)
+		}
+	}
+
+	return e, s[1:], nil
+}
+
+var numberArgumentPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?`)
+
+func parseArgument(s string) (interface{}, string, error) {
+	if strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`) {
+		quote := s[0]
+		var value strings.Builder
+		for i := 1; i < len(s); i++ {
+			switch {
+			case s[i] == '\\' && i+1 < len(s):
+				i++
+				value.WriteByte(s[i])
+			case s[i] == quote:
+				return value.String(), s[i+1:], nil
+			default:
+				value.WriteByte(s[i])
+			}
+		}
+		return nil, "", fmt.Errorf("unterminated string")
+	}
+	if number := numberArgumentPattern.FindString(s); number != "" {
+		f, err := strconv.ParseFloat(number, 64)
+		return f, s[len(number):], err
+	}
+	for _, keyword := range []string{"true", "false"} {
+		if strings.HasPrefix(s, keyword) {
+			return keyword == "true", s[len(keyword):], nil
+		}
+	}
+
+	return nil, "", fmt.Errorf(`unexpected argument "%s"`, s)
+}
+
+// expand calls assertions of the fluent API for each expander of the placeholder.
+func (p *placeholder) expand(assertion interface{}, msgAndArgs []interface{}) error {
+	for _, e := range p.expanders {
+		if err := applyExpander(assertion, e, msgAndArgs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyExpander calls the assertion for the expander. Assertions are nil-safe, so when the node
+// has another type, the expander is still checked and unknown or invalid expanders are reported.
+//
+//nolint:cyclop,gocognit,gocyclo,funlen
+func applyExpander(assertion interface{}, e expander, msgAndArgs []interface{}) error {
+	switch a := assertion.(type) {
+	case *StringAssertion:
+		switch e.name {
+		case "isEmpty":
+			a.IsEmpty(msgAndArgs...)
+		case "isNotEmpty":
+			a.IsNotEmpty(msgAndArgs...)
+		case "oneOf":
+			values := make([]string, len(e.arguments))
+			for i := range e.arguments {
+				s, err := stringArgument(e, i)
+				if err != nil {
+					return err
+				}
+				values[i] = s
+			}
+			a.equalToOneOf(values, msgAndArgs)
+		case "contains", "notContains", "startsWith", "endsWith", "matchRegex":
+			s, err := stringArgument(e, 0)
+			if err != nil {
+				return err
+			}
+			switch e.name {
+			case "contains":
+				a.Contains(s, msgAndArgs...)
+			case "notContains":
+				a.NotContains(s, msgAndArgs...)
+			case "startsWith":
+				a.Matches("^"+regexp.QuoteMeta(s), msgAndArgs...)
+			case "endsWith":
+				a.Matches(regexp.QuoteMeta(s)+"$", msgAndArgs...)
+			case "matchRegex":
+				re, err := regexp.Compile(s)
+				if err != nil {
+					return err
+				}
+				a.Matches(re, msgAndArgs...)
+			}
+		case "length", "minLength", "maxLength":
+			n, err := integerArgument(e)
+			if err != nil {
+				return err
+			}
+			switch e.name {
+			case "length":
+				a.WithLength(n, msgAndArgs...)
+			case "minLength":
+				a.WithLengthGreaterThanOrEqual(n, msgAndArgs...)
+			case "maxLength":
+				a.WithLengthLessThanOrEqual(n, msgAndArgs...)
+			}
+		default:
+			return unknownExpander(e)
+		}
+	case *IntegerAssertion:
+		n, err := integerArgument(e)
+		if err != nil {
+			return err
+		}
+		switch e.name {
+		case "greaterThan":
+			a.GreaterThan(n, msgAndArgs...)
+		case "greaterThanOrEqual":
+			a.GreaterThanOrEqual(n, msgAndArgs...)
+		case "lessThan":
+			a.LessThan(n, msgAndArgs...)
+		case "lessThanOrEqual":
+			a.LessThanOrEqual(n, msgAndArgs...)
+		default:
+			return unknownExpander(e)
+		}
+	case *NumberAssertion:
+		f, err := numberArgument(e)
+		if err != nil {
+			return err
+		}
+		switch e.name {
+		case "greaterThan":
+			a.GreaterThan(f, msgAndArgs...)
+		case "greaterThanOrEqual":
+			a.GreaterThanOrEqual(f, msgAndArgs...)
+		case "lessThan":
+			a.LessThan(f, msgAndArgs...)
+		case "lessThanOrEqual":
+			a.LessThanOrEqual(f, msgAndArgs...)
+		default:
+			return unknownExpander(e)
+		}
+	case *ArrayAssertion:
+		switch e.name {
+		case "isEmpty":
+			a.WithLength(0, msgAndArgs...)
+		case "isNotEmpty":
+			a.WithLengthGreaterThan(0, msgAndArgs...)
+		case "count":
+			n, err := integerArgument(e)
+			if err != nil {
+				return err
+			}
+			a.WithLength(n, msgAndArgs...)
+		default:
+			return unknownExpander(e)
+		}
+	case *ObjectAssertion:
+		switch e.name {
+		case "isEmpty":
+			a.WithPropertiesCount(0, msgAndArgs...)
+		case "isNotEmpty":
+			a.WithPropertiesCountGreaterThan(0, msgAndArgs...)
+		case "count":
+			n, err := integerArgument(e)
+			if err != nil {
+				return err
+			}
+			a.WithPropertiesCount(n, msgAndArgs...)
+		default:
+			return unknownExpander(e)
+		}
+	case *TimeAssertion:
+		s, err := stringArgument(e, 0)
+		if err != nil {
+			return err
+		}
+		t, err := parseTimeArgument(s)
+		if err != nil {
+			return err
+		}
+		switch e.name {
+		case "before":
+			a.Before(t, msgAndArgs...)
+		case "after":
+			a.After(t, msgAndArgs...)
+		default:
+			return unknownExpander(e)
+		}
+	}
+
+	return nil
+}
+
+func unknownExpander(e expander) error {
+	return fmt.Errorf(`unknown expander "%s"`, e.name)
+}
+
+func stringArgument(e expander, i int) (string, error) {
+	if i < len(e.arguments) {
+		if s, ok := e.arguments[i].(string); ok {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf(`expander "%s" expects string argument`, e.name)
+}
+
+func numberArgument(e expander) (float64, error) {
+	if len(e.arguments) == 1 {
+		if f, ok := e.arguments[0].(float64); ok {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf(`expander "%s" expects number argument`, e.name)
+}
+
+func integerArgument(e expander) (int, error) {
+	f, err := numberArgument(e)
+	if err != nil || f != float64(int(f)) {
+		return 0, fmt.Errorf(`expander "%s" expects integer argument`, e.name)
+	}
+	return int(f), nil
+}
+
+func parseTimeArgument(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,152 @@
+package assertjson_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/apitesttest"
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+)
+
+const patternJSON = `{
+	"id": "23d7d9fc-9b3e-4d7a-9d6b-7f3a3b0b7e52",
+	"name": "John Doe",
+	"email": "john@example.com",
+	"age": 42,
+	"rating": 4.5,
+	"active": true,
+	"website": "https://example.com",
+	"createdAt": "2022-10-16T12:00:00Z",
+	"birthday": "1980-01-02",
+	"deletedAt": null,
+	"tags": ["a", "b", "c"],
+	"meta": {"version": 1, "source": "api"}
+}`
+
+func TestAssertNode_MatchesJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		assert       func(json *assertjson.AssertJSON)
+		wantMessages []string
+	}{
+		{
+			name: "placeholders",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().MatchesJSON(`{
+					"id": "@uuid@",
+					"name": "@string@.contains('John').startsWith('J').isNotEmpty()",
+					"email": "@email@",
+					"age": "@integer@.greaterThan(0).lessThan(100)",
+					"rating": "@number@.greaterThanOrEqual(4.5)",
+					"active": "@boolean@",
+					"website": "@url@",
+					"createdAt": "@datetime@.after('2022-01-01T00:00:00Z')",
+					"birthday": "@date@.before('2000-01-01')",
+					"deletedAt": "@null@",
+					"tags": "@array@.count(3)",
+					"meta": "@object@.isNotEmpty()"
+				}`)
+			},
+		},
+		{
+			name: "literal values and rest placeholder",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().MatchesJSON(`{
+					"name": "John Doe",
+					"age": 42,
+					"active": true,
+					"deletedAt": null,
+					"tags": ["a", "@string@", "@...@"],
+					"meta": {"version": "@ignore@", "source": "api"},
+					"@...@": ""
+				}`)
+			},
+		},
+		{
+			name: "placeholders failed",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().MatchesJSON(`{
+					"id": "@integer@",
+					"name": "@string@.endsWith('Smith')",
+					"age": "@integer@.lessThan(18)",
+					"createdAt": "@datetime@.before('2022-01-01T00:00:00Z')",
+					"@...@": ""
+				}`)
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "age": less than 18, actual is 42`,
+				`failed asserting that JSON node "createdAt": is time before "2022-01-01T00:00:00Z"`,
+				`value at path "id" is not numeric`,
+				`failed asserting that JSON node "name": matches "Smith$", actual is "John Doe"`,
+			},
+		},
+		{
+			name: "structure mismatch",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node().MatchesJSON(`{
+					"name": "Jane Doe",
+					"phone": "@string@",
+					"tags": ["a", "b"],
+					"meta": {"version": 1}
+				}`)
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "meta" matches pattern: unexpected properties "source"`,
+				`failed asserting that JSON node "name": equal to "Jane Doe", actual is "John Doe"`,
+				`failed asserting that JSON node "phone" exists`,
+				`failed asserting that JSON node "tags": is array with length is 2, actual is 3`,
+				`failed asserting that JSON node "" matches pattern: unexpected properties "active", "age"`,
+			},
+		},
+		{
+			name: "invalid pattern",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("name").MatchesJSON(`"@string@.unknown()"`)
+				json.Node("age").MatchesJSON(`"@integer@.greaterThan('x')"`)
+				json.Node("name").MatchesJSON(`"@string@.contains('x'"`)
+				json.Node("name").MatchesJSON(`{`)
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "name" matches pattern "@string@.unknown()": unknown expander "unknown"`,
+				`failed asserting that JSON node "age" matches pattern "@integer@.greaterThan('x')": expander "greaterThan" expects integer argument`,
+				`failed asserting that JSON node "name" matches pattern: invalid arguments of expander "contains"`,
+				`pattern is not valid JSON`,
+			},
+		},
+		{
+			name: "invalid expander of node with another type",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Node("age").MatchesJSON(`"@string@.unknown()"`)
+				json.Node("name").MatchesJSON(`"@integer@.greaterThan('x')"`)
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "age" is string`,
+				`failed asserting that JSON node "age" matches pattern "@string@.unknown()": unknown expander "unknown"`,
+				`value at path "name" is not numeric`,
+				`failed asserting that JSON node "name" matches pattern "@integer@.greaterThan('x')": expander "greaterThan" expects integer argument`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertjson.Has(tester, []byte(patternJSON), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestAssertNode_MatchesJSON_WhenExpanderFailed_ExpectMessage(t *testing.T) {
+	tester := &apitesttest.Recorder{}
+
+	assertjson.Has(tester, []byte(patternJSON), func(json *assertjson.AssertJSON) {
+		json.Node("name").MatchesJSON(`"@string@.oneOf('Jane Doe', 'John Smith')"`, "user name")
+	})
+
+	tester.AssertFailedWithMessages(t,
+		`failed asserting that JSON node "name": equal to one of values ("Jane Doe", "John Smith"), actual is "John Doe"`,
+	)
+	tester.AssertFailedWith(t, "user name")
+}
@@ -0,0 +1,163 @@
+package assertjson
+
+import (
+	"fmt"
+
+	"github.com/muonsoft/api-testing/internal/jsonpath"
+	"github.com/stretchr/testify/assert"
+)
+
+// Query selects JSON nodes by JSONPath expression (RFC 9535), for example "$.items[*].id",
+// "$..price" or "$.items[?@.active==true]". The root "$" of the expression is the current node.
+// It returns QueryAssertion to execute a chain of assertions for the matched nodes.
+func (j *AssertJSON) Query(expression string) *QueryAssertion {
+	j.t.Helper()
+	query, err := jsonpath.Parse(expression)
+	if err != nil {
+		j.fail(fmt.Sprintf(`failed to parse JSONPath "%s": %s`, expression, err.Error()))
+		return nil
+	}
+
+	return &QueryAssertion{
+		t:          j.t,
+		message:    j.message,
+		expression: expression,
+		json:       j,
+		nodes:      query.Select(j.data),
+	}
+}
+
+// QueryAssertion is used to build a chain of assertions for the nodes matched by JSONPath expression.
+type QueryAssertion struct {
+	t          TestingT
+	message    string
+	expression string
+	json       *AssertJSON
+	nodes      []jsonpath.Node
+}
+
+// Count returns count of the matched nodes.
+func (a *QueryAssertion) Count() int {
+	if a == nil {
+		return 0
+	}
+	return len(a.nodes)
+}
+
+// Values returns values of the matched nodes in the document order.
+func (a *QueryAssertion) Values() []interface{} {
+	if a == nil {
+		return nil
+	}
+	values := make([]interface{}, len(a.nodes))
+	for i, node := range a.nodes {
+		values[i] = node.Value
+	}
+	return values
+}
+
+// WithCount asserts that the JSONPath expression matches exact count of nodes.
+func (a *QueryAssertion) WithCount(expected int, msgAndArgs ...interface{}) *QueryAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	if len(a.nodes) != expected {
+		a.fail(fmt.Sprintf("matches %d nodes, actual is %d", expected, len(a.nodes)), msgAndArgs...)
+	}
+	return a
+}
+
+// IsEmpty asserts that the JSONPath expression matches no nodes.
+func (a *QueryAssertion) IsEmpty(msgAndArgs ...interface{}) *QueryAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	if len(a.nodes) > 0 {
+		a.fail(fmt.Sprintf("matches no nodes, actual count is %d", len(a.nodes)), msgAndArgs...)
+	}
+	return a
+}
+
+// IsNotEmpty asserts that the JSONPath expression matches at least one node.
+func (a *QueryAssertion) IsNotEmpty(msgAndArgs ...interface{}) *QueryAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	if len(a.nodes) == 0 {
+		a.fail("matches at least one node", msgAndArgs...)
+	}
+	return a
+}
+
+// Every executes callback function for node assertion on each matched node.
+func (a *QueryAssertion) Every(assertNode func(node *AssertNode)) *QueryAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	for _, node := range a.nodes {
+		assertNode(a.node(a.t, node))
+	}
+	return a
+}
+
+// Any asserts that at least one of the matched nodes passes the assertions of the callback function.
+// Failures of the other nodes are not reported.
+func (a *QueryAssertion) Any(assertNode func(node *AssertNode), msgAndArgs ...interface{}) *QueryAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	for _, node := range a.nodes {
+		r := &recorder{}
+		assertNode(a.node(r, node))
+		if !r.failed() {
+			return a
+		}
+	}
+	a.fail(fmt.Sprintf("has any of %d matched nodes passing the assertion", len(a.nodes)), msgAndArgs...)
+
+	return a
+}
+
+func (a *QueryAssertion) node(t TestingT, node jsonpath.Node) *AssertNode {
+	path := a.json.path.With(node.Path)
+	return &AssertNode{
+		t:         atPath(t, path),
+		message:   a.message,
+		path:      path,
+		value:     node.Value,
+		keys:      a.json.keys,
+		useNumber: a.json.useNumber,
+	}
+}
+
+func (a *QueryAssertion) fail(message string, msgAndArgs ...interface{}) {
+	a.t.Helper()
+	assert.Fail(
+		a.t,
+		fmt.Sprintf(`%sfailed asserting that JSONPath "%s" %s`, a.message, a.expression, message),
+		msgAndArgs...,
+	)
+}
+
+// recorder is used to run assertions without reporting failures. It does not implement FailNow,
+// so required assertions (see RequireNode) do not stop the test when they are used in predicates.
+type recorder struct {
+	messages []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Log(args ...interface{}) {}
+
+func (r *recorder) failed() bool {
+	return len(r.messages) > 0
+}
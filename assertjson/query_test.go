@@ -0,0 +1,109 @@
+package assertjson_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const queryJSON = `{
+	"items": [
+		{"id": 1, "name": "a", "active": true, "price": 10},
+		{"id": 2, "name": "b", "active": false, "price": 20},
+		{"id": 3, "name": "c", "active": true, "tags": [{"price": 5}]}
+	]
+}`
+
+func TestAssertJSON_Query(t *testing.T) {
+	tests := []struct {
+		name         string
+		assert       func(json *assertjson.AssertJSON)
+		wantMessages []string
+	}{
+		{
+			name: "count of matched nodes",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Query("$.items[*].id").WithCount(3).IsNotEmpty()
+				json.Query("$..price").WithCount(3)
+				json.Query("$.items[?@.active==true]").WithCount(2)
+				json.Query("$.items[?@.price > 100]").IsEmpty()
+			},
+		},
+		{
+			name: "count failed",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Query("$.items[*].id").WithCount(2)
+				json.Query("$.items[*].id").IsEmpty()
+				json.Query("$.missing").IsNotEmpty()
+			},
+			wantMessages: []string{
+				`failed asserting that JSONPath "$.items[*].id" matches 2 nodes, actual is 3`,
+				`failed asserting that JSONPath "$.items[*].id" matches no nodes, actual count is 3`,
+				`failed asserting that JSONPath "$.missing" matches at least one node`,
+			},
+		},
+		{
+			name: "every node",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Query("$.items[*].id").Every(func(node *assertjson.AssertNode) {
+					node.IsInteger().LessThan(3)
+				})
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "items[2].id": less than 3, actual is 3`,
+			},
+		},
+		{
+			name: "any node",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Query("$.items[*].name").Any(func(node *assertjson.AssertNode) {
+					node.IsString().EqualTo("b")
+				})
+				json.Query("$.items[*].name").Any(func(node *assertjson.AssertNode) {
+					node.IsString().EqualTo("d")
+				})
+			},
+			wantMessages: []string{
+				`failed asserting that JSONPath "$.items[*].name" has any of 3 matched nodes passing the assertion`,
+			},
+		},
+		{
+			name: "query at node",
+			assert: func(json *assertjson.AssertJSON) {
+				json.At("items", 2).Query("$.tags[*].price").Every(func(node *assertjson.AssertNode) {
+					node.IsInteger().EqualTo(6)
+				})
+			},
+			wantMessages: []string{
+				`failed asserting that JSON node "items[2].tags[0].price": equal to 6, actual is 5`,
+			},
+		},
+		{
+			name: "invalid expression",
+			assert: func(json *assertjson.AssertJSON) {
+				json.Query("items[*]").WithCount(1)
+			},
+			wantMessages: []string{
+				`failed to parse JSONPath "items[*]": invalid JSONPath syntax at position 0`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertjson.Has(tester, []byte(queryJSON), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestQueryAssertion_Values(t *testing.T) {
+	assertjson.Has(t, []byte(queryJSON), func(json *assertjson.AssertJSON) {
+		assert.Equal(t, []interface{}{"a", "c"}, json.Query("$.items[?@.active == true].name").Values())
+		assert.Equal(t, 0, json.Query("$.missing").Count())
+	})
+}
@@ -0,0 +1,37 @@
+package assertjson
+
+import (
+	"github.com/muonsoft/api-testing/internal/failfast"
+)
+
+// RequireT is a TestingT that can stop the test, it is implemented by *testing.T.
+type RequireT interface {
+	TestingT
+	FailNow()
+}
+
+// RequireHas works like Has, but stops the test by calling FailNow on the first failed assertion.
+func RequireHas(t RequireT, data []byte, jsonAssert JSONAssertFunc, options ...ParseOption) {
+	t.Helper()
+	Has(failfast.New(t), data, jsonAssert, options...)
+}
+
+// RequireFileHas works like FileHas, but stops the test by calling FailNow on the first failed assertion.
+func RequireFileHas(t RequireT, filename string, jsonAssert JSONAssertFunc, options ...ParseOption) {
+	t.Helper()
+	FileHas(failfast.New(t), filename, jsonAssert, options...)
+}
+
+// RequireNode works like Node, but the test is stopped by calling FailNow on the first failed
+// assertion of the node (including a chain of fluent assertions). If the underlying TestingT
+// cannot stop the test, it works the same way as Node.
+func (j *AssertJSON) RequireNode(path ...interface{}) *AssertNode {
+	j.t.Helper()
+	if t, ok := j.t.(RequireT); ok {
+		required := *j
+		required.t = failfast.New(t)
+		return required.Node(path...)
+	}
+
+	return j.Node(path...)
+}
@@ -0,0 +1,74 @@
+package assertjson_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireHas(t *testing.T) {
+	tester := &mock.Tester{}
+	executed := false
+
+	tester.Run(func() {
+		assertjson.RequireHas(tester, []byte(`{"id":1}`), func(json *assertjson.AssertJSON) {
+			json.Node("name").IsString().EqualTo("John")
+			executed = true
+		})
+	})
+
+	assert.True(t, tester.Stopped())
+	assert.False(t, executed)
+	tester.AssertContains(t, []string{`failed to find JSON node "name": [name] not found`})
+}
+
+func TestRequireHas_FluentAssertion(t *testing.T) {
+	tester := &mock.Tester{}
+
+	tester.Run(func() {
+		assertjson.RequireHas(tester, []byte(`{"items":[1, 2]}`), func(json *assertjson.AssertJSON) {
+			json.Node("items").IsArray().WithLength(3).WithLengthGreaterThan(5)
+		})
+	})
+
+	assert.True(t, tester.Stopped())
+	tester.AssertContains(t, []string{`failed asserting that JSON node "items": is array with length is 3, actual is 2`})
+}
+
+func TestRequireHas_Passed(t *testing.T) {
+	tester := &mock.Tester{}
+
+	tester.Run(func() {
+		assertjson.RequireHas(tester, []byte(`{"id":1,"items":[1]}`), func(json *assertjson.AssertJSON) {
+			json.Node("id").IsInteger().EqualTo(1)
+			json.Node("items").IsArray().None(func(json *assertjson.AssertJSON) {
+				json.Node().IsString()
+			})
+		})
+	})
+
+	assert.False(t, tester.Stopped())
+	tester.AssertContains(t, nil)
+}
+
+func TestAssertJSON_RequireNode(t *testing.T) {
+	tester := &mock.Tester{}
+	executed := false
+
+	tester.Run(func() {
+		assertjson.Has(tester, []byte(`{"id":"1"}`), func(json *assertjson.AssertJSON) {
+			json.Node("id").IsString().EqualTo("2")
+			json.RequireNode("id").IsInteger()
+			executed = true
+		})
+	})
+
+	assert.True(t, tester.Stopped())
+	assert.False(t, executed)
+	tester.AssertContains(t, []string{
+		`failed asserting that JSON node "id": equal to "2", actual is "1"`,
+		`value at path "id" is not numeric`,
+	})
+}
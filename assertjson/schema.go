@@ -0,0 +1,95 @@
+package assertjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/js"
+	"github.com/muonsoft/api-testing/internal/jsonschema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Schema is a JSON Schema used by MatchesSchema and HasSchema. Draft 2020-12 is used by default,
+// draft-07 is used when "$schema" keyword of the root schema points to it.
+type Schema struct {
+	document  interface{}
+	validator *jsonschema.Validator
+}
+
+// LoadSchema reads JSON Schema from the file. References to other files ("$ref": "common.json#/$defs/id")
+// are resolved relative to the directory of the file.
+func LoadSchema(filename string) (*Schema, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("read schema: %w", err)
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf(`schema "%s" has invalid JSON: %w`, filename, err)
+	}
+	resolver, err := jsonschema.NewFileResolver(filename, document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Schema{document: document, validator: jsonschema.NewValidatorWithResolver(resolver, dialectOf(document))}, nil
+}
+
+// ParseSchema parses JSON Schema from the byte slice. References to other files
+// are resolved relative to the working directory.
+func ParseSchema(data []byte) (*Schema, error) {
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("schema has invalid JSON: %w", err)
+	}
+	resolver, err := jsonschema.NewFileResolver("schema.json", document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Schema{document: document, validator: jsonschema.NewValidatorWithResolver(resolver, dialectOf(document))}, nil
+}
+
+// HasSchema asserts that JSON data is valid against the JSON Schema.
+// Each violation is reported as a separate failure with the path to the invalid node.
+func HasSchema(t TestingT, data []byte, schema *Schema) {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		assert.Fail(t, fmt.Sprintf("data has invalid JSON: %s", err.Error()))
+		return
+	}
+	matchSchema(t, "", nil, value, schema)
+}
+
+// MatchesSchema asserts that the JSON node is valid against the JSON Schema.
+// Each violation is reported as a separate failure with the path to the invalid node.
+func (node *AssertNode) MatchesSchema(schema *Schema) {
+	node.t.Helper()
+	if node.exists() {
+		matchSchema(node.t, node.message, node.path, node.value, schema)
+	}
+}
+
+func matchSchema(t TestingT, message string, path *js.Path, value interface{}, schema *Schema) {
+	t.Helper()
+	for _, violation := range schema.validator.Validate(schema.document, value) {
+		violationPath := path.With(violation.Path)
+		assert.Fail(atPath(t, violationPath), message+fmt.Sprintf(
+			`failed asserting that JSON node "%s" matches schema: %s`,
+			violationPath.String(), violation.Message,
+		))
+	}
+}
+
+func dialectOf(document interface{}) jsonschema.Dialect {
+	if object, ok := document.(map[string]interface{}); ok {
+		if uri, ok := object["$schema"].(string); ok && strings.Contains(uri, "draft-07") {
+			return jsonschema.Draft7
+		}
+	}
+
+	return jsonschema.Draft2020
+}
@@ -0,0 +1,88 @@
+package assertjson_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasSchema(t *testing.T) {
+	tests := []struct {
+		name         string
+		schema       string
+		json         string
+		wantMessages []string
+	}{
+		{
+			name:   "valid user",
+			schema: "testdata/schema/user.schema.json",
+			json:   `{"id":1,"name":"John","email":"john@example.com","address":{"city":"Moscow"},"tags":["admin"]}`,
+		},
+		{
+			name:   "invalid user",
+			schema: "testdata/schema/user.schema.json",
+			json:   `{"id":0,"name":"","address":{},"tags":["Admin"],"role":"admin"}`,
+			wantMessages: []string{
+				`failed asserting that JSON node "address" matches schema: required property "city" is missing`,
+				`failed asserting that JSON node "id" matches schema: must be greater than or equal to 1, actual is 0`,
+				`failed asserting that JSON node "name" matches schema: must be string with length greater than or equal to 1, actual is 0`,
+				`failed asserting that JSON node "" matches schema: property "role" is not allowed`,
+				`failed asserting that JSON node "tags[0]" matches schema: must match pattern "^[a-z]+$", actual is "Admin"`,
+			},
+		},
+		{
+			name:   "valid draft-07",
+			schema: "testdata/schema/draft07.schema.json",
+			json:   `{"point":[1,2],"card":"1234","billing":"address"}`,
+		},
+		{
+			name:   "invalid draft-07",
+			schema: "testdata/schema/draft07.schema.json",
+			json:   `{"point":[1,2,3],"card":"1234"}`,
+			wantMessages: []string{
+				`failed asserting that JSON node "point[2]" matches schema: value is not allowed`,
+				`failed asserting that JSON node "" matches schema: property "billing" is required when property "card" is present`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schema, err := assertjson.LoadSchema(test.schema)
+			require.NoError(t, err)
+			tester := &mock.Tester{}
+
+			assertjson.HasSchema(tester, []byte(test.json), schema)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestAssertNode_MatchesSchema(t *testing.T) {
+	schema, err := assertjson.ParseSchema([]byte(`{
+		"type": "array",
+		"items": {"$ref": "testdata/schema/common.schema.json#/$defs/id"}
+	}`))
+	require.NoError(t, err)
+	tester := &mock.Tester{}
+
+	assertjson.Has(tester, []byte(`{"data":{"ids":[1,"2"]}}`), func(json *assertjson.AssertJSON) {
+		json.Node("data", "ids").MatchesSchema(schema)
+	})
+
+	tester.AssertContains(t, []string{
+		`failed asserting that JSON node "data.ids[1]" matches schema: must be of type integer, actual is string`,
+	})
+}
+
+func TestHasSchema_WhenInvalidReference_ExpectFailure(t *testing.T) {
+	schema, err := assertjson.ParseSchema([]byte(`{"$ref": "testdata/schema/missing.json"}`))
+	require.NoError(t, err)
+	tester := &mock.Tester{}
+
+	assertjson.HasSchema(tester, []byte(`{}`), schema)
+
+	tester.AssertContains(t, []string{`matches schema: invalid reference "testdata/schema/missing.json"`})
+}
@@ -0,0 +1,169 @@
+package assertjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/muonsoft/api-testing/internal/js"
+	"github.com/muonsoft/api-testing/internal/jsondiff"
+	"github.com/muonsoft/api-testing/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+// Placeholders are used to replace volatile values in snapshots.
+const (
+	UUIDPlaceholder     = "@uuid@"
+	DateTimePlaceholder = "@datetime@"
+	JWTPlaceholder      = "@jwt@"
+)
+
+// SnapshotOption is used to set up snapshot matching.
+type SnapshotOption func(options *snapshot.Options)
+
+// MaskNode replaces value of the JSON node at the path with the placeholder before comparing with snapshot.
+// The path is relative to the root of the snapshot and is set the same way as for AssertJSON.Node().
+// Invalid path is reported as a failure of the snapshot assertion.
+func MaskNode(placeholder string, path ...interface{}) SnapshotOption {
+	path = preprocessPath(path)
+	target, err := js.PathFromAny(path...)
+
+	return func(options *snapshot.Options) {
+		if err != nil {
+			options.Errors = append(options.Errors, fmt.Errorf("invalid path of masked node: %w", err))
+			return
+		}
+		options.Masks = append(options.Masks, func(p *js.Path, value interface{}) (interface{}, bool) {
+			if p.Equal(target) {
+				return placeholder, true
+			}
+			return nil, false
+		})
+	}
+}
+
+// MaskUUIDs replaces all strings with UUID by "@uuid@" placeholder before comparing with snapshot.
+func MaskUUIDs() SnapshotOption {
+	return maskStrings(UUIDPlaceholder, func(s string) bool {
+		_, err := uuid.FromString(s)
+		return err == nil
+	})
+}
+
+// MaskTimes replaces all strings with time in RFC3339 format by "@datetime@" placeholder
+// before comparing with snapshot.
+func MaskTimes() SnapshotOption {
+	return maskStrings(DateTimePlaceholder, func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil
+	})
+}
+
+// MaskJWTs replaces all strings with JSON Web Token by "@jwt@" placeholder before comparing with snapshot.
+// Signature of the token is not verified.
+func MaskJWTs() SnapshotOption {
+	return maskStrings(JWTPlaceholder, func(s string) bool {
+		_, _, err := jwt.NewParser().ParseUnverified(s, jwt.MapClaims{})
+		return err == nil
+	})
+}
+
+// MatchesSnapshot asserts that JSON data matches the snapshot stored in "testdata/{name}.json" file.
+// Before comparing JSON is normalized (indented with sorted keys) and volatile values
+// are replaced by masks. Run tests with UPDATE_SNAPSHOTS=1 to rewrite the snapshot file.
+func MatchesSnapshot(t TestingT, name string, data []byte, options ...SnapshotOption) {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		assert.Fail(t, fmt.Sprintf("data has invalid JSON: %s", err.Error()))
+		return
+	}
+	matchSnapshot(t, "", name, value, options)
+}
+
+// MatchesSnapshot asserts that the JSON node matches the snapshot stored in "testdata/{name}.json" file.
+// Before comparing JSON is normalized (indented with sorted keys) and volatile values
+// are replaced by masks. Run tests with UPDATE_SNAPSHOTS=1 to rewrite the snapshot file.
+func (node *AssertNode) MatchesSnapshot(name string, options ...SnapshotOption) {
+	node.t.Helper()
+	if node.exists() {
+		matchSnapshot(node.t, node.message, name, copyValue(node.value), options)
+	}
+}
+
+func matchSnapshot(t TestingT, message, name string, value interface{}, options []SnapshotOption) {
+	t.Helper()
+	opts := &snapshot.Options{}
+	for _, setOption := range options {
+		setOption(opts)
+	}
+	if len(opts.Errors) > 0 {
+		for _, err := range opts.Errors {
+			assert.Fail(t, message+err.Error())
+		}
+		return
+	}
+	actual, err := snapshot.NormalizeJSON(value, opts.Masks)
+	if err != nil {
+		assert.Fail(t, message+fmt.Sprintf("failed to normalize JSON: %s", err.Error()))
+		return
+	}
+
+	filename := snapshot.Filename(name, ".json")
+	if snapshot.IsUpdate() {
+		if err := snapshot.Save(filename, actual); err != nil {
+			assert.Fail(t, message+err.Error())
+		}
+		return
+	}
+
+	expected, err := snapshot.Load(filename)
+	if err != nil {
+		assert.Fail(t, message+err.Error())
+		return
+	}
+	if bytes.Equal(expected, actual) {
+		return
+	}
+	message += fmt.Sprintf(`failed asserting that JSON matches snapshot "%s"`, filename)
+	differences, ok := snapshot.CompareJSON(expected, actual)
+	if !ok {
+		assert.Equal(t, string(expected), string(actual), message)
+	} else if len(differences) > 0 {
+		assert.Fail(t, message+", differences:\n"+jsondiff.Format(differences))
+	}
+}
+
+func maskStrings(placeholder string, isVolatile func(s string) bool) SnapshotOption {
+	return func(options *snapshot.Options) {
+		options.Masks = append(options.Masks, func(_ *js.Path, value interface{}) (interface{}, bool) {
+			if s, ok := value.(string); ok && isVolatile(s) {
+				return placeholder, true
+			}
+			return nil, false
+		})
+	}
+}
+
+// copyValue makes deep copy of decoded JSON value, so masks will not modify original data.
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			c[key] = copyValue(element)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, element := range v {
+			c[i] = copyValue(element)
+		}
+		return c
+	}
+
+	return value
+}
@@ -0,0 +1,148 @@
+package assertjson_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesSnapshot(t *testing.T) {
+	tests := []struct {
+		name         string
+		json         string
+		assert       func(t *mock.Tester, data []byte)
+		wantMessages []string
+	}{
+		{
+			name: "matches with masks",
+			json: `{
+				"id": "a1f0c5d6-3b4e-4f5a-8b6c-7d8e9f0a1b2c",
+				"name": "John <Doe>",
+				"createdAt": "2022-10-16T15:14:32+03:00",
+				"token": ` + jsonWithJWT(jwt.MapClaims{"sub": "user"}) + `,
+				"counter": 123
+			}`,
+			assert: func(t *mock.Tester, data []byte) {
+				assertjson.MatchesSnapshot(t, "user", data,
+					assertjson.MaskUUIDs(),
+					assertjson.MaskTimes(),
+					assertjson.MaskJWTs(),
+					assertjson.MaskNode("@integer@", "counter"),
+				)
+			},
+		},
+		{
+			name: "node matches snapshot",
+			json: `{"data": {"items": [{"id": 1}, {"id": 2}]}}`,
+			assert: func(t *mock.Tester, data []byte) {
+				assertjson.Has(t, data, func(json *assertjson.AssertJSON) {
+					json.Node("data").MatchesSnapshot("items", assertjson.MaskNode("@id@", "items", 1, "id"))
+					json.Node("data", "items", 1, "id").IsInteger().EqualTo(2)
+				})
+			},
+		},
+		{
+			name: "does not match",
+			json: `{"data": {"items": [{"id": 3}]}}`,
+			assert: func(t *mock.Tester, data []byte) {
+				assertjson.Has(t, data, func(json *assertjson.AssertJSON) {
+					json.Node("data").MatchesSnapshot("items")
+				})
+			},
+			wantMessages: []string{
+				`failed asserting that JSON matches snapshot "testdata/items.json", differences:`,
+			},
+		},
+		{
+			name: "snapshot does not exist",
+			json: `{}`,
+			assert: func(t *mock.Tester, data []byte) {
+				assertjson.MatchesSnapshot(t, "missing", data)
+			},
+			wantMessages: []string{
+				`snapshot does not exist: "testdata/missing.json", run tests with UPDATE_SNAPSHOTS=1 to create it`,
+			},
+		},
+		{
+			name: "invalid mask path",
+			json: `{"id": 1}`,
+			assert: func(t *mock.Tester, data []byte) {
+				assertjson.MatchesSnapshot(t, "user", data, assertjson.MaskNode("@id@", 1.5))
+			},
+			wantMessages: []string{
+				`invalid path of masked node: invalid path: should contain only strings and numbers`,
+			},
+		},
+		{
+			name: "invalid JSON",
+			json: `{`,
+			assert: func(t *mock.Tester, data []byte) {
+				assertjson.MatchesSnapshot(t, "user", data)
+			},
+			wantMessages: []string{
+				`data has invalid JSON: unexpected end of JSON input`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			test.assert(tester, []byte(test.json))
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+// update is the flag commonly defined by golden-file tests, it must not conflict with the package.
+var update = flag.Bool("update", false, "update golden files")
+
+func TestMatchesSnapshot_WhenUpdate_ExpectFileSaved(t *testing.T) {
+	tests := []struct {
+		name   string
+		enable func() (disable func())
+	}{
+		{
+			name: "environment variable",
+			enable: func() func() {
+				require.NoError(t, os.Setenv("UPDATE_SNAPSHOTS", "1"))
+				return func() { require.NoError(t, os.Unsetenv("UPDATE_SNAPSHOTS")) }
+			},
+		},
+		{
+			name: "flag defined by test binary",
+			enable: func() func() {
+				*update = true
+				return func() { *update = false }
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wd, err := os.Getwd()
+			require.NoError(t, err)
+			require.NoError(t, os.Chdir(t.TempDir()))
+			disable := test.enable()
+			defer func() {
+				disable()
+				require.NoError(t, os.Chdir(wd))
+			}()
+			tester := &mock.Tester{}
+
+			assertjson.MatchesSnapshot(tester, "nested/name", []byte(`{"b":1,"a":"<>"}`))
+
+			tester.AssertContains(t, nil)
+			data, err := os.ReadFile(filepath.Join("testdata", "nested", "name.json"))
+			require.NoError(t, err)
+			assert.Equal(t, "{\n\t\"a\": \"<>\",\n\t\"b\": 1\n}\n", string(data))
+		})
+	}
+}
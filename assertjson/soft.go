@@ -0,0 +1,139 @@
+package assertjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/js"
+)
+
+// Failure is a failed assertion collected by SoftAssertions.
+type Failure struct {
+	// Path is a path to the failed JSON node. It is empty for failures not related to a node.
+	Path    string
+	Message string
+}
+
+// SoftAssertions is a TestingT that collects failures instead of reporting them immediately.
+// Collected failures are reported to the parent test as one summary by the Report method.
+// It can be passed to any function of assertjson, assertxml and apitest packages.
+type SoftAssertions struct {
+	t        TestingT
+	failures []Failure
+}
+
+// NewSoftAssertions creates SoftAssertions collecting failures for the parent test.
+func NewSoftAssertions(t TestingT) *SoftAssertions {
+	return &SoftAssertions{t: t}
+}
+
+// HasSoft works like Has, but assertions are run in soft mode: all failures are
+// reported at the end as one error with the summary followed by the indented JSON document.
+func HasSoft(t TestingT, data []byte, jsonAssert JSONAssertFunc, options ...ParseOption) {
+	t.Helper()
+	soft := NewSoftAssertions(t)
+	Has(soft, data, jsonAssert, options...)
+	soft.Report(indentJSON(data))
+}
+
+// Helper marks the calling function as a test helper function.
+func (s *SoftAssertions) Helper() {
+	s.t.Helper()
+}
+
+// Error collects failure.
+func (s *SoftAssertions) Error(args ...interface{}) {
+	s.collect("", fmt.Sprint(args...))
+}
+
+// Errorf collects failure.
+func (s *SoftAssertions) Errorf(format string, args ...interface{}) {
+	s.collect("", fmt.Sprintf(format, args...))
+}
+
+// Log passes arguments to the parent test.
+func (s *SoftAssertions) Log(args ...interface{}) {
+	s.t.Helper()
+	s.t.Log(args...)
+}
+
+// Failures returns failures collected since the last report.
+func (s *SoftAssertions) Failures() []Failure {
+	return s.failures
+}
+
+// Report reports collected failures to the parent test as one error. The summary lists
+// failures with paths to the JSON nodes and ends with details (for example, a dump of the
+// tested document). Nothing is reported if all assertions passed.
+func (s *SoftAssertions) Report(details string) {
+	s.t.Helper()
+	if len(s.failures) == 0 {
+		return
+	}
+
+	summary := &strings.Builder{}
+	fmt.Fprintf(summary, "soft assertions: %d failed", len(s.failures))
+	for i, failure := range s.failures {
+		if failure.Path != "" {
+			fmt.Fprintf(summary, "\n#%d at \"%s\":\n", i+1, failure.Path)
+		} else {
+			fmt.Fprintf(summary, "\n#%d:\n", i+1)
+		}
+		summary.WriteString(failure.Message)
+	}
+	if details != "" {
+		summary.WriteString("\n" + details)
+	}
+	s.failures = nil
+
+	s.t.Errorf("%s", summary.String())
+}
+
+func (s *SoftAssertions) collect(path, message string) {
+	s.failures = append(s.failures, Failure{Path: path, Message: strings.Trim(message, "\n")})
+}
+
+// softNode collects failures of the assertions on the JSON node with the path to the node.
+type softNode struct {
+	soft *SoftAssertions
+	path string
+}
+
+func (n *softNode) Helper() {
+	n.soft.t.Helper()
+}
+
+func (n *softNode) Error(args ...interface{}) {
+	n.soft.collect(n.path, fmt.Sprint(args...))
+}
+
+func (n *softNode) Errorf(format string, args ...interface{}) {
+	n.soft.collect(n.path, fmt.Sprintf(format, args...))
+}
+
+func (n *softNode) Log(args ...interface{}) {
+	n.soft.t.Helper()
+	n.soft.t.Log(args...)
+}
+
+// atPath returns TestingT for the assertions on the JSON node at the given path. In soft mode
+// failures are collected with the path to the node, otherwise t is returned as is.
+func atPath(t TestingT, path *js.Path) TestingT {
+	switch soft := t.(type) {
+	case *SoftAssertions:
+		return &softNode{soft: soft, path: path.String()}
+	case *softNode:
+		return &softNode{soft: soft.soft, path: path.String()}
+	}
+	return t
+}
+
+func indentJSON(data []byte) string {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "\t"); err != nil {
+		return string(data)
+	}
+	return indented.String()
+}
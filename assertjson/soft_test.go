@@ -0,0 +1,77 @@
+package assertjson_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasSoft(t *testing.T) {
+	tester := &mock.Tester{}
+
+	assertjson.HasSoft(tester, []byte(`{"id":1,"items":[{"name":"a"}]}`), func(json *assertjson.AssertJSON) {
+		json.Node("id").IsInteger().EqualTo(2)
+		json.Node("items", 0, "name").IsString().EqualTo("b")
+		json.Node("missing").Exists()
+	})
+
+	tester.AssertContains(t, []string{"soft assertions: 3 failed\n#1 at \"id\":\n"})
+	tester.AssertContains(t, []string{`#2 at "items[0].name":`})
+	tester.AssertContains(t, []string{`failed asserting that JSON node "missing" exists`})
+	tester.AssertContains(t, []string{"{\n\t\"id\": 1,\n\t\"items\": ["})
+}
+
+func TestHasSoft_Passed(t *testing.T) {
+	tester := &mock.Tester{}
+
+	assertjson.HasSoft(tester, []byte(`{"id":1}`), func(json *assertjson.AssertJSON) {
+		json.Node("id").IsInteger().EqualTo(1)
+	})
+
+	tester.AssertContains(t, nil)
+}
+
+func TestSoftAssertions_Failures(t *testing.T) {
+	tester := &mock.Tester{}
+	soft := assertjson.NewSoftAssertions(tester)
+
+	assertjson.Has(soft, []byte(`{"id":"1"}`), func(json *assertjson.AssertJSON) {
+		json.Node("id").IsInteger()
+	})
+	assertjson.Has(soft, []byte(`{`), func(json *assertjson.AssertJSON) {})
+
+	failures := soft.Failures()
+	if assert.Len(t, failures, 2) {
+		assert.Equal(t, "id", failures[0].Path)
+		assert.Contains(t, failures[0].Message, `value at path "id" is not numeric`)
+		assert.Equal(t, "", failures[1].Path)
+		assert.Contains(t, failures[1].Message, "data has invalid JSON")
+	}
+	tester.AssertContains(t, nil)
+	soft.Report("")
+	assert.Empty(t, soft.Failures())
+}
+
+func TestSoftAssertions_Failures_ExpectPathsOfFailedNodes(t *testing.T) {
+	tester := &mock.Tester{}
+	soft := assertjson.NewSoftAssertions(tester)
+
+	assertjson.Has(soft, []byte(`{"items":[{"id":1},{"id":"2"}]}`), func(json *assertjson.AssertJSON) {
+		json.Node("items").ForEach(func(node *assertjson.AssertNode) {
+			node.Assert(func(json *assertjson.AssertJSON) {
+				json.Node("id").IsInteger(`see JSON node "items"`)
+			})
+		})
+		json.At("items", 0).Node("name").Exists()
+		assert.Fail(soft, "custom failure", `see JSON node "items"`)
+	})
+
+	failures := soft.Failures()
+	if assert.Len(t, failures, 3) {
+		assert.Equal(t, "items[1].id", failures[0].Path)
+		assert.Equal(t, "items[0].name", failures[1].Path)
+		assert.Equal(t, "", failures[2].Path)
+	}
+}
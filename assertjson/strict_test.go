@@ -0,0 +1,77 @@
+package assertjson_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertjson"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictParsing(t *testing.T) {
+	tests := []struct {
+		name         string
+		json         string
+		options      []assertjson.ParseOption
+		wantMessages []string
+	}{
+		{
+			name:    "valid JSON",
+			json:    `{"id": 1, "items": [{"id": 1}, {"id": 2}]}`,
+			options: []assertjson.ParseOption{assertjson.StrictParsing()},
+		},
+		{
+			name: "duplicate keys are accepted by default",
+			json: `{"id": 1, "id": 2}`,
+		},
+		{
+			name:    "duplicate keys",
+			json:    `{"id": 1, "items": [{"id": 1, "id": 2}], "id": 2}`,
+			options: []assertjson.ParseOption{assertjson.StrictParsing()},
+			wantMessages: []string{
+				`data has invalid JSON: duplicate key at "items[0].id"`,
+				`data has invalid JSON: duplicate key at "id"`,
+			},
+		},
+		{
+			name:    "invalid strings",
+			json:    "{\"name\": \"\xff\", \"title\": \"\\ud800\"}",
+			options: []assertjson.ParseOption{assertjson.StrictParsing(), assertjson.UseNumber()},
+			wantMessages: []string{
+				`data has invalid JSON: invalid UTF-8 in string at "name"`,
+				`data has invalid JSON: lone surrogate "\ud800" in string at "title"`,
+			},
+		},
+		{
+			name:    "trailing data",
+			json:    `{"id": 1} {"id": 2}`,
+			options: []assertjson.ParseOption{assertjson.StrictParsing(), assertjson.UseNumber()},
+			wantMessages: []string{
+				`data has invalid JSON: invalid character '{' after top-level value`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertjson.Has(tester, []byte(test.json), func(json *assertjson.AssertJSON) {
+				json.Node("id").IsInteger()
+			}, test.options...)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestFileHas_StrictParsing(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "data.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"id": 1, "id": 2}`), 0o600))
+	tester := &mock.Tester{}
+
+	assertjson.FileHas(tester, filename, func(json *assertjson.AssertJSON) {}, assertjson.StrictParsing())
+
+	tester.AssertContains(t, []string{`data has invalid JSON: duplicate key at "id"`})
+}
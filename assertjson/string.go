@@ -161,9 +161,19 @@ func (a *StringAssertion) EqualToOneOf(expectedValues ...string) *StringAssertio
 	}
 	a.t.Helper()
 
+	return a.equalToOneOf(expectedValues, nil)
+}
+
+func (a *StringAssertion) equalToOneOf(expectedValues []string, msgAndArgs []interface{}) *StringAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
 	if !isOneOf(a.value, expectedValues) {
 		a.fail(
 			fmt.Sprintf(`equal to one of values (%s), actual is "%s"`, formatStrings(expectedValues), a.value),
+			msgAndArgs...,
 		)
 	}
 
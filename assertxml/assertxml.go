@@ -1,4 +1,6 @@
-// Package assertxml provides methods for testing XML values. Selecting XML values provided by XML Path Syntax.
+// Package assertxml provides methods for testing XML values. Selecting XML values provided by XPath 1.0
+// expressions with namespace support (see AssertXML.WithNamespace). Documents can be validated
+// against XML Schema by MatchesXSD and HasSchema functions.
 //
 // Example usage
 //
@@ -23,33 +25,70 @@
 //
 //	        // string assertions
 //	        xml.Node("/root/stringNode").EqualToTheString("stringValue")
+//
+//	        // typed assertions
+//	        xml.Node("/root/integerNode").IsInteger().GreaterThan(0)
+//	        xml.Node("/root/createdAt").IsTime().AfterDate(2021, 1, 1)
+//	        xml.Node("/root/email").IsEmail()
+//
+//	        // node sets and scoped assertions
+//	        xml.Node("/root/item").Count().EqualTo(2)
+//	        xml.Node("/root/item[1]").Attribute("id").IsInteger().EqualTo(1)
+//	        xml.ForEach("/root/item", func(item *AssertXML) {
+//	            item.Node("name").Exists()
+//	        })
+//	        xml.At("/root/item[2]").Node("name").EqualToTheString("second")
+//
+//	        // canonical comparison of XML fragments
+//	        xml.Node("/root/item[1]").EqualXML(`<item id="1"><name>first</name></item>`)
+//
+//	        // namespaces
+//	        xml.WithNamespace("atom", "http://www.w3.org/2005/Atom")
+//	        xml.Node("/atom:feed/atom:title").EqualToTheString("Feed")
 //	    })
 //	 }
 package assertxml
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/muonsoft/api-testing/internal/xmlassert"
+	"github.com/muonsoft/api-testing/internal/xmldom"
+	"github.com/muonsoft/api-testing/internal/xpath"
 	"github.com/stretchr/testify/assert"
-	"gopkg.in/xmlpath.v2"
 )
 
 // AssertXML - main structure that holds parsed XML.
 type AssertXML struct {
-	t   TestingT
-	xml *xmlpath.Node
+	t TestingT
+	// node is a context node of XPath expressions
+	node *xmldom.Node
+	// namespaces maps prefixes used in XPath expressions to namespace URIs
+	namespaces map[string]string
+	// path is a location of the context node used in failure messages, it is empty for the document
+	path string
+	// err is set when the context node is not found, assertions on its nodes are skipped
+	err error
 }
 
 // AssertNode - structure for asserting XML node.
 type AssertNode struct {
-	t     TestingT
-	found bool
-	path  string
-	value string
+	t          TestingT
+	err        error
+	found      bool
+	path       string
+	value      string
+	nodes      xpath.NodeSet
+	namespaces map[string]string
 }
 
+// errNotFound marks nodes derived from a node that is not found, the failure is already reported.
+var errNotFound = errors.New("node not found")
+
 // XMLAssertFunc - callback function used for asserting XML nodes.
 type XMLAssertFunc func(xml *AssertXML)
 
@@ -58,7 +97,7 @@ func FileHas(t TestingT, filename string, xmlAssert XMLAssertFunc) {
 	t.Helper()
 	data, err := os.ReadFile(filename)
 	if err != nil {
-		assert.Failf(t, "failed to read file '%s': %s", filename, err.Error())
+		assert.Fail(t, fmt.Sprintf("failed to read file '%s': %s", filename, err.Error()))
 	} else {
 		Has(t, data, xmlAssert)
 	}
@@ -67,29 +106,92 @@ func FileHas(t TestingT, filename string, xmlAssert XMLAssertFunc) {
 // Has loads XML from byte slice and runs user callback for testing its nodes.
 func Has(t TestingT, data []byte, xmlAssert XMLAssertFunc) {
 	t.Helper()
-	xml, err := xmlpath.Parse(bytes.NewReader(data))
-	body := &AssertXML{
-		t:   t,
-		xml: xml,
-	}
+	root, err := xmldom.Parse(bytes.NewReader(data))
 	if err != nil {
-		assert.Failf(t, "data has invalid XML: %s", err.Error())
+		assert.Fail(t, fmt.Sprintf("data has invalid XML: %s", err.Error()))
 	} else {
-		xmlAssert(body)
+		xmlAssert(newAssertXML(t, root))
+	}
+}
+
+func init() {
+	// packages of this module that inspect the document before running the assertions
+	// (like soap) use it, so the data is not parsed twice
+	xmlassert.New = func(t xmlassert.TestingT, document *xmldom.Node) interface{} {
+		return newAssertXML(t, document)
+	}
+}
+
+func newAssertXML(t TestingT, document *xmldom.Node) *AssertXML {
+	return &AssertXML{t: t, node: document, namespaces: map[string]string{}}
+}
+
+// WithNamespace registers the namespace prefix to be used in XPath expressions. Names without
+// prefix match only nodes without a namespace (as defined by XPath 1.0), so a prefix should be
+// registered even for the default namespace of the document. Alternatively the namespace can be
+// registered with the empty prefix, then unprefixed names of elements match elements of this
+// namespace (unprefixed names of attributes still match attributes without a namespace).
+func (x *AssertXML) WithNamespace(prefix, uri string) *AssertXML {
+	// the map is shared with the scoped objects and nodes, so it is copied on write
+	// to keep the registered prefix local to this object
+	namespaces := make(map[string]string, len(x.namespaces)+1)
+	for p, u := range x.namespaces {
+		namespaces[p] = u
 	}
+	namespaces[prefix] = uri
+	x.namespaces = namespaces
+	return x
 }
 
-// Node searches for XML node by XML Path Syntax. Returns struct for asserting the node values.
+// Node searches for XML node by XPath expression. Returns struct for asserting the node values.
+// If the expression selects a node-set, the string value of the first node is asserted. Results of
+// other types (for example, "count(/root/item)") are converted into a string.
 func (x *AssertXML) Node(path string) *AssertNode {
 	x.t.Helper()
-	p := xmlpath.MustCompile(path)
-	value, found := p.String(x.xml)
-
-	return &AssertNode{
-		t:     x.t,
-		found: found,
-		path:  path,
-		value: value,
+	node := &AssertNode{t: x.t, path: x.pathTo(path), namespaces: x.namespaces}
+	if x.err != nil {
+		node.err = x.err
+		return node
+	}
+
+	value, err := x.evaluate(path)
+	if err != nil {
+		node.err = err
+		assert.Fail(x.t, fmt.Sprintf(`failed to evaluate XPath "%s": %s`, node.path, err.Error()))
+		return node
+	}
+	if nodes, ok := value.(xpath.NodeSet); ok {
+		node.nodes = nodes
+		node.found = len(nodes) > 0
+	} else {
+		node.found = true
+	}
+	node.value = xpath.String(value)
+
+	return node
+}
+
+// At is used to test assertions on some node in a batch. It returns AssertXML object scoped
+// to the first node selected by the expression, so relative XPath expressions are evaluated from it.
+func (x *AssertXML) At(path string) *AssertXML {
+	x.t.Helper()
+	node := x.Node(path)
+	if node.isNodeSet() && node.exists() {
+		return x.scopeTo(node.nodes[0])
+	}
+
+	return &AssertXML{t: x.t, namespaces: x.namespaces, path: node.path, err: errNotFound}
+}
+
+// ForEach runs the callback for each node selected by the expression.
+// The callback receives AssertXML object scoped to the node.
+func (x *AssertXML) ForEach(path string, xmlAssert XMLAssertFunc) {
+	x.t.Helper()
+	node := x.Node(path)
+	if node.isNodeSet() {
+		for _, n := range node.nodes {
+			xmlAssert(x.scopeTo(n))
+		}
 	}
 }
 
@@ -100,10 +202,48 @@ func (x *AssertXML) Nodef(format string, a ...interface{}) *AssertNode {
 	return x.Node(fmt.Sprintf(format, a...))
 }
 
-func (node *AssertNode) exists() bool {
+func (x *AssertXML) evaluate(path string) (xpath.Value, error) {
+	expression, err := xpath.Compile(path, x.namespaces)
+	if err != nil {
+		return nil, err
+	}
+	return expression.Evaluate(x.node)
+}
+
+// scopeTo returns AssertXML object with the node as a context node.
+func (x *AssertXML) scopeTo(node *xmldom.Node) *AssertXML {
+	scoped := &AssertXML{t: x.t, node: node, namespaces: x.namespaces}
+	if node.Type != xmldom.DocumentNode {
+		scoped.path = node.PathWith(x.namespaces)
+	}
+	return scoped
+}
+
+// pathTo returns the path of the expression for failure messages, relative expressions
+// of the scoped object are prefixed by the location of the context node.
+func (x *AssertXML) pathTo(path string) string {
+	if x.path == "" || strings.HasPrefix(path, "/") {
+		return path
+	}
+	return x.path + "/" + path
+}
+
+// Values returns string values of all nodes selected by the expression.
+func (node *AssertNode) Values() []string {
+	values := make([]string, len(node.nodes))
+	for i, n := range node.nodes {
+		values[i] = n.StringValue()
+	}
+	return values
+}
+
+func (node *AssertNode) exists(msgAndArgs ...interface{}) bool {
 	node.t.Helper()
+	if node.err != nil {
+		return false
+	}
 	if !node.found {
-		node.t.Errorf(`failed to find XML node "%s"`, node.path)
+		assert.Fail(node.t, fmt.Sprintf(`failed to find XML node "%s"`, node.path), msgAndArgs...)
 	}
 
 	return node.found
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestFileHas(t *testing.T) {
@@ -17,3 +19,104 @@ func TestFileHas(t *testing.T) {
 		xml.Nodef("/root/%s", "stringNode").EqualToTheString("stringValue")
 	})
 }
+
+const feedXML = `<?xml version="1.0"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:app="http://www.w3.org/2007/app">
+	<title>Feed</title>
+	<entry><title>First</title><app:edited>2021-01-01</app:edited></entry>
+	<entry><title>Second</title></entry>
+</feed>`
+
+func TestAssertXML_XPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		assert       func(xml *assertxml.AssertXML)
+		wantMessages []string
+	}{
+		{
+			name: "namespaces",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.WithNamespace("atom", "http://www.w3.org/2005/Atom").
+					WithNamespace("app", "http://www.w3.org/2007/app")
+				xml.Node("/atom:feed/atom:title").EqualToTheString("Feed")
+				xml.Node("//atom:entry[app:edited]/atom:title").EqualToTheString("First")
+				xml.Node("//atom:entry[last()]/atom:title").EqualToTheString("Second")
+				xml.Node("count(//atom:entry)").EqualToTheString("2")
+				xml.Node("/feed/title").DoesNotExist()
+				xml.Node("//*[local-name() = 'title'][. = 'Second']").Exists()
+			},
+		},
+		{
+			name: "failures",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.Node("/feed/title").Exists()
+				xml.Node("/atom:feed").Exists()
+				xml.Node("/feed[").EqualToTheString("Feed")
+			},
+			wantMessages: []string{
+				`failed asserting that xml node '/feed/title' exists`,
+				`failed to evaluate XPath "/atom:feed": namespace prefix "atom" is not registered`,
+				`failed to evaluate XPath "/feed[": expected node test, found end of expression`,
+			},
+		},
+		{
+			name: "default namespace",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.WithNamespace("", "http://www.w3.org/2005/Atom")
+				xml.Node("/feed/title").EqualToTheString("Feed")
+				xml.Node("count(//entry)").EqualToTheString("2")
+			},
+		},
+		{
+			name: "paths of default namespace elements",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.WithNamespace("app", "http://www.w3.org/2007/app")
+				xml.ForEach("//*[local-name() = 'entry']", func(entry *assertxml.AssertXML) {
+					entry.Node("app:edited").Exists()
+				})
+				xml.WithNamespace("a", "http://www.w3.org/2005/Atom")
+				xml.ForEach("//a:entry", func(entry *assertxml.AssertXML) {
+					entry.Node("app:edited").Exists()
+				})
+			},
+			wantMessages: []string{
+				`failed asserting that xml node '/*[local-name()='feed' and namespace-uri()='http://www.w3.org/2005/Atom']` +
+					`/*[local-name()='entry' and namespace-uri()='http://www.w3.org/2005/Atom'][2]/app:edited' exists`,
+				`failed asserting that xml node '/a:feed/a:entry[2]/app:edited' exists`,
+			},
+		},
+		{
+			name: "namespace registered in scope",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.WithNamespace("atom", "http://www.w3.org/2005/Atom")
+				xml.ForEach("//atom:entry", func(entry *assertxml.AssertXML) {
+					entry.WithNamespace("app", "http://www.w3.org/2007/app")
+				})
+				xml.At("/atom:feed").WithNamespace("a", "http://www.w3.org/2005/Atom")
+				xml.Node("//app:edited").Exists()
+				xml.Node("//a:title").Exists()
+			},
+			wantMessages: []string{
+				`failed to evaluate XPath "//app:edited": namespace prefix "app" is not registered`,
+				`failed to evaluate XPath "//a:title": namespace prefix "a" is not registered`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertxml.Has(tester, []byte(feedXML), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestAssertNode_Values(t *testing.T) {
+	assertxml.Has(t, []byte(feedXML), func(xml *assertxml.AssertXML) {
+		xml.WithNamespace("atom", "http://www.w3.org/2005/Atom")
+
+		assert.Equal(t, []string{"Feed", "First", "Second"}, xml.Node("//atom:title").Values())
+	})
+}
@@ -0,0 +1,102 @@
+package assertxml
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/xmldiff"
+	"github.com/muonsoft/api-testing/internal/xmldom"
+	"github.com/stretchr/testify/assert"
+)
+
+// EqualOption is used to set up comparison of XML by EqualXML.
+type EqualOption func(options *xmldiff.Options)
+
+// IgnoreComments excludes comments from comparison.
+func IgnoreComments() EqualOption {
+	return func(options *xmldiff.Options) {
+		options.IgnoreComments = true
+	}
+}
+
+// PreserveWhitespace makes whitespace significant: text is compared as is, including leading
+// and trailing whitespace, and whitespace-only text nodes are compared too. Use it to test
+// mixed content, like "<p>a <b/></p>".
+func PreserveWhitespace() EqualOption {
+	return func(options *xmldiff.Options) {
+		options.PreserveWhitespace = true
+	}
+}
+
+// EqualXML asserts that XML documents are equal after canonicalization. Comparison ignores
+// whitespace-only text nodes, leading and trailing whitespace of text (unless PreserveWhitespace
+// option is used), order of attributes and choice of namespace prefixes (names are compared
+// by namespace URIs).
+// On failure, it reports the list of added, removed and changed nodes with their XPath locations
+// in the actual document.
+func EqualXML(t TestingT, expected, actual []byte, options ...EqualOption) {
+	t.Helper()
+	expectedRoot, err := xmldom.Parse(bytes.NewReader(expected))
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("expected value has invalid XML: %s", err.Error()))
+		return
+	}
+	actualRoot, err := xmldom.Parse(bytes.NewReader(actual))
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("data has invalid XML: %s", err.Error()))
+		return
+	}
+
+	differences := xmldiff.Compare(expectedRoot, actualRoot, newEqualOptions(options))
+	if len(differences) > 0 {
+		assert.Fail(t, "failed asserting that XML documents are equal, differences:\n"+xmldiff.Format(differences))
+	}
+}
+
+// EqualXML asserts that the first selected node is equal to the XML fragment after canonicalization
+// (see EqualXML function for the rules). The fragment should have a single root element.
+func (node *AssertNode) EqualXML(fragment string, options ...EqualOption) {
+	node.t.Helper()
+	if !node.exists() || !node.isNodeSet() {
+		return
+	}
+	expected, err := xmldom.Parse(strings.NewReader(fragment))
+	if err != nil {
+		assert.Fail(node.t, fmt.Sprintf("expected value has invalid XML: %s", err.Error()))
+		return
+	}
+
+	actual := node.nodes[0]
+	if actual.Type != xmldom.DocumentNode {
+		expected = documentElement(expected)
+	}
+
+	differences := xmldiff.Compare(expected, actual, newEqualOptions(options))
+	if len(differences) > 0 {
+		assert.Fail(node.t, fmt.Sprintf(
+			"failed asserting that XML node \"%s\" is equal to XML fragment, differences:\n%s",
+			node.path,
+			xmldiff.Format(differences),
+		))
+	}
+}
+
+func newEqualOptions(options []EqualOption) xmldiff.Options {
+	opts := xmldiff.Options{}
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
+
+// documentElement returns the root element of the document, comments and processing
+// instructions around it are not compared with the node.
+func documentElement(document *xmldom.Node) *xmldom.Node {
+	for _, child := range document.Children {
+		if child.Type == xmldom.ElementNode {
+			return child
+		}
+	}
+	return document
+}
@@ -0,0 +1,141 @@
+package assertxml_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/muonsoft/api-testing/internal/mock"
+)
+
+func TestEqualXML(t *testing.T) {
+	tests := []struct {
+		name         string
+		expected     string
+		actual       string
+		options      []assertxml.EqualOption
+		wantMessages []string
+	}{
+		{
+			name:     "equal documents",
+			expected: `<s:feed xmlns:s="urn:feed" version="1"><s:title>Feed</s:title></s:feed>`,
+			actual: `<?xml version="1.0"?>
+<feed version="1" xmlns="urn:feed">
+	<title> Feed </title>
+</feed>`,
+		},
+		{
+			name:     "different documents",
+			expected: `<feed version="1"><title>Feed</title><entry/></feed>`,
+			actual:   `<feed version="2"><title>News</title></feed>`,
+			wantMessages: []string{
+				`failed asserting that XML documents are equal, differences:`,
+			},
+		},
+		{
+			name:     "comments are compared by default",
+			expected: `<feed><!--generated--></feed>`,
+			actual:   `<feed/>`,
+			wantMessages: []string{
+				`removed "/feed/comment()": <!--generated-->`,
+			},
+		},
+		{
+			name:     "ignored comments",
+			expected: `<feed><!--generated--></feed>`,
+			actual:   `<feed/>`,
+			options:  []assertxml.EqualOption{assertxml.IgnoreComments()},
+		},
+		{
+			name:     "mixed content with trimmed whitespace",
+			expected: `<p>a <b/></p>`,
+			actual:   `<p>a<b/></p>`,
+		},
+		{
+			name:     "mixed content with preserved whitespace",
+			expected: `<p>a <b/></p>`,
+			actual:   `<p>a<b/></p>`,
+			options:  []assertxml.EqualOption{assertxml.PreserveWhitespace()},
+			wantMessages: []string{
+				`changed "/p/text()": "a " -> "a"`,
+			},
+		},
+		{
+			name:     "invalid expected XML",
+			expected: `<feed>`,
+			actual:   `<feed/>`,
+			wantMessages: []string{
+				`expected value has invalid XML: unexpected EOF: element <feed> is not closed`,
+			},
+		},
+		{
+			name:     "invalid actual XML",
+			expected: `<feed/>`,
+			actual:   `<feed></entry>`,
+			wantMessages: []string{
+				`data has invalid XML: line 1: unexpected end element </entry>`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertxml.EqualXML(tester, []byte(test.expected), []byte(test.actual), test.options...)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestAssertNode_EqualXML(t *testing.T) {
+	tests := []struct {
+		name         string
+		assert       func(xml *assertxml.AssertXML)
+		wantMessages []string
+	}{
+		{
+			name: "equal fragments",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.Node("/catalog/book[2]").EqualXML(
+					`<!-- second book --><book xmlns:l="http://www.w3.org/1999/xlink" l:href="/books/2" id="2">
+						<title>XML</title>
+						<price>20</price>
+					</book>`,
+				)
+				xml.Node("/").EqualXML(catalogXML)
+			},
+		},
+		{
+			name: "different fragment",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.Node("/catalog/book[3]").EqualXML(`<book id="3"><title>XSLT</title></book>`)
+			},
+			wantMessages: []string{
+				`failed asserting that XML node "/catalog/book[3]" is equal to XML fragment, differences:`,
+			},
+		},
+		{
+			name: "node not found",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.Node("/catalog/magazine").EqualXML(`<magazine/>`)
+			},
+			wantMessages: []string{`failed to find XML node "/catalog/magazine"`},
+		},
+		{
+			name: "invalid fragment",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.Node("/catalog/book[1]").EqualXML(`<book><title></book>`)
+			},
+			wantMessages: []string{`expected value has invalid XML: line 1: unexpected end element </book>`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertxml.Has(tester, []byte(catalogXML), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package assertxml
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/muonsoft/api-testing/assertions"
+	"github.com/muonsoft/api-testing/internal/xpath"
+	"github.com/stretchr/testify/assert"
+)
+
+// Count returns IntegerAssertion to execute a chain of assertions for the number of nodes
+// selected by the expression. For example, Count().EqualTo(3) asserts that exactly 3 nodes are selected.
+func (node *AssertNode) Count() *assertions.IntegerAssertion {
+	node.t.Helper()
+	if !node.isNodeSet() {
+		return nil
+	}
+
+	return assertions.NewIntegerAssertion(
+		node.t,
+		fmt.Sprintf(`failed asserting that count of XML nodes "%s": `, node.path),
+		big.NewInt(int64(len(node.nodes))),
+	)
+}
+
+// ForEach runs the callback for each node selected by the expression.
+func (node *AssertNode) ForEach(assertNode func(node *AssertNode)) {
+	node.t.Helper()
+	if !node.isNodeSet() {
+		return
+	}
+	for _, n := range node.nodes {
+		assertNode(&AssertNode{
+			t:          node.t,
+			found:      true,
+			path:       n.PathWith(node.namespaces),
+			value:      n.StringValue(),
+			nodes:      xpath.NodeSet{n},
+			namespaces: node.namespaces,
+		})
+	}
+}
+
+// Attribute returns struct for asserting the attribute of the first selected element.
+// Prefixed names (for example, "xlink:href") use namespaces registered by AssertXML.WithNamespace.
+func (node *AssertNode) Attribute(name string) *AssertNode {
+	node.t.Helper()
+	attribute := &AssertNode{t: node.t, path: node.path + "/@" + name, namespaces: node.namespaces}
+	if !node.exists() || !node.isNodeSet() {
+		attribute.err = errNotFound
+		return attribute
+	}
+
+	expression, err := xpath.Compile("@"+name, node.namespaces)
+	if err == nil {
+		var value xpath.Value
+		value, err = expression.Evaluate(node.nodes[0])
+		attribute.nodes, _ = value.(xpath.NodeSet)
+	}
+	if err != nil {
+		attribute.err = err
+		assert.Fail(node.t, fmt.Sprintf(`failed to evaluate XPath "%s": %s`, attribute.path, err.Error()))
+		return attribute
+	}
+	attribute.found = len(attribute.nodes) > 0
+	attribute.value = xpath.String(attribute.nodes)
+
+	return attribute
+}
+
+// isNodeSet reports whether the expression selects a node-set, the failure is reported otherwise.
+func (node *AssertNode) isNodeSet() bool {
+	node.t.Helper()
+	if node.err != nil {
+		return false
+	}
+	if node.nodes == nil && node.found {
+		assert.Fail(node.t, fmt.Sprintf(`failed asserting that XPath "%s" selects nodes, actual is "%s"`, node.path, node.value))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,126 @@
+package assertxml_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const catalogXML = `<?xml version="1.0"?>
+<catalog xmlns:xlink="http://www.w3.org/1999/xlink">
+	<book id="1" xlink:href="/books/1"><title>Go</title><price>10</price></book>
+	<book id="2" xlink:href="/books/2"><title>XML</title><price>20</price></book>
+	<book id="3"><title>XPath</title><price>30</price></book>
+</catalog>`
+
+func TestAssertXML_NodeSets(t *testing.T) {
+	tests := []struct {
+		name         string
+		assert       func(xml *assertxml.AssertXML)
+		wantMessages []string
+	}{
+		{
+			name: "scoped assertions passed",
+			assert: func(xml *assertxml.AssertXML) {
+				book := xml.At("/catalog/book[@id = '2']")
+				book.Node("title").EqualToTheString("XML")
+				book.Node("price").IsInteger().EqualTo(20)
+				book.Node("/catalog/book[1]/title").EqualToTheString("Go")
+				book.At("title").Node(".").EqualToTheString("XML")
+			},
+		},
+		{
+			name: "scoped assertions failed",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.At("/catalog/book[2]").Node("title").EqualToTheString("Go")
+				xml.At("/catalog/book[2]").Node("author").Exists()
+				xml.At("/catalog/magazine").Node("title").Exists()
+				xml.At("count(/catalog/book)").Node("title").Exists()
+			},
+			wantMessages: []string{
+				`Not equal`,
+				`failed asserting that xml node '/catalog/book[2]/author' exists`,
+				`failed to find XML node "/catalog/magazine"`,
+				`failed asserting that XPath "count(/catalog/book)" selects nodes, actual is "3"`,
+			},
+		},
+		{
+			name: "for each passed",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.ForEach("/catalog/book", func(book *assertxml.AssertXML) {
+					book.Node("title").IsString().IsNotEmpty()
+					book.Node("price").IsInteger().GreaterThan(0)
+				})
+				xml.Node("/catalog/book/@id").ForEach(func(node *assertxml.AssertNode) {
+					node.IsInteger().LessThanOrEqual(3)
+				})
+			},
+		},
+		{
+			name: "for each failed",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.ForEach("/catalog/book", func(book *assertxml.AssertXML) {
+					book.Node("price").IsInteger().LessThan(20)
+				})
+				xml.Node("/catalog/book/title").ForEach(func(node *assertxml.AssertNode) {
+					node.IsString().WithLength(2)
+				})
+			},
+			wantMessages: []string{
+				`failed asserting that XML node "/catalog/book[2]/price": less than 20, actual is 20`,
+				`failed asserting that XML node "/catalog/book[3]/price": less than 20, actual is 30`,
+				`failed asserting that XML node "/catalog/book[2]/title": is string with length is 2, actual is 3`,
+				`failed asserting that XML node "/catalog/book[3]/title": is string with length is 2, actual is 5`,
+			},
+		},
+		{
+			name: "count",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.Node("/catalog/book").Count().EqualTo(3)
+				xml.Node("/catalog/magazine").Count().IsZero()
+				xml.Node("/catalog/book[@xlink:href]").Count().EqualTo(2)
+				xml.Node("/catalog/book").Count().GreaterThan(3)
+				xml.Node("string(/catalog/book)").Count().EqualTo(1)
+			},
+			wantMessages: []string{
+				`failed to evaluate XPath "/catalog/book[@xlink:href]": namespace prefix "xlink" is not registered`,
+				`failed asserting that count of XML nodes "/catalog/book": greater than 3, actual is 3`,
+				`failed asserting that XPath "string(/catalog/book)" selects nodes, actual is "Go10"`,
+			},
+		},
+		{
+			name: "attributes",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.WithNamespace("xl", "http://www.w3.org/1999/xlink")
+				xml.Node("/catalog/book[2]").Attribute("id").IsInteger().EqualTo(2)
+				xml.Node("/catalog/book[2]").Attribute("xl:href").IsString().EqualTo("/books/2")
+				xml.Node("/catalog/book[3]").Attribute("xl:href").DoesNotExist()
+				xml.Node("/catalog/book[3]").Attribute("isbn").Exists()
+				xml.Node("/catalog/book[3]").Attribute("p:isbn").Exists()
+				xml.Node("/catalog/magazine").Attribute("id").Exists()
+			},
+			wantMessages: []string{
+				`failed asserting that xml node '/catalog/book[3]/@isbn' exists`,
+				`failed to evaluate XPath "/catalog/book[3]/@p:isbn": namespace prefix "p" is not registered`,
+				`failed to find XML node "/catalog/magazine"`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertxml.Has(tester, []byte(catalogXML), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestAssertXML_At_Values(t *testing.T) {
+	assertxml.Has(t, []byte(catalogXML), func(xml *assertxml.AssertXML) {
+		assert.Equal(t, []string{"10", "20", "30"}, xml.At("/catalog").Node("book/price").Values())
+	})
+}
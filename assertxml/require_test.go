@@ -0,0 +1,45 @@
+package assertxml_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireHas(t *testing.T) {
+	tester := &mock.Tester{}
+	executed := false
+
+	tester.Run(func() {
+		assertxml.RequireHas(tester, []byte(`<user><id>1</id></user>`), func(xml *assertxml.AssertXML) {
+			xml.Node("/user/name").EqualToTheString("John")
+			executed = true
+		})
+	})
+
+	assert.True(t, tester.Stopped())
+	assert.False(t, executed)
+	tester.AssertContains(t, []string{`failed to find XML node "/user/name"`})
+}
+
+func TestAssertXML_RequireNode(t *testing.T) {
+	tester := &mock.Tester{}
+	executed := false
+
+	tester.Run(func() {
+		assertxml.Has(tester, []byte(`<user><id>a</id></user>`), func(xml *assertxml.AssertXML) {
+			xml.Node("/user/id").EqualToTheString("b")
+			xml.RequireNode("/user/id").IsInteger()
+			executed = true
+		})
+	})
+
+	assert.True(t, tester.Stopped())
+	assert.False(t, executed)
+	tester.AssertContains(t, []string{
+		`expected: "b"`,
+		`/user/id`,
+	})
+}
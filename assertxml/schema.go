@@ -0,0 +1,89 @@
+package assertxml
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+	"github.com/muonsoft/api-testing/internal/xsd"
+	"github.com/stretchr/testify/assert"
+)
+
+// Schema is an XML Schema (XSD 1.0) used by HasSchema and MatchesSchema. Validation is implemented
+// in pure Go and supports a practical subset of the specification: global and local elements and
+// attributes, sequences, choices, all groups and wildcards with occurrence constraints, named groups,
+// simple types with facets, lists and unions, complex type extension and restriction, substitution
+// groups and namespaces. Identity constraints (xs:key, xs:unique) and xs:redefine are not supported.
+type Schema struct {
+	schema *xsd.Schema
+}
+
+// LoadSchema reads XML Schema from the file. Included and imported schema documents
+// are loaded relative to the directory of the file.
+func LoadSchema(filename string) (*Schema, error) {
+	schema, err := xsd.Load(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: schema}, nil
+}
+
+// ParseSchema parses XML Schema from the byte slice. Included and imported schema documents
+// are loaded relative to the working directory.
+func ParseSchema(data []byte) (*Schema, error) {
+	schema, err := xsd.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: schema}, nil
+}
+
+// MatchesXSD asserts that XML data is valid against the XML Schema loaded from the file.
+// Each violation is reported as a separate failure with XPath location of the invalid node.
+func MatchesXSD(t TestingT, data []byte, schemaFile string) {
+	t.Helper()
+	schema, err := LoadSchema(schemaFile)
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf(`failed to load XSD schema "%s": %s`, schemaFile, err.Error()))
+		return
+	}
+	HasSchema(t, data, schema)
+}
+
+// HasSchema asserts that XML data is valid against the XML Schema.
+// Each violation is reported as a separate failure with XPath location of the invalid node.
+func HasSchema(t TestingT, data []byte, schema *Schema) {
+	t.Helper()
+	root, err := xmldom.Parse(bytes.NewReader(data))
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("data has invalid XML: %s", err.Error()))
+		return
+	}
+	matchSchema(t, root, schema, nil)
+}
+
+// MatchesSchema asserts that the XML element selected by the expression is valid against the XML Schema.
+// The element must be declared globally in the schema.
+func (node *AssertNode) MatchesSchema(schema *Schema) {
+	node.t.Helper()
+	if !node.isNodeSet() || !node.exists() {
+		return
+	}
+	element := node.nodes[0]
+	if element.Type != xmldom.ElementNode && element.Type != xmldom.DocumentNode {
+		assert.Fail(node.t, fmt.Sprintf(`failed asserting that XML node "%s" matches XSD: node is not an element`, node.path))
+		return
+	}
+	matchSchema(node.t, element, schema, node.namespaces)
+}
+
+// matchSchema reports violations with XPath locations that use the registered namespace prefixes.
+func matchSchema(t TestingT, node *xmldom.Node, schema *Schema, namespaces map[string]string) {
+	t.Helper()
+	for _, violation := range schema.schema.Validate(node) {
+		assert.Fail(t, fmt.Sprintf(
+			`failed asserting that XML node "%s" matches XSD: %s`,
+			violation.Node.PathWith(namespaces), violation.Message,
+		))
+	}
+}
@@ -0,0 +1,103 @@
+package assertxml_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesXSD(t *testing.T) {
+	tests := []struct {
+		name         string
+		schema       string
+		xml          string
+		wantMessages []string
+	}{
+		{
+			name:   "valid catalog",
+			schema: "testdata/schema/catalog.xsd",
+			xml: `<catalog xmlns="urn:example:catalog" updated="2021-05-01">
+				<book id="1">
+					<title>Go</title>
+					<author>Alan</author>
+					<author>Brian</author>
+					<isbn>978-0134190440</isbn>
+					<price currency="USD">34.99</price>
+				</book>
+			</catalog>`,
+		},
+		{
+			name:   "invalid catalog",
+			schema: "testdata/schema/catalog.xsd",
+			xml: `<c:catalog xmlns:c="urn:example:catalog" updated="yesterday">
+				<c:book id="0">
+					<c:title>Go</c:title>
+					<c:isbn>978</c:isbn>
+					<c:price currency="RUB">free</c:price>
+				</c:book>
+				<c:book id="2">
+					<c:title>XML</c:title>
+					<c:author>Tim</c:author>
+				</c:book>
+			</c:catalog>`,
+			wantMessages: []string{
+				`failed asserting that XML node "/c:catalog/@updated" matches XSD: must be of type xs:date, actual is "yesterday"`,
+				`failed asserting that XML node "/c:catalog/c:book[1]/@id" matches XSD: must be of type xs:positiveInteger, actual is "0"`,
+				`failed asserting that XML node "/c:catalog/c:book[1]/c:isbn" matches XSD: unexpected element "c:isbn", expected "c:author"`,
+				`failed asserting that XML node "/c:catalog/c:book[1]/c:isbn" matches XSD: must match pattern "\d{3}-\d{10}", actual is "978"`,
+				`failed asserting that XML node "/c:catalog/c:book[1]/c:price/@currency" matches XSD: must be one of "EUR", "USD", actual is "RUB"`,
+				`failed asserting that XML node "/c:catalog/c:book[1]/c:price" matches XSD: must be of type xs:decimal, actual is "free"`,
+				`failed asserting that XML node "/c:catalog/c:book[2]" matches XSD: missing required element, expected one of "c:author", "c:isbn", "c:issn", "c:price"`,
+			},
+		},
+		{
+			name:   "invalid XML",
+			schema: "testdata/schema/catalog.xsd",
+			xml:    `<catalog`,
+			wantMessages: []string{
+				`data has invalid XML`,
+			},
+		},
+		{
+			name:   "missing schema",
+			schema: "testdata/schema/missing.xsd",
+			xml:    `<catalog/>`,
+			wantMessages: []string{
+				`failed to load XSD schema "testdata/schema/missing.xsd"`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertxml.MatchesXSD(tester, []byte(test.xml), test.schema)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestAssertNode_MatchesSchema(t *testing.T) {
+	schema, err := assertxml.LoadSchema("testdata/schema/catalog.xsd")
+	require.NoError(t, err)
+	tester := &mock.Tester{}
+
+	assertxml.Has(tester, []byte(`<response xmlns:m="urn:example:money">
+		<m:price xmlns="urn:example:catalog" currency="EUR">10</m:price>
+		<price xmlns="urn:example:catalog" currency="EUR">-</price>
+	</response>`), func(xml *assertxml.AssertXML) {
+		xml.WithNamespace("c", "urn:example:catalog")
+		xml.Node("/response/c:price").MatchesSchema(schema)
+		xml.Node("/response/*[1]").MatchesSchema(schema)
+		xml.Node("count(/response/*)").MatchesSchema(schema)
+	})
+
+	tester.AssertContains(t, []string{
+		`failed asserting that XML node "/response/c:price" matches XSD: must be of type xs:decimal, actual is "-"`,
+		`failed asserting that XML node "/response/m:price" matches XSD: element "m:price" is not declared in the schema`,
+		`failed asserting that XPath "count(/response/*)" selects nodes, actual is "2"`,
+	})
+}
@@ -1,6 +1,72 @@
 package assertxml
 
-import "github.com/stretchr/testify/assert"
+import (
+	"fmt"
+
+	"github.com/muonsoft/api-testing/assertions"
+	"github.com/stretchr/testify/assert"
+)
+
+// IsString asserts that the XML node exists and returns StringAssertion
+// to execute a chain of assertions for its string value.
+func (node *AssertNode) IsString(msgAndArgs ...interface{}) *assertions.StringAssertion {
+	node.t.Helper()
+	if node.exists(msgAndArgs...) {
+		return assertions.NewStringAssertion(node.t, node.messagePrefix(), node.value)
+	}
+
+	return nil
+}
+
+// IsInteger asserts that the XML node has an integer value.
+func (node *AssertNode) IsInteger(msgAndArgs ...interface{}) *assertions.IntegerAssertion {
+	node.t.Helper()
+	return node.IsString(msgAndArgs...).WithInteger(msgAndArgs...)
+}
+
+// IsNumber asserts that the XML node has a numeric value.
+func (node *AssertNode) IsNumber(msgAndArgs ...interface{}) *assertions.NumberAssertion {
+	node.t.Helper()
+	return node.IsString(msgAndArgs...).WithNumber(msgAndArgs...)
+}
+
+// IsTime asserts that the XML node has a time value in RFC3339 format.
+func (node *AssertNode) IsTime(msgAndArgs ...interface{}) *assertions.TimeAssertion {
+	node.t.Helper()
+	return node.IsString(msgAndArgs...).WithTime(msgAndArgs...)
+}
+
+// IsTimeWithLayout asserts that the XML node has a time value with the given layout.
+func (node *AssertNode) IsTimeWithLayout(layout string, msgAndArgs ...interface{}) *assertions.TimeAssertion {
+	node.t.Helper()
+	return node.IsString(msgAndArgs...).WithTimeWithLayout(layout, msgAndArgs...)
+}
+
+// IsDate asserts that the XML node has a date value in "YYYY-MM-DD" format.
+func (node *AssertNode) IsDate(msgAndArgs ...interface{}) *assertions.TimeAssertion {
+	node.t.Helper()
+	return node.IsString(msgAndArgs...).WithDate(msgAndArgs...)
+}
+
+// IsUUID asserts that the XML node has a value with UUID.
+// It returns UUIDAssertion to execute a chain of assertions for the parsed value.
+func (node *AssertNode) IsUUID(msgAndArgs ...interface{}) *assertions.UUIDAssertion {
+	node.t.Helper()
+	return node.IsString(msgAndArgs...).WithUUID(msgAndArgs...)
+}
+
+// IsURL asserts that the XML node has a value with URL.
+// It returns URLAssertion to execute a chain of assertions for the parsed value.
+func (node *AssertNode) IsURL(msgAndArgs ...interface{}) *assertions.URLAssertion {
+	node.t.Helper()
+	return node.IsString(msgAndArgs...).WithURL(msgAndArgs...)
+}
+
+// IsEmail asserts that the XML node has a value with email.
+func (node *AssertNode) IsEmail(msgAndArgs ...interface{}) *assertions.StringAssertion {
+	node.t.Helper()
+	return node.IsString(msgAndArgs...).WithEmail(msgAndArgs...)
+}
 
 // EqualToTheString asserts that the XML node has a string value equals to the given value.
 func (node *AssertNode) EqualToTheString(expectedValue string, msgAndArgs ...interface{}) {
@@ -9,3 +75,7 @@ func (node *AssertNode) EqualToTheString(expectedValue string, msgAndArgs ...int
 		assert.Equal(node.t, expectedValue, node.value, msgAndArgs...)
 	}
 }
+
+func (node *AssertNode) messagePrefix() string {
+	return fmt.Sprintf(`failed asserting that XML node "%s": `, node.path)
+}
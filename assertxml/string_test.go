@@ -0,0 +1,112 @@
+package assertxml_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/muonsoft/api-testing/internal/mock"
+)
+
+const typedXML = `<?xml version="1.0"?>
+<user id="c8fd9b5e-1c5a-4b2f-9b0a-3c1d2e4f5a6b">
+	<name>John</name>
+	<age>42</age>
+	<rating>4.5</rating>
+	<createdAt>2021-05-20T10:30:00Z</createdAt>
+	<birthday>1990-01-15</birthday>
+	<homepage>https://example.com/john</homepage>
+	<email>john@example.com</email>
+	<balance>123456789012345678901234567890</balance>
+</user>`
+
+func TestAssertNode_TypedAssertions(t *testing.T) {
+	tests := []struct {
+		name         string
+		assert       func(xml *assertxml.AssertXML)
+		wantMessages []string
+	}{
+		{
+			name: "passed",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.Node("/user/name").IsString().EqualTo("John").WithLength(4)
+				xml.Node("/user/age").IsInteger().EqualTo(42).GreaterThan(18).LessThanOrEqual(42)
+				xml.Node("/user/rating").IsNumber().EqualToWithDelta(4.4, 0.2).LessThan(5)
+				xml.Node("/user/createdAt").IsTime().EqualTo(time.Date(2021, 5, 20, 10, 30, 0, 0, time.UTC))
+				xml.Node("/user/birthday").IsDate().EqualToDate(1990, 1, 15)
+				xml.Node("/user/@id").IsUUID().OfVersion(4).IsNotNil()
+				xml.Node("/user/homepage").IsURL().WithSchemas("https").WithHosts("example.com")
+				xml.Node("/user/balance").IsInteger().EqualToDecimal("123456789012345678901234567890").GreaterThan(0)
+				xml.Node("/user/rating").IsNumber().EqualToDecimal("4.5")
+				xml.Node("/user/email").IsEmail()
+			},
+		},
+		{
+			name: "type mismatch",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.Node("/user/name").IsInteger()
+				xml.Node("/user/name").IsNumber()
+				xml.Node("/user/name").IsTime()
+				xml.Node("/user/name").IsUUID()
+				xml.Node("/user/name").IsURL()
+				xml.Node("/user/name").IsEmail()
+			},
+			wantMessages: []string{
+				`failed asserting that XML node "/user/name": is integer, actual is "John"`,
+				`failed asserting that XML node "/user/name": is number, actual is "John"`,
+				`failed asserting that XML node "/user/name": is time: parsing time "John"`,
+				`failed asserting that XML node "/user/name": is UUID, actual is "John"`,
+				`failed asserting that XML node "/user/name": is URL, actual is "John"`,
+				`failed asserting that XML node "/user/name": is email, actual is "John"`,
+			},
+		},
+		{
+			name: "chained failures",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.Node("/user/name").IsString().EqualTo("Jane")
+				xml.Node("/user/age").IsInteger().LessThan(18)
+				xml.Node("/user/rating").IsNumber().GreaterThan(5)
+				xml.Node("/user/birthday").IsDate().AfterDate(2000, 1, 1)
+			},
+			wantMessages: []string{
+				`failed asserting that XML node "/user/name": equal to "Jane", actual is "John"`,
+				`failed asserting that XML node "/user/age": less than 18, actual is 42`,
+				`failed asserting that XML node "/user/rating": greater than 5.000000, actual is 4.500000`,
+				`failed asserting that XML node "/user/birthday": after "2000-01-01", actual is "1990-01-15"`,
+			},
+		},
+		{
+			name: "node not found",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.Node("/user/phone").IsInteger().EqualTo(1)
+				xml.Node("/user/phone").IsString("phone is required")
+			},
+			wantMessages: []string{
+				`failed to find XML node "/user/phone"`,
+				`phone is required`,
+			},
+		},
+		{
+			name: "value assertions failed",
+			assert: func(xml *assertxml.AssertXML) {
+				xml.Node("/user/@id").IsUUID().OfVersion(1)
+				xml.Node("/user/homepage").IsURL().WithHosts("example.org")
+				xml.Node("/user/balance").IsInteger().LessThan(0)
+			},
+			wantMessages: []string{
+				`failed asserting that XML node "/user/@id": is UUID of version 1, actual is 4`,
+				`failed asserting that XML node "/user/homepage": is URL with hosts "example.org", actual is "example.com"`,
+				`failed asserting that XML node "/user/balance": less than 0, actual is 123456789012345678901234567890`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			assertxml.Has(tester, []byte(typedXML), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
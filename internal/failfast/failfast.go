@@ -0,0 +1,84 @@
+// Package failfast provides a wrapper around TestingT that stops the test on the first failure.
+package failfast
+
+import "fmt"
+
+// TestingT is a common interface of testing packages that is implemented by *testing.T.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Log(args ...interface{})
+	FailNow()
+}
+
+// T reports failures to the parent test and stops it by calling FailNow.
+type T struct {
+	t TestingT
+	// postponed is a depth of Postpone calls, the test is not stopped while it is positive
+	postponed int
+	failed    bool
+}
+
+// New wraps the test. If the test is already wrapped, it is returned as is.
+func New(t TestingT) *T {
+	if ft, ok := t.(*T); ok {
+		return ft
+	}
+	return &T{t: t}
+}
+
+// Helper marks the calling function as a test helper function.
+func (t *T) Helper() {
+	t.t.Helper()
+}
+
+// Error reports the failure and stops the test.
+func (t *T) Error(args ...interface{}) {
+	t.t.Helper()
+	t.t.Errorf("%s", fmt.Sprint(args...))
+	t.stop()
+}
+
+// Errorf reports the failure and stops the test.
+func (t *T) Errorf(format string, args ...interface{}) {
+	t.t.Helper()
+	t.t.Errorf(format, args...)
+	t.stop()
+}
+
+// Log passes arguments to the parent test.
+func (t *T) Log(args ...interface{}) {
+	t.t.Helper()
+	t.t.Log(args...)
+}
+
+// FailNow stops the test.
+func (t *T) FailNow() {
+	t.t.FailNow()
+}
+
+// Postpone runs f and, if t is T and f reported a failure, stops the test after f returns.
+// It is used to finish logging of the failure details before the test is stopped.
+func Postpone(t interface{}, f func()) {
+	ft, ok := t.(*T)
+	if !ok {
+		f()
+		return
+	}
+
+	ft.postponed++
+	f()
+	ft.postponed--
+	if ft.postponed == 0 && ft.failed {
+		ft.failed = false
+		ft.t.FailNow()
+	}
+}
+
+func (t *T) stop() {
+	if t.postponed > 0 {
+		t.failed = true
+		return
+	}
+	t.t.FailNow()
+}
@@ -0,0 +1,335 @@
+// Package fluent implements fluent assertions for numbers, UUIDs and URLs shared by assertions,
+// assertjson and assertxml packages.
+package fluent
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestingT is an interface wrapper around *testing.T.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// IntegerAssertion is used to build a chain of assertions for the integer value.
+type IntegerAssertion struct {
+	t             TestingT
+	messagePrefix string
+	// value is set to 0 when the exact value does not fit into int
+	value int
+	exact *big.Int
+}
+
+// NewIntegerAssertion creates IntegerAssertion for the exact integer value. Values out of range
+// of int are compared without loss of precision, but Value returns 0 for them.
+func NewIntegerAssertion(t TestingT, messagePrefix string, value *big.Int) *IntegerAssertion {
+	a := &IntegerAssertion{t: t, messagePrefix: messagePrefix, exact: new(big.Int).Set(value)}
+	if value.IsInt64() && int64(int(value.Int64())) == value.Int64() {
+		a.value = int(value.Int64())
+	}
+	return a
+}
+
+// IsZero asserts that the integer value equals to 0.
+func (a *IntegerAssertion) IsZero(msgAndArgs ...interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(a.exact.Sign() == 0, fmt.Sprintf(`is zero, actual is %s`, a.exact), msgAndArgs)
+}
+
+// IsNotZero asserts that the integer value not equals to 0.
+func (a *IntegerAssertion) IsNotZero(msgAndArgs ...interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(a.exact.Sign() != 0, `is not zero`, msgAndArgs)
+}
+
+// EqualTo asserts that the integer value equals to the given value.
+func (a *IntegerAssertion) EqualTo(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(
+		a.compare(expected) == 0,
+		fmt.Sprintf(`equal to %d, actual is %s`, expected, a.exact),
+		msgAndArgs,
+	)
+}
+
+// NotEqualTo asserts that the integer value not equals to the given value.
+func (a *IntegerAssertion) NotEqualTo(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(
+		a.compare(expected) != 0,
+		fmt.Sprintf(`not equal to %d, actual is %s`, expected, a.exact),
+		msgAndArgs,
+	)
+}
+
+// GreaterThan asserts that the integer value is greater than the given value.
+func (a *IntegerAssertion) GreaterThan(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(
+		a.compare(expected) > 0,
+		fmt.Sprintf(`greater than %d, actual is %s`, expected, a.exact),
+		msgAndArgs,
+	)
+}
+
+// GreaterThanOrEqual asserts that the integer value is greater than or equal to the given value.
+func (a *IntegerAssertion) GreaterThanOrEqual(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(
+		a.compare(expected) >= 0,
+		fmt.Sprintf(`greater than or equal %d, actual is %s`, expected, a.exact),
+		msgAndArgs,
+	)
+}
+
+// LessThan asserts that the integer value is less than the given value.
+func (a *IntegerAssertion) LessThan(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(
+		a.compare(expected) < 0,
+		fmt.Sprintf(`less than %d, actual is %s`, expected, a.exact),
+		msgAndArgs,
+	)
+}
+
+// LessThanOrEqual asserts that the integer value is less than or equal to the given value.
+func (a *IntegerAssertion) LessThanOrEqual(expected int, msgAndArgs ...interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(
+		a.compare(expected) <= 0,
+		fmt.Sprintf(`less than or equal %d, actual is %s`, expected, a.exact),
+		msgAndArgs,
+	)
+}
+
+// EqualToInt64 asserts that the integer value equals to the given 64-bit integer.
+func (a *IntegerAssertion) EqualToInt64(expected int64, msgAndArgs ...interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(
+		a.exact.Cmp(big.NewInt(expected)) == 0,
+		fmt.Sprintf(`equal to %d, actual is %s`, expected, a.exact),
+		msgAndArgs,
+	)
+}
+
+// EqualToDecimal asserts that the integer value exactly equals to the decimal literal
+// (like "12345678901234567890"). It is used to check integers out of range of int64.
+func (a *IntegerAssertion) EqualToDecimal(expected string, msgAndArgs ...interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	message, ok := equalDecimal(new(big.Rat).SetInt(a.exact), expected)
+	return a.check(ok, message, msgAndArgs)
+}
+
+// Value returns the integer value. It returns 0 if the value is out of range of int.
+func (a *IntegerAssertion) Value() int {
+	if a == nil {
+		return 0
+	}
+	return a.value
+}
+
+// compare compares the exact value with the given one, so values out of range of int
+// are compared correctly.
+func (a *IntegerAssertion) compare(expected int) int {
+	return a.exact.Cmp(big.NewInt(int64(expected)))
+}
+
+func (a *IntegerAssertion) check(passed bool, message string, msgAndArgs []interface{}) *IntegerAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	if !passed {
+		a.fail(message, msgAndArgs)
+	}
+
+	return a
+}
+
+func (a *IntegerAssertion) fail(message string, msgAndArgs []interface{}) {
+	a.t.Helper()
+	assert.Fail(a.t, a.messagePrefix+message, msgAndArgs...)
+}
+
+// NumberAssertion is used to build a chain of assertions for the numeric value.
+type NumberAssertion struct {
+	t             TestingT
+	messagePrefix string
+	value         float64
+	exact         *big.Rat
+}
+
+// NewNumberAssertion creates NumberAssertion for the numeric value. The exact value is used
+// by EqualToDecimal assertion, if it is nil, then it is calculated by the shortest decimal
+// representation of the value, so 0.1 is exactly 1/10.
+func NewNumberAssertion(t TestingT, messagePrefix string, value float64, exact *big.Rat) *NumberAssertion {
+	if exact == nil && !math.IsInf(value, 0) && !math.IsNaN(value) {
+		exact, _ = new(big.Rat).SetString(strconv.FormatFloat(value, 'g', -1, 64))
+	}
+	return &NumberAssertion{t: t, messagePrefix: messagePrefix, value: value, exact: exact}
+}
+
+// IsZero asserts that the numeric value equals to 0.
+func (a *NumberAssertion) IsZero(msgAndArgs ...interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(a.value == 0, fmt.Sprintf(`is zero, actual is %f`, a.value), msgAndArgs)
+}
+
+// IsNotZero asserts that the numeric value not equals to 0.
+func (a *NumberAssertion) IsNotZero(msgAndArgs ...interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(a.value != 0, `is not zero`, msgAndArgs)
+}
+
+// EqualTo asserts that the numeric value equals to the given value.
+func (a *NumberAssertion) EqualTo(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(a.value == expected, fmt.Sprintf(`equal to %f, actual is %f`, expected, a.value), msgAndArgs)
+}
+
+// NotEqualTo asserts that the numeric value not equals to the given value.
+func (a *NumberAssertion) NotEqualTo(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(a.value != expected, fmt.Sprintf(`not equal to %f, actual is %f`, expected, a.value), msgAndArgs)
+}
+
+// EqualToWithDelta asserts that the numeric value equals to the given value with delta.
+func (a *NumberAssertion) EqualToWithDelta(expected, delta float64, msgAndArgs ...interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	dt := a.value - expected
+	return a.check(
+		dt >= -delta && dt <= delta,
+		fmt.Sprintf(`equal to %f with delta %f, actual is %f`, expected, delta, a.value),
+		msgAndArgs,
+	)
+}
+
+// GreaterThan asserts that the numeric value is greater than the given value.
+func (a *NumberAssertion) GreaterThan(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(a.value > expected, fmt.Sprintf(`greater than %f, actual is %f`, expected, a.value), msgAndArgs)
+}
+
+// GreaterThanOrEqual asserts that the numeric value is greater than or equal to the given value.
+func (a *NumberAssertion) GreaterThanOrEqual(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(
+		a.value >= expected,
+		fmt.Sprintf(`greater than or equal %f, actual is %f`, expected, a.value),
+		msgAndArgs,
+	)
+}
+
+// LessThan asserts that the numeric value is less than the given value.
+func (a *NumberAssertion) LessThan(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(a.value < expected, fmt.Sprintf(`less than %f, actual is %f`, expected, a.value), msgAndArgs)
+}
+
+// LessThanOrEqual asserts that the numeric value is less than or equal to the given value.
+func (a *NumberAssertion) LessThanOrEqual(expected float64, msgAndArgs ...interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	return a.check(
+		a.value <= expected,
+		fmt.Sprintf(`less than or equal %f, actual is %f`, expected, a.value),
+		msgAndArgs,
+	)
+}
+
+// EqualToDecimal asserts that the numeric value exactly equals to the decimal literal
+// (like "0.1" or "12345678901234567890"). Unlike EqualTo, it compares values without rounding
+// to float64, if the exact value is known.
+func (a *NumberAssertion) EqualToDecimal(expected string, msgAndArgs ...interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	message, ok := equalDecimal(a.exact, expected)
+	return a.check(ok, message, msgAndArgs)
+}
+
+// Value returns the numeric value.
+func (a *NumberAssertion) Value() float64 {
+	if a == nil {
+		return 0
+	}
+	return a.value
+}
+
+func (a *NumberAssertion) check(passed bool, message string, msgAndArgs []interface{}) *NumberAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	if !passed {
+		assert.Fail(a.t, a.messagePrefix+message, msgAndArgs...)
+	}
+
+	return a
+}
+
+func equalDecimal(actual *big.Rat, expected string) (string, bool) {
+	want, ok := new(big.Rat).SetString(expected)
+	if !ok {
+		return fmt.Sprintf(`equal to %s, but it is not a valid decimal number`, expected), false
+	}
+	if actual == nil || actual.Cmp(want) != 0 {
+		return fmt.Sprintf(`equal to %s, actual is %s`, expected, formatDecimal(actual)), false
+	}
+	return "", true
+}
+
+func formatDecimal(value *big.Rat) string {
+	if value == nil {
+		return "NaN"
+	}
+	if value.IsInt() {
+		return value.Num().String()
+	}
+	s := value.FloatString(20)
+	return strings.TrimRight(s, "0")
+}
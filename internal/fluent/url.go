@@ -0,0 +1,95 @@
+package fluent
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// URLAssertion is used to build a chain of assertions for the URL value.
+type URLAssertion struct {
+	t             TestingT
+	messagePrefix string
+	url           *url.URL
+}
+
+func NewURLAssertion(t TestingT, messagePrefix string, value *url.URL) *URLAssertion {
+	return &URLAssertion{t: t, messagePrefix: messagePrefix, url: value}
+}
+
+// WithSchemas additionally asserts than URL schema contains one of the given values.
+func (a *URLAssertion) WithSchemas(schemas ...string) *URLAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	for _, schema := range schemas {
+		if a.url.Scheme == schema {
+			return a
+		}
+	}
+
+	a.fail(
+		fmt.Sprintf(
+			`is URL with schemas %s, actual is "%s"`,
+			strings.Join(quoteAll(schemas), ", "),
+			a.url.Scheme,
+		),
+	)
+
+	return a
+}
+
+// WithHosts additionally asserts than URL host contains one of the given values.
+func (a *URLAssertion) WithHosts(hosts ...string) *URLAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	for _, host := range hosts {
+		if a.url.Host == host {
+			return a
+		}
+	}
+
+	a.fail(
+		fmt.Sprintf(
+			`is URL with hosts %s, actual is "%s"`,
+			strings.Join(quoteAll(hosts), ", "),
+			a.url.Host,
+		),
+	)
+
+	return a
+}
+
+// That asserts that the URL value is satisfied by callback function.
+func (a *URLAssertion) That(f func(u *url.URL) error, msgAndArgs ...interface{}) *URLAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+	if err := f(a.url); err != nil {
+		a.fail(`is URL: `+err.Error(), msgAndArgs...)
+	}
+
+	return a
+}
+
+func (a *URLAssertion) fail(message string, msgAndArgs ...interface{}) {
+	a.t.Helper()
+	assert.Fail(a.t, a.messagePrefix+message, msgAndArgs...)
+}
+
+func quoteAll(s []string) []string {
+	ss := make([]string, len(s))
+	for i, v := range s {
+		ss[i] = strconv.Quote(v)
+	}
+	return ss
+}
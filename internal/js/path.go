@@ -153,6 +153,39 @@ func (path *Path) Elements() []PathElement {
 	return elements
 }
 
+// Equal returns true if both paths have the same elements.
+func (path *Path) Equal(other *Path) bool {
+	elements := path.Elements()
+	otherElements := other.Elements()
+	if len(elements) != len(otherElements) {
+		return false
+	}
+	for i := range elements {
+		if elements[i].IsIndex() != otherElements[i].IsIndex() || elements[i].String() != otherElements[i].String() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasPrefix returns true if the path starts with all elements of the prefix.
+// Every path has an empty prefix.
+func (path *Path) HasPrefix(prefix *Path) bool {
+	elements := path.Elements()
+	prefixElements := prefix.Elements()
+	if len(elements) < len(prefixElements) {
+		return false
+	}
+	for i := range prefixElements {
+		if elements[i].IsIndex() != prefixElements[i].IsIndex() || elements[i].String() != prefixElements[i].String() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Len returns count of property path elements.
 func (path *Path) Len() int {
 	length := 0
@@ -142,3 +142,22 @@ type String string
 func (s String) String() string {
 	return string(s)
 }
+
+func TestPath_Equal(t *testing.T) {
+	path := js.NewPath(js.PropertyName("top"), js.ArrayIndex(0))
+
+	assert.True(t, path.Equal(js.NewPath(js.PropertyName("top"), js.ArrayIndex(0))))
+	assert.False(t, path.Equal(js.NewPath(js.PropertyName("top"), js.PropertyName("0"))))
+	assert.False(t, path.Equal(js.NewPath(js.PropertyName("top"))))
+	assert.True(t, (*js.Path)(nil).Equal(nil))
+}
+
+func TestPath_HasPrefix(t *testing.T) {
+	path := js.NewPath(js.PropertyName("top"), js.ArrayIndex(0), js.PropertyName("id"))
+
+	assert.True(t, path.HasPrefix(js.NewPath(js.PropertyName("top"), js.ArrayIndex(0))))
+	assert.True(t, path.HasPrefix(path))
+	assert.True(t, path.HasPrefix(nil))
+	assert.False(t, path.HasPrefix(js.NewPath(js.PropertyName("top"), js.PropertyName("0"))))
+	assert.False(t, path.HasPrefix(path.WithProperty("name")))
+}
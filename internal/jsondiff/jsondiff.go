@@ -0,0 +1,163 @@
+// Package jsondiff implements structural comparison of decoded JSON values.
+package jsondiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/js"
+)
+
+// Kind is a kind of the difference.
+type Kind int
+
+const (
+	// Changed means that the value at the path differs.
+	Changed Kind = iota
+	// Added means that the actual document has a value that does not exist in the expected one.
+	Added
+	// Removed means that the actual document has no value that exists in the expected one.
+	Removed
+)
+
+// String returns name of the difference kind.
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	}
+	return "changed"
+}
+
+// Difference describes one difference between the expected and the actual values.
+type Difference struct {
+	Kind     Kind
+	Path     *js.Path
+	Expected interface{}
+	Actual   interface{}
+}
+
+// String formats the difference as a single line.
+func (d Difference) String() string {
+	switch d.Kind {
+	case Added:
+		return fmt.Sprintf(`added "%s": %s`, d.Path.String(), formatValue(d.Actual))
+	case Removed:
+		return fmt.Sprintf(`removed "%s": %s`, d.Path.String(), formatValue(d.Expected))
+	}
+
+	return fmt.Sprintf(`changed "%s": %s -> %s`, d.Path.String(), formatValue(d.Expected), formatValue(d.Actual))
+}
+
+// Compare returns differences between the expected and the actual decoded JSON values.
+// Object properties are compared by keys in sorted order, arrays are compared element by element.
+// Numbers are compared by value, so 1 and 1.0 are equal. The path is used as prefix
+// for the paths of differences.
+func Compare(path *js.Path, expected, actual interface{}) []Difference {
+	var differences []Difference
+	compare(&differences, path, expected, actual)
+	return differences
+}
+
+// Format formats the differences line by line, each line is indented by the tab.
+func Format(differences []Difference) string {
+	lines := make([]string, len(differences))
+	for i, difference := range differences {
+		lines[i] = "\t" + difference.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func compare(differences *[]Difference, path *js.Path, expected, actual interface{}) {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		if a, ok := actual.(map[string]interface{}); ok {
+			compareObjects(differences, path, e, a)
+			return
+		}
+	case []interface{}:
+		if a, ok := actual.([]interface{}); ok {
+			compareArrays(differences, path, e, a)
+			return
+		}
+	default:
+		if equalScalars(expected, actual) {
+			return
+		}
+	}
+
+	*differences = append(*differences, Difference{Kind: Changed, Path: path, Expected: expected, Actual: actual})
+}
+
+func compareObjects(differences *[]Difference, path *js.Path, expected, actual map[string]interface{}) {
+	keys := make([]string, 0, len(expected)+len(actual))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	for key := range actual {
+		if _, exists := expected[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		e, inExpected := expected[key]
+		a, inActual := actual[key]
+		switch {
+		case !inActual:
+			*differences = append(*differences, Difference{Kind: Removed, Path: path.WithProperty(key), Expected: e})
+		case !inExpected:
+			*differences = append(*differences, Difference{Kind: Added, Path: path.WithProperty(key), Actual: a})
+		default:
+			compare(differences, path.WithProperty(key), e, a)
+		}
+	}
+}
+
+func compareArrays(differences *[]Difference, path *js.Path, expected, actual []interface{}) {
+	for i := 0; i < len(expected) || i < len(actual); i++ {
+		switch {
+		case i >= len(actual):
+			*differences = append(*differences, Difference{Kind: Removed, Path: path.WithIndex(i), Expected: expected[i]})
+		case i >= len(expected):
+			*differences = append(*differences, Difference{Kind: Added, Path: path.WithIndex(i), Actual: actual[i]})
+		default:
+			compare(differences, path.WithIndex(i), expected[i], actual[i])
+		}
+	}
+}
+
+func equalScalars(expected, actual interface{}) bool {
+	if e, ok := toRat(expected); ok {
+		a, ok := toRat(actual)
+		return ok && e.Cmp(a) == 0
+	}
+	return reflect.DeepEqual(expected, actual)
+}
+
+func toRat(value interface{}) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case float64:
+		return new(big.Rat).SetFloat64(v), true
+	case json.Number:
+		return new(big.Rat).SetString(v.String())
+	}
+	return nil, false
+}
+
+func formatValue(value interface{}) string {
+	s := &strings.Builder{}
+	encoder := json.NewEncoder(s)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return strings.TrimSuffix(s.String(), "\n")
+}
@@ -0,0 +1,74 @@
+package jsondiff_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/muonsoft/api-testing/internal/js"
+	"github.com/muonsoft/api-testing/internal/jsondiff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		want     []string
+	}{
+		{
+			name:     "equal documents",
+			expected: `{"a": 1, "b": [1, 2, {"c": null}]}`,
+			actual:   `{"b": [1.0, 2, {"c": null}], "a": 1}`,
+		},
+		{
+			name:     "changed values",
+			expected: `{"id": 1, "name": "<John>", "items": [{"id": 1}]}`,
+			actual:   `{"id": "1", "name": "Jane", "items": [{"id": 2}]}`,
+			want: []string{
+				`changed "id": 1 -> "1"`,
+				`changed "items[0].id": 1 -> 2`,
+				`changed "name": "<John>" -> "Jane"`,
+			},
+		},
+		{
+			name:     "added and removed",
+			expected: `{"a": 1, "items": [1, 2, 3]}`,
+			actual:   `{"b": {"c": true}, "items": [1]}`,
+			want: []string{
+				`removed "a": 1`,
+				`added "b": {"c":true}`,
+				`removed "items[1]": 2`,
+				`removed "items[2]": 3`,
+			},
+		},
+		{
+			name:     "changed type",
+			expected: `{"a": [1]}`,
+			actual:   `{"a": {"0": 1}}`,
+			want:     []string{`changed "a": [1] -> {"0":1}`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var expected, actual interface{}
+			require.NoError(t, json.Unmarshal([]byte(test.expected), &expected))
+			require.NoError(t, json.Unmarshal([]byte(test.actual), &actual))
+
+			differences := jsondiff.Compare(nil, expected, actual)
+
+			var got []string
+			for _, difference := range differences {
+				got = append(got, difference.String())
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	differences := jsondiff.Compare(js.NewPath().WithProperty("data"), map[string]interface{}{"a": "x"}, map[string]interface{}{"a": "y"})
+
+	assert.Equal(t, "\tchanged \"data.a\": \"x\" -> \"y\"", jsondiff.Format(differences))
+}
@@ -0,0 +1,510 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/muonsoft/api-testing/internal/js"
+)
+
+// Node is a value selected by the query with its location in the document.
+type Node struct {
+	Path  *js.Path
+	Value interface{}
+}
+
+// Select evaluates the query on the document and returns selected nodes in the document order.
+// Object members are visited in the order of sorted keys.
+func (q *Query) Select(document interface{}) []Node {
+	return q.selectFrom(Node{Value: document}, document)
+}
+
+// SelectValues evaluates the query on the document and returns selected values.
+func (q *Query) SelectValues(document interface{}) []interface{} {
+	nodes := q.Select(document)
+	values := make([]interface{}, len(nodes))
+	for i, node := range nodes {
+		values[i] = node.Value
+	}
+
+	return values
+}
+
+func (q *Query) selectFrom(current Node, root interface{}) []Node {
+	nodes := []Node{current}
+	for _, s := range q.segments {
+		var next []Node
+		for _, node := range nodes {
+			if s.descendant {
+				for _, descendant := range descendants(node) {
+					next = s.apply(next, descendant, root)
+				}
+			} else {
+				next = s.apply(next, node, root)
+			}
+		}
+		nodes = next
+	}
+
+	return nodes
+}
+
+func (s segment) apply(result []Node, node Node, root interface{}) []Node {
+	for _, sel := range s.selectors {
+		result = sel.apply(result, node, root)
+	}
+	return result
+}
+
+// descendants returns the node and all its descendants in the document order.
+func descendants(node Node) []Node {
+	result := []Node{node}
+	for _, child := range children(node) {
+		result = append(result, descendants(child)...)
+	}
+	return result
+}
+
+func children(node Node) []Node {
+	switch v := node.Value.(type) {
+	case []interface{}:
+		result := make([]Node, len(v))
+		for i, element := range v {
+			result[i] = Node{Path: node.Path.WithIndex(i), Value: element}
+		}
+		return result
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		result := make([]Node, len(keys))
+		for i, key := range keys {
+			result[i] = Node{Path: node.Path.WithProperty(key), Value: v[key]}
+		}
+		return result
+	}
+
+	return nil
+}
+
+type selector interface {
+	apply(result []Node, node Node, root interface{}) []Node
+}
+
+type nameSelector struct {
+	name string
+}
+
+func (s nameSelector) apply(result []Node, node Node, _ interface{}) []Node {
+	if object, ok := node.Value.(map[string]interface{}); ok {
+		if value, exists := object[s.name]; exists {
+			result = append(result, Node{Path: node.Path.WithProperty(s.name), Value: value})
+		}
+	}
+	return result
+}
+
+type wildcardSelector struct{}
+
+func (s wildcardSelector) apply(result []Node, node Node, _ interface{}) []Node {
+	return append(result, children(node)...)
+}
+
+type indexSelector struct {
+	index int
+}
+
+func (s indexSelector) apply(result []Node, node Node, _ interface{}) []Node {
+	if array, ok := node.Value.([]interface{}); ok {
+		i := s.index
+		if i < 0 {
+			i += len(array)
+		}
+		if i >= 0 && i < len(array) {
+			result = append(result, Node{Path: node.Path.WithIndex(i), Value: array[i]})
+		}
+	}
+	return result
+}
+
+type sliceSelector struct {
+	start, end, step *int
+}
+
+func (s sliceSelector) apply(result []Node, node Node, _ interface{}) []Node {
+	array, ok := node.Value.([]interface{})
+	if !ok {
+		return result
+	}
+	length := len(array)
+	step := 1
+	if s.step != nil {
+		step = *s.step
+	}
+	if step == 0 {
+		return result
+	}
+
+	var start, end int
+	if step > 0 {
+		start, end = 0, length
+	} else {
+		start, end = length-1, -length-1
+	}
+	if s.start != nil {
+		start = *s.start
+	}
+	if s.end != nil {
+		end = *s.end
+	}
+	start, end = normalize(start, length), normalize(end, length)
+
+	if step > 0 {
+		lower, upper := clamp(start, 0, length), clamp(end, 0, length)
+		for i := lower; i < upper; i += step {
+			result = append(result, Node{Path: node.Path.WithIndex(i), Value: array[i]})
+		}
+	} else {
+		upper, lower := clamp(start, -1, length-1), clamp(end, -1, length-1)
+		for i := upper; i > lower; i += step {
+			result = append(result, Node{Path: node.Path.WithIndex(i), Value: array[i]})
+		}
+	}
+
+	return result
+}
+
+func normalize(i, length int) int {
+	if i < 0 {
+		return length + i
+	}
+	return i
+}
+
+func clamp(i, lower, upper int) int {
+	if i < lower {
+		return lower
+	}
+	if i > upper {
+		return upper
+	}
+	return i
+}
+
+type filterSelector struct {
+	expr expression
+}
+
+func (s filterSelector) apply(result []Node, node Node, root interface{}) []Node {
+	for _, child := range children(node) {
+		if s.expr.test(child.Value, root) {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+type expression interface {
+	test(current, root interface{}) bool
+}
+
+type orExpression []expression
+
+func (e orExpression) test(current, root interface{}) bool {
+	for _, operand := range e {
+		if operand.test(current, root) {
+			return true
+		}
+	}
+	return false
+}
+
+type andExpression []expression
+
+func (e andExpression) test(current, root interface{}) bool {
+	for _, operand := range e {
+		if !operand.test(current, root) {
+			return false
+		}
+	}
+	return true
+}
+
+type notExpression struct {
+	expr expression
+}
+
+func (e notExpression) test(current, root interface{}) bool {
+	return !e.expr.test(current, root)
+}
+
+type existsExpression struct {
+	query *Query
+}
+
+func (e existsExpression) test(current, root interface{}) bool {
+	return len(e.query.evaluate(current, root)) > 0
+}
+
+type functionExpression struct {
+	call *functionCall
+}
+
+func (e functionExpression) test(current, root interface{}) bool {
+	result, _ := e.call.value(current, root)
+	b, _ := result.(bool)
+	return b
+}
+
+type comparisonExpression struct {
+	left     operand
+	operator string
+	right    operand
+}
+
+func (e comparisonExpression) test(current, root interface{}) bool {
+	left, leftExists := e.left.value(current, root)
+	right, rightExists := e.right.value(current, root)
+
+	switch e.operator {
+	case "==":
+		return equal(left, leftExists, right, rightExists)
+	case "!=":
+		return !equal(left, leftExists, right, rightExists)
+	case "<":
+		return leftExists && rightExists && less(left, right)
+	case ">":
+		return leftExists && rightExists && less(right, left)
+	case "<=":
+		return equal(left, leftExists, right, rightExists) || (leftExists && rightExists && less(left, right))
+	case ">=":
+		return equal(left, leftExists, right, rightExists) || (leftExists && rightExists && less(right, left))
+	}
+
+	return false
+}
+
+// operand is a comparable value: literal, singular query or function call.
+// The second result is false when the value is "Nothing" (empty node list).
+type operand interface {
+	value(current, root interface{}) (interface{}, bool)
+}
+
+type literal struct {
+	constant interface{}
+}
+
+func (l literal) value(_, _ interface{}) (interface{}, bool) {
+	return l.constant, true
+}
+
+type queryOperand struct {
+	query *Query
+}
+
+func (o queryOperand) value(current, root interface{}) (interface{}, bool) {
+	nodes := o.query.evaluate(current, root)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0].Value, true
+}
+
+func (q *Query) evaluate(current, root interface{}) []Node {
+	if q.relative {
+		return q.selectFrom(Node{Value: current}, root)
+	}
+	return q.selectFrom(Node{Value: root}, root)
+}
+
+func equal(left interface{}, leftExists bool, right interface{}, rightExists bool) bool {
+	if !leftExists || !rightExists {
+		return !leftExists && !rightExists
+	}
+	if a, ok := toFloat(left); ok {
+		b, ok := toFloat(right)
+		return ok && a == b
+	}
+	switch l := left.(type) {
+	case []interface{}:
+		r, ok := right.([]interface{})
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			if !equal(l[i], true, r[i], true) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		r, ok := right.(map[string]interface{})
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for key, value := range l {
+			other, exists := r[key]
+			if !exists || !equal(value, true, other, true) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(left, right)
+}
+
+func less(left, right interface{}) bool {
+	if a, ok := toFloat(left); ok {
+		b, ok := toFloat(right)
+		return ok && a < b
+	}
+	if a, ok := left.(string); ok {
+		b, ok := right.(string)
+		return ok && a < b
+	}
+	return false
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+type functionType int
+
+const (
+	valueType functionType = iota
+	logicalType
+	nodesType
+)
+
+type function struct {
+	name       string
+	parameters []functionType
+	result     functionType
+	call       func(arguments []interface{}, exists []bool) (interface{}, bool)
+}
+
+type functionCall struct {
+	function  *function
+	arguments []operand
+}
+
+func (c *functionCall) value(current, root interface{}) (interface{}, bool) {
+	arguments := make([]interface{}, len(c.arguments))
+	exists := make([]bool, len(c.arguments))
+	for i, argument := range c.arguments {
+		if c.function.parameters[i] == nodesType {
+			query := argument.(queryOperand).query
+			arguments[i] = query.evaluate(current, root)
+			exists[i] = true
+			continue
+		}
+		arguments[i], exists[i] = argument.value(current, root)
+	}
+
+	return c.function.call(arguments, exists)
+}
+
+var functions = map[string]*function{
+	"length": {
+		name:       "length",
+		parameters: []functionType{valueType},
+		result:     valueType,
+		call: func(arguments []interface{}, exists []bool) (interface{}, bool) {
+			if !exists[0] {
+				return nil, false
+			}
+			switch v := arguments[0].(type) {
+			case string:
+				return float64(utf8.RuneCountInString(v)), true
+			case []interface{}:
+				return float64(len(v)), true
+			case map[string]interface{}:
+				return float64(len(v)), true
+			}
+			return nil, false
+		},
+	},
+	"count": {
+		name:       "count",
+		parameters: []functionType{nodesType},
+		result:     valueType,
+		call: func(arguments []interface{}, _ []bool) (interface{}, bool) {
+			return float64(len(arguments[0].([]Node))), true
+		},
+	},
+	"value": {
+		name:       "value",
+		parameters: []functionType{nodesType},
+		result:     valueType,
+		call: func(arguments []interface{}, _ []bool) (interface{}, bool) {
+			nodes := arguments[0].([]Node)
+			if len(nodes) != 1 {
+				return nil, false
+			}
+			return nodes[0].Value, true
+		},
+	},
+	"match": {
+		name:       "match",
+		parameters: []functionType{valueType, valueType},
+		result:     logicalType,
+		call: func(arguments []interface{}, exists []bool) (interface{}, bool) {
+			return matchRegexp(arguments, exists, true), true
+		},
+	},
+	"search": {
+		name:       "search",
+		parameters: []functionType{valueType, valueType},
+		result:     logicalType,
+		call: func(arguments []interface{}, exists []bool) (interface{}, bool) {
+			return matchRegexp(arguments, exists, false), true
+		},
+	},
+}
+
+var regexpCache sync.Map
+
+func matchRegexp(arguments []interface{}, exists []bool, full bool) bool {
+	if !exists[0] || !exists[1] {
+		return false
+	}
+	s, ok := arguments[0].(string)
+	if !ok {
+		return false
+	}
+	pattern, ok := arguments[1].(string)
+	if !ok {
+		return false
+	}
+	if full {
+		pattern = `\A(?:` + pattern + `)\z`
+	}
+	cached, ok := regexpCache.Load(pattern)
+	if !ok {
+		// "." in I-Regexp (RFC 9485) does not match line breaks
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+		cached, _ = regexpCache.LoadOrStore(pattern, re)
+	}
+
+	return cached.(*regexp.Regexp).MatchString(s)
+}
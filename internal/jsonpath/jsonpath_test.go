@@ -0,0 +1,101 @@
+package jsonpath_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/muonsoft/api-testing/internal/jsonpath"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bookstore = `{
+	"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 399}
+	}
+}`
+
+func TestQuery_Select(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantPaths  []string
+	}{
+		{expression: `$`, wantPaths: []string{""}},
+		{expression: `$.store.book[*].author`, wantPaths: []string{
+			"store.book[0].author", "store.book[1].author", "store.book[2].author", "store.book[3].author",
+		}},
+		{expression: `$..author`, wantPaths: []string{
+			"store.book[0].author", "store.book[1].author", "store.book[2].author", "store.book[3].author",
+		}},
+		{expression: `$.store.*`, wantPaths: []string{"store.bicycle", "store.book"}},
+		{expression: `$.store..price`, wantPaths: []string{
+			"store.bicycle.price",
+			"store.book[0].price", "store.book[1].price", "store.book[2].price", "store.book[3].price",
+		}},
+		{expression: `$..book[2]`, wantPaths: []string{"store.book[2]"}},
+		{expression: `$..book[-1]`, wantPaths: []string{"store.book[3]"}},
+		{expression: `$..book[0,1]`, wantPaths: []string{"store.book[0]", "store.book[1]"}},
+		{expression: `$..book[:2]`, wantPaths: []string{"store.book[0]", "store.book[1]"}},
+		{expression: `$..book[::-2]`, wantPaths: []string{"store.book[3]", "store.book[1]"}},
+		{expression: `$..book[?@.isbn]`, wantPaths: []string{"store.book[2]", "store.book[3]"}},
+		{expression: `$..book[?@.price<10]`, wantPaths: []string{"store.book[0]", "store.book[2]"}},
+		{expression: `$..book[?@.price < $.store.bicycle.price && @.category == 'fiction']`, wantPaths: []string{
+			"store.book[1]", "store.book[2]", "store.book[3]",
+		}},
+		{expression: `$..book[?!(@.price >= 10)]`, wantPaths: []string{"store.book[0]", "store.book[2]"}},
+		{expression: `$..book[?match(@.author, 'J.*')]`, wantPaths: []string{"store.book[3]"}},
+		{expression: `$..book[?search(@.title, 'of')]`, wantPaths: []string{"store.book[0]", "store.book[1]", "store.book[3]"}},
+		{expression: `$..book[?length(@.title) > 21]`, wantPaths: []string{"store.book[0]"}},
+		{expression: `$.store[?count(@.*) == 2]`, wantPaths: []string{"store.bicycle"}},
+		{expression: `$["store"]['bicycle']["color"]`, wantPaths: []string{"store.bicycle.color"}},
+		{expression: `$.missing`, wantPaths: nil},
+	}
+	var document interface{}
+	require.NoError(t, json.Unmarshal([]byte(bookstore), &document))
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			query, err := jsonpath.Parse(test.expression)
+			require.NoError(t, err)
+
+			nodes := query.Select(document)
+
+			var paths []string
+			for _, node := range nodes {
+				paths = append(paths, node.Path.String())
+			}
+			assert.Equal(t, test.wantPaths, paths)
+		})
+	}
+}
+
+func TestParse_WhenInvalidExpression_ExpectError(t *testing.T) {
+	expressions := []string{
+		``,
+		`store`,
+		`@.store`,
+		`$.`,
+		`$[`,
+		`$[01]`,
+		`$[-0]`,
+		`$ `,
+		`$[?@.a == @..b]`,
+		`$[?@.a == 1 == 2]`,
+		`$[?length(@.*) > 1]`,
+		`$[?count(@.a)]`,
+		`$[?unknown(@.a)]`,
+		`$['a\q']`,
+	}
+	for _, expression := range expressions {
+		t.Run(expression, func(t *testing.T) {
+			_, err := jsonpath.Parse(expression)
+
+			assert.ErrorIs(t, err, jsonpath.ErrSyntax)
+		})
+	}
+}
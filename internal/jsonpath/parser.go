@@ -0,0 +1,622 @@
+// Package jsonpath implements JSONPath query expressions defined by RFC 9535.
+// Queries are evaluated on the decoded JSON values (maps, slices and scalars).
+package jsonpath
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var ErrSyntax = errors.New("invalid JSONPath syntax")
+
+// Query is a compiled JSONPath expression.
+type Query struct {
+	expression string
+	relative   bool
+	segments   []segment
+}
+
+// Parse compiles JSONPath expression. The expression must start with the root identifier "$".
+func Parse(expression string) (*Query, error) {
+	p := &parser{input: expression}
+	query, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	if query.relative {
+		return nil, p.errorf("query must start with \"$\"")
+	}
+	if !p.eof() {
+		return nil, p.errorf("unexpected character %q", p.peek())
+	}
+	query.expression = expression
+
+	return query, nil
+}
+
+// String returns source expression of the query.
+func (q *Query) String() string {
+	return q.expression
+}
+
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w at position %d: %s", ErrSyntax, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(p.input[p.pos:], prefix)
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && isSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+// parseQuery parses absolute ("$") or relative ("@") query with its segments.
+func (p *parser) parseQuery() (*Query, error) {
+	query := &Query{}
+	switch p.peek() {
+	case '$':
+	case '@':
+		query.relative = true
+	default:
+		return nil, p.errorf(`query must start with "$" or "@"`)
+	}
+	p.pos++
+
+	for {
+		// whitespace is allowed between segments, it must be restored if no segment follows
+		start := p.pos
+		p.skipSpaces()
+		if !p.hasPrefix(".") && !p.hasPrefix("[") {
+			p.pos = start
+			return query, nil
+		}
+		s, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		query.segments = append(query.segments, s)
+	}
+}
+
+func (p *parser) parseSegment() (segment, error) {
+	if p.hasPrefix("..") {
+		p.pos += 2
+		switch {
+		case p.hasPrefix("["):
+			selectors, err := p.parseBracketed()
+			return segment{descendant: true, selectors: selectors}, err
+		case p.hasPrefix("*"):
+			p.pos++
+			return segment{descendant: true, selectors: []selector{wildcardSelector{}}}, nil
+		}
+		name, err := p.parseMemberName()
+		return segment{descendant: true, selectors: []selector{nameSelector{name: name}}}, err
+	}
+	if p.hasPrefix(".") {
+		p.pos++
+		if p.hasPrefix("*") {
+			p.pos++
+			return segment{selectors: []selector{wildcardSelector{}}}, nil
+		}
+		name, err := p.parseMemberName()
+		return segment{selectors: []selector{nameSelector{name: name}}}, err
+	}
+	selectors, err := p.parseBracketed()
+
+	return segment{selectors: selectors}, err
+}
+
+func (p *parser) parseMemberName() (string, error) {
+	start := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		isFirst := r == '_' || r >= 0x80 || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isFirst && (p.pos == start || r < '0' || r > '9') {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return "", p.errorf("member name expected")
+	}
+
+	return p.input[start:p.pos], nil
+}
+
+func (p *parser) parseBracketed() ([]selector, error) {
+	p.pos++ // [
+	var selectors []selector
+	for {
+		p.skipSpaces()
+		s, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, s)
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return selectors, nil
+		default:
+			return nil, p.errorf(`expected "," or "]"`)
+		}
+	}
+}
+
+func (p *parser) parseSelector() (selector, error) {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		return nameSelector{name: name}, err
+	case c == '*':
+		p.pos++
+		return wildcardSelector{}, nil
+	case c == '?':
+		p.pos++
+		p.skipSpaces()
+		e, err := p.parseLogicalOr()
+		return filterSelector{expr: e}, err
+	case c == ':' || c == '-' || (c >= '0' && c <= '9'):
+		return p.parseIndexOrSlice()
+	}
+
+	return nil, p.errorf("selector expected")
+}
+
+func (p *parser) parseIndexOrSlice() (selector, error) {
+	var bounds [3]*int
+	for i := 0; i < 3; i++ {
+		p.skipSpaces()
+		if c := p.peek(); c == '-' || (c >= '0' && c <= '9') {
+			n, err := p.parseInteger()
+			if err != nil {
+				return nil, err
+			}
+			bounds[i] = &n
+			p.skipSpaces()
+		}
+		if i == 0 && p.peek() != ':' {
+			if bounds[0] == nil {
+				return nil, p.errorf("index expected")
+			}
+			return indexSelector{index: *bounds[0]}, nil
+		}
+		if i == 2 || p.peek() != ':' {
+			break
+		}
+		p.pos++
+	}
+
+	return sliceSelector{start: bounds[0], end: bounds[1], step: bounds[2]}, nil
+}
+
+// maxInteger is the limit of I-JSON integers.
+const maxInteger = 1<<53 - 1
+
+func (p *parser) parseInteger() (int, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	digits := p.pos
+	for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	s := p.input[start:p.pos]
+	if p.pos == digits || (p.input[digits] == '0' && (p.pos-digits > 1 || digits > start)) {
+		return 0, p.errorf("invalid integer %q", s)
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n > maxInteger || n < -maxInteger {
+		return 0, p.errorf("integer %q is out of range", s)
+	}
+
+	return int(n), nil
+}
+
+func (p *parser) parseString() (string, error) {
+	quote := p.peek()
+	p.pos++
+	var s strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.peek()
+		switch {
+		case c == quote:
+			p.pos++
+			return s.String(), nil
+		case c == '\\':
+			r, err := p.parseEscape(quote)
+			if err != nil {
+				return "", err
+			}
+			s.WriteRune(r)
+		case c < 0x20:
+			return "", p.errorf("control character in string")
+		default:
+			r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+			s.WriteRune(r)
+			p.pos += size
+		}
+	}
+}
+
+func (p *parser) parseEscape(quote byte) (rune, error) {
+	p.pos++ // backslash
+	if p.eof() {
+		return 0, p.errorf("unterminated escape sequence")
+	}
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case '/', '\\':
+		return rune(c), nil
+	case 'u':
+		r, err := p.parseHex()
+		if err != nil {
+			return 0, err
+		}
+		if utf16.IsSurrogate(r) {
+			if !p.hasPrefix(`\u`) {
+				return 0, p.errorf("invalid surrogate pair")
+			}
+			p.pos += 2
+			low, err := p.parseHex()
+			if err != nil {
+				return 0, err
+			}
+			r = utf16.DecodeRune(r, low)
+			if r == unicode.ReplacementChar {
+				return 0, p.errorf("invalid surrogate pair")
+			}
+		}
+		return r, nil
+	}
+	if c == quote {
+		return rune(c), nil
+	}
+
+	return 0, p.errorf("invalid escape sequence")
+}
+
+func (p *parser) parseHex() (rune, error) {
+	if p.pos+4 > len(p.input) {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	n, err := strconv.ParseUint(p.input[p.pos:p.pos+4], 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	p.pos += 4
+
+	return rune(n), nil
+}
+
+func (p *parser) parseLogicalOr() (expression, error) {
+	left, err := p.parseLogicalAnd()
+	if err != nil {
+		return nil, err
+	}
+	operands := []expression{left}
+	for {
+		p.skipSpaces()
+		if !p.hasPrefix("||") {
+			break
+		}
+		p.pos += 2
+		p.skipSpaces()
+		right, err := p.parseLogicalAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+
+	return orExpression(operands), nil
+}
+
+func (p *parser) parseLogicalAnd() (expression, error) {
+	left, err := p.parseBasic()
+	if err != nil {
+		return nil, err
+	}
+	operands := []expression{left}
+	for {
+		p.skipSpaces()
+		if !p.hasPrefix("&&") {
+			break
+		}
+		p.pos += 2
+		p.skipSpaces()
+		right, err := p.parseBasic()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+
+	return andExpression(operands), nil
+}
+
+//nolint:cyclop
+func (p *parser) parseBasic() (expression, error) {
+	negate := false
+	if p.hasPrefix("!") && !p.hasPrefix("!=") {
+		p.pos++
+		p.skipSpaces()
+		negate = true
+	}
+
+	if p.hasPrefix("(") {
+		p.pos++
+		p.skipSpaces()
+		e, err := p.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.hasPrefix(")") {
+			return nil, p.errorf(`expected ")"`)
+		}
+		p.pos++
+		return not(e, negate), nil
+	}
+
+	left, err := p.parseComparable()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	operator := p.parseOperator()
+	if operator == "" {
+		switch l := left.(type) {
+		case queryOperand:
+			return not(existsExpression{query: l.query}, negate), nil
+		case *functionCall:
+			if l.function.result != logicalType {
+				return nil, p.errorf("function %s() result must be compared", l.function.name)
+			}
+			return not(functionExpression{call: l}, negate), nil
+		}
+		return nil, p.errorf("literal must be compared")
+	}
+	if negate {
+		return nil, p.errorf(`comparison cannot be negated without parentheses`)
+	}
+	p.skipSpaces()
+	right, err := p.parseComparable()
+	if err != nil {
+		return nil, err
+	}
+	for _, operand := range []operand{left, right} {
+		if err := p.checkComparable(operand); err != nil {
+			return nil, err
+		}
+	}
+
+	return comparisonExpression{left: left, operator: operator, right: right}, nil
+}
+
+func (p *parser) checkComparable(o operand) error {
+	switch v := o.(type) {
+	case queryOperand:
+		if !v.query.isSingular() {
+			return p.errorf("non-singular query cannot be compared")
+		}
+	case *functionCall:
+		if v.function.result != valueType {
+			return p.errorf("function %s() result cannot be compared", v.function.name)
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) parseOperator() string {
+	for _, operator := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.hasPrefix(operator) {
+			p.pos += len(operator)
+			return operator
+		}
+	}
+
+	return ""
+}
+
+//nolint:cyclop
+func (p *parser) parseComparable() (operand, error) {
+	c := p.peek()
+	switch {
+	case c == '@' || c == '$':
+		query, err := p.parseQuery()
+		if err != nil {
+			return nil, err
+		}
+		return queryOperand{query: query}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		return literal{constant: s}, err
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	case p.hasPrefix("true"):
+		p.pos += 4
+		return literal{constant: true}, nil
+	case p.hasPrefix("false"):
+		p.pos += 5
+		return literal{constant: false}, nil
+	case p.hasPrefix("null"):
+		p.pos += 4
+		return literal{constant: nil}, nil
+	case c >= 'a' && c <= 'z':
+		return p.parseFunction()
+	}
+
+	return nil, p.errorf("comparable expected")
+}
+
+var numberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?`)
+
+func (p *parser) parseNumber() (operand, error) {
+	s := numberPattern.FindString(p.input[p.pos:])
+	if s == "" {
+		return nil, p.errorf("invalid number")
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(n, 0) {
+		return nil, p.errorf("invalid number %q", s)
+	}
+	p.pos += len(s)
+
+	return literal{constant: n}, nil
+}
+
+func (p *parser) parseFunction() (operand, error) {
+	start := p.pos
+	for !p.eof() {
+		c := p.peek()
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '_' {
+			break
+		}
+		p.pos++
+	}
+	name := p.input[start:p.pos]
+	f, ok := functions[name]
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("unknown function %q", name)
+	}
+	if !p.hasPrefix("(") {
+		return nil, p.errorf(`expected "("`)
+	}
+	p.pos++
+
+	call := &functionCall{function: f}
+	for {
+		p.skipSpaces()
+		if len(call.arguments) == 0 && p.hasPrefix(")") {
+			break
+		}
+		argument, err := p.parseArgument(f, len(call.arguments))
+		if err != nil {
+			return nil, err
+		}
+		call.arguments = append(call.arguments, argument)
+		p.skipSpaces()
+		if !p.hasPrefix(",") {
+			break
+		}
+		p.pos++
+	}
+	if !p.hasPrefix(")") {
+		return nil, p.errorf(`expected ")"`)
+	}
+	p.pos++
+	if len(call.arguments) != len(f.parameters) {
+		return nil, p.errorf("function %s() expects %d arguments, got %d", name, len(f.parameters), len(call.arguments))
+	}
+
+	return call, nil
+}
+
+func (p *parser) parseArgument(f *function, index int) (operand, error) {
+	argument, err := p.parseComparable()
+	if err != nil {
+		return nil, err
+	}
+	if index >= len(f.parameters) {
+		return argument, nil
+	}
+
+	switch f.parameters[index] {
+	case valueType:
+		if err := p.checkComparable(argument); err != nil {
+			return nil, err
+		}
+	case nodesType:
+		if _, ok := argument.(queryOperand); !ok {
+			return nil, p.errorf("function %s() expects query as argument", f.name)
+		}
+	case logicalType:
+		return nil, p.errorf("logical arguments are not supported")
+	}
+
+	return argument, nil
+}
+
+func not(e expression, negate bool) expression {
+	if negate {
+		return notExpression{expr: e}
+	}
+	return e
+}
+
+func (q *Query) isSingular() bool {
+	for _, s := range q.segments {
+		if s.descendant || len(s.selectors) != 1 {
+			return false
+		}
+		switch s.selectors[0].(type) {
+		case nameSelector, indexSelector:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,843 @@
+// Package jsonschema implements validation of decoded JSON values against JSON Schema.
+// Schemas are used in a decoded form (maps, slices and scalars) without compilation,
+// so the same package is used for standalone schemas and for schemas embedded into OpenAPI documents.
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/muonsoft/api-testing/internal/is"
+	"github.com/muonsoft/api-testing/internal/js"
+)
+
+var ErrInvalidReference = errors.New("invalid reference")
+
+// Dialect defines the set of keywords and their semantics.
+type Dialect int
+
+const (
+	// Draft2020 is JSON Schema draft 2020-12, it is also used by OpenAPI 3.1.
+	Draft2020 Dialect = iota
+	// Draft7 is JSON Schema draft-07.
+	Draft7
+	// OpenAPI30 is a JSON Schema subset used by OpenAPI 3.0 ("nullable", boolean "exclusiveMaximum", etc.).
+	OpenAPI30
+)
+
+// Mode is used to apply "readOnly" and "writeOnly" keywords.
+type Mode int
+
+const (
+	// AnyMode ignores "readOnly" and "writeOnly" keywords.
+	AnyMode Mode = iota
+	// RequestMode does not require "readOnly" properties.
+	RequestMode
+	// ResponseMode does not require "writeOnly" properties.
+	ResponseMode
+)
+
+// Violation describes a value that does not match the schema.
+type Violation struct {
+	// Path is a location of the invalid value. Nil value means the root of the document.
+	Path    *js.Path
+	Message string
+}
+
+// String formats violation with the path.
+func (v Violation) String() string {
+	if v.Path == nil {
+		return v.Message
+	}
+
+	return fmt.Sprintf(`at "%s": %s`, v.Path.String(), v.Message)
+}
+
+// Validator is used to validate values against the schemas of the document.
+type Validator struct {
+	dialect  Dialect
+	mode     Mode
+	resolver *Resolver
+}
+
+// NewValidator creates a Validator for the schemas from the root document.
+// References like "#/components/schemas/User" are resolved against the root.
+func NewValidator(root interface{}, dialect Dialect) *Validator {
+	return &Validator{dialect: dialect, resolver: NewResolver(root)}
+}
+
+// NewValidatorWithResolver creates a Validator that uses the resolver to find referenced schemas.
+func NewValidatorWithResolver(resolver *Resolver, dialect Dialect) *Validator {
+	return &Validator{dialect: dialect, resolver: resolver}
+}
+
+// WithMode returns a copy of the validator with the mode.
+func (v *Validator) WithMode(mode Mode) *Validator {
+	c := *v
+	c.mode = mode
+	return &c
+}
+
+// Validate validates the value against the schema located in the root document.
+func (v *Validator) Validate(schema interface{}, value interface{}) []Violation {
+	return v.ValidateAt(v.resolver.Root(), schema, value, nil)
+}
+
+// ValidateAt validates the value against the schema located in the document scope.
+// The path is used as a prefix for violation paths.
+func (v *Validator) ValidateAt(scope *Scope, schema interface{}, value interface{}, path *js.Path) []Violation {
+	e := &evaluation{validator: v}
+	e.validate(scope, schema, value, path)
+	return e.violations
+}
+
+type evaluation struct {
+	validator  *Validator
+	violations []Violation
+	depth      int
+}
+
+const maxDepth = 256
+
+func (e *evaluation) fail(path *js.Path, format string, args ...interface{}) {
+	e.violations = append(e.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (e *evaluation) isValid(scope *Scope, schema interface{}, value interface{}, path *js.Path) bool {
+	_, valid := e.try(scope, schema, value, path)
+	return valid
+}
+
+// try validates the value without reporting violations. It returns the properties and items
+// evaluated by the schema, they are used by "unevaluatedProperties" and "unevaluatedItems".
+func (e *evaluation) try(scope *Scope, schema interface{}, value interface{}, path *js.Path) (*evaluated, bool) {
+	sub := &evaluation{validator: e.validator, depth: e.depth}
+	ev := sub.validate(scope, schema, value, path)
+	return ev, len(sub.violations) == 0
+}
+
+// evaluated holds the properties and items of the value that were evaluated by the schema
+// and its subschemas applied to the same value.
+type evaluated struct {
+	properties map[string]bool
+	// items is a count of leading array items evaluated by "prefixItems"
+	items    int
+	allItems bool
+	// indexes of array items matched by "contains"
+	indexes map[int]bool
+}
+
+func (ev *evaluated) addProperty(name string) {
+	if ev.properties == nil {
+		ev.properties = make(map[string]bool)
+	}
+	ev.properties[name] = true
+}
+
+func (ev *evaluated) addIndex(index int) {
+	if ev.indexes == nil {
+		ev.indexes = make(map[int]bool)
+	}
+	ev.indexes[index] = true
+}
+
+func (ev *evaluated) hasItem(index int) bool {
+	return ev.allItems || index < ev.items || ev.indexes[index]
+}
+
+func (ev *evaluated) merge(other *evaluated) {
+	for name := range other.properties {
+		ev.addProperty(name)
+	}
+	for index := range other.indexes {
+		ev.addIndex(index)
+	}
+	if other.items > ev.items {
+		ev.items = other.items
+	}
+	ev.allItems = ev.allItems || other.allItems
+}
+
+//nolint:cyclop
+func (e *evaluation) validate(scope *Scope, schema interface{}, value interface{}, path *js.Path) *evaluated {
+	ev := &evaluated{}
+	if b, ok := schema.(bool); ok {
+		if !b {
+			e.fail(path, "value is not allowed")
+		}
+		return ev
+	}
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		return ev
+	}
+
+	e.depth++
+	defer func() { e.depth-- }()
+	if e.depth > maxDepth {
+		e.fail(path, "schema is too deep or has circular references")
+		return ev
+	}
+
+	scope = scope.enter(s)
+
+	if ref, ok := s["$ref"].(string); ok {
+		refScope, refSchema, err := e.validator.resolver.Resolve(scope, ref)
+		if err != nil {
+			e.fail(path, "%s", err.Error())
+			return ev
+		}
+		ev.merge(e.validate(refScope, refSchema, value, path))
+		// in OpenAPI 3.0 and draft-07 sibling keywords of "$ref" are ignored
+		if e.validator.dialect != Draft2020 {
+			return ev
+		}
+	}
+	if ref, ok := s["$dynamicRef"].(string); ok && e.validator.dialect == Draft2020 {
+		refScope, refSchema, err := e.validator.resolver.Resolve(scope, ref)
+		if err != nil {
+			e.fail(path, "%s", err.Error())
+			return ev
+		}
+		ev.merge(e.validate(refScope, refSchema, value, path))
+	}
+
+	if value == nil && e.validator.dialect == OpenAPI30 && s["nullable"] == true {
+		return ev
+	}
+
+	if !e.validateType(s, value, path) {
+		return ev
+	}
+	e.validateGeneric(s, value, path)
+
+	switch v := value.(type) {
+	case string:
+		e.validateString(s, v, path)
+	case []interface{}:
+		e.validateArray(scope, s, v, path, ev)
+	case map[string]interface{}:
+		e.validateObject(scope, s, v, path, ev)
+	default:
+		if n, ok := toRat(value); ok {
+			e.validateNumber(s, n, path)
+		}
+	}
+
+	e.validateComposition(scope, s, value, path, ev)
+
+	// "unevaluatedProperties" and "unevaluatedItems" are applied after all other keywords,
+	// they see annotations of the adjacent keywords and of the successfully applied subschemas
+	if e.validator.dialect == Draft2020 {
+		switch v := value.(type) {
+		case []interface{}:
+			e.validateUnevaluatedItems(scope, s, v, path, ev)
+		case map[string]interface{}:
+			e.validateUnevaluatedProperties(scope, s, v, path, ev)
+		}
+	}
+
+	return ev
+}
+
+func (e *evaluation) validateType(s map[string]interface{}, value interface{}, path *js.Path) bool {
+	var types []string
+	switch t := s["type"].(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, tt := range t {
+			if ts, ok := tt.(string); ok {
+				types = append(types, ts)
+			}
+		}
+	default:
+		return true
+	}
+
+	actual := typeOf(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+
+	e.fail(path, `must be of type %s, actual is %s`, strings.Join(types, " or "), actual)
+
+	return false
+}
+
+func (e *evaluation) validateGeneric(s map[string]interface{}, value interface{}, path *js.Path) {
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, option := range enum {
+			if Equal(option, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			e.fail(path, `must be one of %s, actual is %s`, formatValues(enum), formatValue(value))
+		}
+	}
+	if expected, ok := s["const"]; ok && e.validator.dialect != OpenAPI30 {
+		if !Equal(expected, value) {
+			e.fail(path, `must be equal to %s, actual is %s`, formatValue(expected), formatValue(value))
+		}
+	}
+}
+
+//nolint:cyclop
+func (e *evaluation) validateNumber(s map[string]interface{}, n *big.Rat, path *js.Path) {
+	if m, ok := toRat(s["multipleOf"]); ok && m.Sign() > 0 {
+		if !new(big.Rat).Quo(n, m).IsInt() {
+			e.fail(path, `must be multiple of %s, actual is %s`, formatRat(m), formatRat(n))
+		}
+	}
+
+	exclusiveMaximum := false
+	exclusiveMinimum := false
+	if e.validator.dialect == OpenAPI30 {
+		exclusiveMaximum = s["exclusiveMaximum"] == true
+		exclusiveMinimum = s["exclusiveMinimum"] == true
+	} else {
+		if limit, ok := toRat(s["exclusiveMaximum"]); ok && n.Cmp(limit) >= 0 {
+			e.fail(path, `must be less than %s, actual is %s`, formatRat(limit), formatRat(n))
+		}
+		if limit, ok := toRat(s["exclusiveMinimum"]); ok && n.Cmp(limit) <= 0 {
+			e.fail(path, `must be greater than %s, actual is %s`, formatRat(limit), formatRat(n))
+		}
+	}
+	if limit, ok := toRat(s["maximum"]); ok {
+		if exclusiveMaximum && n.Cmp(limit) >= 0 {
+			e.fail(path, `must be less than %s, actual is %s`, formatRat(limit), formatRat(n))
+		} else if n.Cmp(limit) > 0 {
+			e.fail(path, `must be less than or equal to %s, actual is %s`, formatRat(limit), formatRat(n))
+		}
+	}
+	if limit, ok := toRat(s["minimum"]); ok {
+		if exclusiveMinimum && n.Cmp(limit) <= 0 {
+			e.fail(path, `must be greater than %s, actual is %s`, formatRat(limit), formatRat(n))
+		} else if n.Cmp(limit) < 0 {
+			e.fail(path, `must be greater than or equal to %s, actual is %s`, formatRat(limit), formatRat(n))
+		}
+	}
+
+	if format, ok := s["format"].(string); ok {
+		e.validateNumberFormat(format, n, path)
+	}
+}
+
+func (e *evaluation) validateNumberFormat(format string, n *big.Rat, path *js.Path) {
+	var limit int
+	switch format {
+	case "int32":
+		limit = 32
+	case "int64":
+		limit = 64
+	default:
+		return
+	}
+	if !n.IsInt() {
+		e.fail(path, `must be integer of format "%s", actual is %s`, format, formatRat(n))
+		return
+	}
+	upper := new(big.Int).Lsh(big.NewInt(1), uint(limit-1))
+	lower := new(big.Int).Neg(upper)
+	if n.Num().Cmp(lower) < 0 || n.Num().Cmp(upper) >= 0 {
+		e.fail(path, `must be integer of format "%s", actual is %s`, format, formatRat(n))
+	}
+}
+
+func (e *evaluation) validateString(s map[string]interface{}, value string, path *js.Path) {
+	length := utf8.RuneCountInString(value)
+	if limit, ok := toInt(s["maxLength"]); ok && length > limit {
+		e.fail(path, `must be string with length less than or equal to %d, actual is %d`, limit, length)
+	}
+	if limit, ok := toInt(s["minLength"]); ok && length < limit {
+		e.fail(path, `must be string with length greater than or equal to %d, actual is %d`, limit, length)
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			e.fail(path, `schema has invalid pattern "%s": %s`, pattern, err.Error())
+		} else if !re.MatchString(value) {
+			e.fail(path, `must match pattern "%s", actual is "%s"`, pattern, value)
+		}
+	}
+	if format, ok := s["format"].(string); ok {
+		if err := checkStringFormat(format, value); err != nil {
+			e.fail(path, `must be string of format "%s", actual is "%s"`, format, value)
+		}
+	}
+}
+
+//nolint:cyclop,gocognit
+func (e *evaluation) validateArray(scope *Scope, s map[string]interface{}, value []interface{}, path *js.Path, ev *evaluated) {
+	if limit, ok := toInt(s["maxItems"]); ok && len(value) > limit {
+		e.fail(path, `must be array with length less than or equal to %d, actual is %d`, limit, len(value))
+	}
+	if limit, ok := toInt(s["minItems"]); ok && len(value) < limit {
+		e.fail(path, `must be array with length greater than or equal to %d, actual is %d`, limit, len(value))
+	}
+	if s["uniqueItems"] == true {
+		for i := 0; i < len(value); i++ {
+			for j := i + 1; j < len(value); j++ {
+				if Equal(value[i], value[j]) {
+					e.fail(path, `must be array with unique elements, elements %d and %d are equal`, i, j)
+				}
+			}
+		}
+	}
+
+	// tuple validation: "prefixItems" in draft 2020-12, array form of "items" in previous drafts
+	prefix, _ := s["prefixItems"].([]interface{})
+	items := s["items"]
+	additional := interface{}(nil)
+	if e.validator.dialect != Draft2020 {
+		prefix = nil
+		if tuple, ok := s["items"].([]interface{}); ok {
+			prefix = tuple
+			items = nil
+			additional = s["additionalItems"]
+		}
+	}
+	for i, schema := range prefix {
+		if i < len(value) {
+			e.validate(scope, schema, value[i], path.WithIndex(i))
+			ev.items = i + 1
+		}
+	}
+	rest := items
+	if rest == nil {
+		rest = additional
+	}
+	if rest != nil {
+		for i := len(prefix); i < len(value); i++ {
+			e.validate(scope, rest, value[i], path.WithIndex(i))
+		}
+		ev.allItems = true
+	}
+
+	if contains, ok := s["contains"]; ok {
+		count := 0
+		for i, element := range value {
+			if e.isValid(scope, contains, element, path.WithIndex(i)) {
+				count++
+				ev.addIndex(i)
+			}
+		}
+		minContains, hasMin := toInt(s["minContains"])
+		if !hasMin || e.validator.dialect != Draft2020 {
+			minContains = 1
+		}
+		if count < minContains {
+			e.fail(path, `must contain at least %d matching elements, actual is %d`, minContains, count)
+		}
+		if maxContains, ok := toInt(s["maxContains"]); ok && e.validator.dialect == Draft2020 && count > maxContains {
+			e.fail(path, `must contain at most %d matching elements, actual is %d`, maxContains, count)
+		}
+	}
+}
+
+//nolint:cyclop,gocognit,funlen
+func (e *evaluation) validateObject(scope *Scope, s map[string]interface{}, value map[string]interface{}, path *js.Path, ev *evaluated) {
+	if limit, ok := toInt(s["maxProperties"]); ok && len(value) > limit {
+		e.fail(path, `must be object with properties count less than or equal to %d, actual is %d`, limit, len(value))
+	}
+	if limit, ok := toInt(s["minProperties"]); ok && len(value) < limit {
+		e.fail(path, `must be object with properties count greater than or equal to %d, actual is %d`, limit, len(value))
+	}
+
+	properties, _ := s["properties"].(map[string]interface{})
+	if required, ok := s["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, ok := r.(string)
+			if !ok {
+				continue
+			}
+			if _, exists := value[name]; exists || e.isSkipped(scope, properties[name]) {
+				continue
+			}
+			e.fail(path, `required property "%s" is missing`, name)
+		}
+	}
+
+	keys := sortedKeys(value)
+	patterns, _ := s["patternProperties"].(map[string]interface{})
+	additional, hasAdditional := s["additionalProperties"]
+	for _, key := range keys {
+		evaluated := false
+		if schema, ok := properties[key]; ok {
+			evaluated = true
+			e.validate(scope, schema, value[key], path.WithProperty(key))
+		}
+		for pattern, schema := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				e.fail(path, `schema has invalid pattern "%s": %s`, pattern, err.Error())
+				continue
+			}
+			if re.MatchString(key) {
+				evaluated = true
+				e.validate(scope, schema, value[key], path.WithProperty(key))
+			}
+		}
+		if !evaluated && hasAdditional {
+			evaluated = true
+			if additional == false {
+				e.fail(path, `property "%s" is not allowed`, key)
+			} else {
+				e.validate(scope, additional, value[key], path.WithProperty(key))
+			}
+		}
+		if evaluated {
+			ev.addProperty(key)
+		}
+	}
+
+	if names, ok := s["propertyNames"]; ok {
+		for _, key := range keys {
+			for _, violation := range e.validator.ValidateAt(scope, names, key, nil) {
+				e.fail(path, `property name "%s" is invalid: %s`, key, violation.Message)
+			}
+		}
+	}
+
+	dependentRequired, _ := s["dependentRequired"].(map[string]interface{})
+	dependentSchemas, _ := s["dependentSchemas"].(map[string]interface{})
+	if e.validator.dialect == Draft7 {
+		dependentRequired = make(map[string]interface{})
+		dependentSchemas = make(map[string]interface{})
+		dependencies, _ := s["dependencies"].(map[string]interface{})
+		for key, dependency := range dependencies {
+			if _, ok := dependency.([]interface{}); ok {
+				dependentRequired[key] = dependency
+			} else {
+				dependentSchemas[key] = dependency
+			}
+		}
+	}
+	for _, key := range sortedKeys(dependentRequired) {
+		if _, exists := value[key]; !exists {
+			continue
+		}
+		required, _ := dependentRequired[key].([]interface{})
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, exists := value[name]; !exists {
+					e.fail(path, `property "%s" is required when property "%s" is present`, name, key)
+				}
+			}
+		}
+	}
+	for _, key := range sortedKeys(dependentSchemas) {
+		if _, exists := value[key]; exists {
+			ev.merge(e.validate(scope, dependentSchemas[key], value, path))
+		}
+	}
+}
+
+func (e *evaluation) validateUnevaluatedProperties(
+	scope *Scope, s map[string]interface{}, value map[string]interface{}, path *js.Path, ev *evaluated,
+) {
+	schema, ok := s["unevaluatedProperties"]
+	if !ok {
+		return
+	}
+	for _, key := range sortedKeys(value) {
+		if ev.properties[key] {
+			continue
+		}
+		if schema == false {
+			e.fail(path, `property "%s" is not allowed`, key)
+		} else {
+			e.validate(scope, schema, value[key], path.WithProperty(key))
+		}
+		ev.addProperty(key)
+	}
+}
+
+func (e *evaluation) validateUnevaluatedItems(
+	scope *Scope, s map[string]interface{}, value []interface{}, path *js.Path, ev *evaluated,
+) {
+	schema, ok := s["unevaluatedItems"]
+	if !ok {
+		return
+	}
+	for i, element := range value {
+		if !ev.hasItem(i) {
+			e.validate(scope, schema, element, path.WithIndex(i))
+		}
+	}
+	ev.allItems = true
+}
+
+func (e *evaluation) isSkipped(scope *Scope, property interface{}) bool {
+	s, ok := property.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		_, resolved, err := e.validator.resolver.Resolve(scope, ref)
+		if err == nil {
+			if rs, ok := resolved.(map[string]interface{}); ok {
+				s = rs
+			}
+		}
+	}
+
+	switch e.validator.mode {
+	case RequestMode:
+		return s["readOnly"] == true
+	case ResponseMode:
+		return s["writeOnly"] == true
+	}
+
+	return false
+}
+
+//nolint:cyclop
+func (e *evaluation) validateComposition(
+	scope *Scope, s map[string]interface{}, value interface{}, path *js.Path, ev *evaluated,
+) {
+	if schemas, ok := s["allOf"].([]interface{}); ok {
+		for _, schema := range schemas {
+			ev.merge(e.validate(scope, schema, value, path))
+		}
+	}
+	if schemas, ok := s["anyOf"].([]interface{}); ok {
+		// all subschemas are evaluated to collect annotations of every matching one
+		matched := false
+		for _, schema := range schemas {
+			if sub, valid := e.try(scope, schema, value, path); valid {
+				matched = true
+				ev.merge(sub)
+			}
+		}
+		if !matched {
+			e.fail(path, `must match at least one schema of "anyOf"`)
+		}
+	}
+	if schemas, ok := s["oneOf"].([]interface{}); ok {
+		count := 0
+		for _, schema := range schemas {
+			if sub, valid := e.try(scope, schema, value, path); valid {
+				count++
+				ev.merge(sub)
+			}
+		}
+		if count != 1 {
+			e.fail(path, `must match exactly one schema of "oneOf", actual count is %d`, count)
+		}
+	}
+	if schema, ok := s["not"]; ok {
+		if e.isValid(scope, schema, value, path) {
+			e.fail(path, `must not match the schema of "not"`)
+		}
+	}
+	if condition, ok := s["if"]; ok && e.validator.dialect != OpenAPI30 {
+		if sub, valid := e.try(scope, condition, value, path); valid {
+			ev.merge(sub)
+			if then, ok := s["then"]; ok {
+				ev.merge(e.validate(scope, then, value, path))
+			}
+		} else if otherwise, ok := s["else"]; ok {
+			ev.merge(e.validate(scope, otherwise, value, path))
+		}
+	}
+}
+
+// Equal compares decoded JSON values. Numbers are compared by value, so 1 and 1.0 are equal.
+func Equal(a, b interface{}) bool {
+	if na, ok := toRat(a); ok {
+		nb, ok := toRat(b)
+		return ok && na.Cmp(nb) == 0
+	}
+	switch va := a.(type) {
+	case []interface{}:
+		vb, ok := b.([]interface{})
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for i := range va {
+			if !Equal(va[i], vb[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		vb, ok := b.(map[string]interface{})
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for key, value := range va {
+			other, exists := vb[key]
+			if !exists || !Equal(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		if n, ok := toRat(v); ok {
+			if n.IsInt() {
+				return "integer"
+			}
+			return "number"
+		}
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func toRat(value interface{}) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(v), true
+	case float32:
+		return new(big.Rat).SetFloat64(float64(v)), true
+	case int:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case int64:
+		return new(big.Rat).SetInt64(v), true
+	case json.Number:
+		return new(big.Rat).SetString(v.String())
+	}
+
+	return nil, false
+}
+
+func toInt(value interface{}) (int, bool) {
+	n, ok := toRat(value)
+	if !ok || !n.IsInt() || !n.Num().IsInt64() {
+		return 0, false
+	}
+
+	return int(n.Num().Int64()), true
+}
+
+func formatRat(n *big.Rat) string {
+	if n.IsInt() {
+		return n.Num().String()
+	}
+	f, _ := n.Float64()
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+func formatValues(values []interface{}) string {
+	s := make([]string, len(values))
+	for i, value := range values {
+		s[i] = formatValue(value)
+	}
+
+	return strings.Join(s, ", ")
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+var errInvalidFormat = errors.New("invalid format")
+
+//nolint:cyclop
+func checkStringFormat(format, value string) error {
+	var err error
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339Nano, value)
+	case "date":
+		_, err = time.Parse("2006-01-02", value)
+	case "time":
+		_, err = time.Parse("15:04:05Z07:00", value)
+		if err != nil {
+			_, err = time.Parse("15:04:05.999999999Z07:00", value)
+		}
+	case "email":
+		if !is.Email(value) {
+			err = errInvalidFormat
+		}
+	case "uuid":
+		_, err = uuid.FromString(value)
+	case "uri", "url":
+		var u *url.URL
+		u, err = url.Parse(value)
+		if err == nil && !u.IsAbs() {
+			err = errInvalidFormat
+		}
+	case "uri-reference":
+		_, err = url.Parse(value)
+	case "ipv4":
+		if !isIP(value, 4) {
+			err = errInvalidFormat
+		}
+	case "ipv6":
+		if !isIP(value, 6) {
+			err = errInvalidFormat
+		}
+	case "regex":
+		_, err = regexp.Compile(value)
+	}
+
+	return err
+}
+
+func isIP(value string, version int) bool {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return false
+	}
+	if version == 4 {
+		return ip.To4() != nil && !strings.Contains(value, ":")
+	}
+
+	return strings.Contains(value, ":")
+}
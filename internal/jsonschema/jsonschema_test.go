@@ -0,0 +1,164 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/muonsoft/api-testing/internal/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		dialect        jsonschema.Dialect
+		schema         string
+		value          string
+		wantViolations []string
+	}{
+		{
+			name:    "valid object",
+			dialect: jsonschema.Draft2020,
+			schema:  `{"type":"object","properties":{"id":{"type":"integer"}},"required":["id"]}`,
+			value:   `{"id":1}`,
+		},
+		{
+			name:           "type mismatch",
+			dialect:        jsonschema.Draft2020,
+			schema:         `{"type":["string","null"]}`,
+			value:          `1.5`,
+			wantViolations: []string{`must be of type string or null, actual is number`},
+		},
+		{
+			name:    "nested path",
+			dialect: jsonschema.Draft2020,
+			schema:  `{"properties":{"items":{"items":{"properties":{"id":{"minimum":1}}}}}}`,
+			value:   `{"items":[{"id":1},{"id":0}]}`,
+			wantViolations: []string{
+				`at "items[1].id": must be greater than or equal to 1, actual is 0`,
+			},
+		},
+		{
+			name:           "nullable in OpenAPI 3.0",
+			dialect:        jsonschema.OpenAPI30,
+			schema:         `{"type":"string","nullable":true}`,
+			value:          `null`,
+			wantViolations: nil,
+		},
+		{
+			name:           "boolean exclusive maximum in OpenAPI 3.0",
+			dialect:        jsonschema.OpenAPI30,
+			schema:         `{"maximum":10,"exclusiveMaximum":true}`,
+			value:          `10`,
+			wantViolations: []string{`must be less than 10, actual is 10`},
+		},
+		{
+			name:           "numeric exclusive maximum",
+			dialect:        jsonschema.Draft2020,
+			schema:         `{"exclusiveMaximum":10}`,
+			value:          `10`,
+			wantViolations: []string{`must be less than 10, actual is 10`},
+		},
+		{
+			name:           "local reference",
+			dialect:        jsonschema.Draft2020,
+			schema:         `{"$defs":{"id":{"type":"integer"}},"items":{"$ref":"#/$defs/id"}}`,
+			value:          `[1,"2"]`,
+			wantViolations: []string{`at "[1]": must be of type integer, actual is string`},
+		},
+		{
+			name:           "one of",
+			dialect:        jsonschema.Draft2020,
+			schema:         `{"oneOf":[{"type":"integer"},{"minimum":0}]}`,
+			value:          `1`,
+			wantViolations: []string{`must match exactly one schema of "oneOf", actual count is 2`},
+		},
+		{
+			name:           "enum",
+			dialect:        jsonschema.Draft2020,
+			schema:         `{"enum":["a",1]}`,
+			value:          `1.0`,
+			wantViolations: nil,
+		},
+		{
+			name:           "tuple in draft-07",
+			dialect:        jsonschema.Draft7,
+			schema:         `{"items":[{"type":"string"}],"additionalItems":false}`,
+			value:          `["a","b"]`,
+			wantViolations: []string{`at "[1]": value is not allowed`},
+		},
+		{
+			name:    "unevaluated properties",
+			dialect: jsonschema.Draft2020,
+			schema: `{
+				"allOf":[{"properties":{"id":{"type":"integer"}}}],
+				"anyOf":[{"properties":{"name":{}},"required":["name"]},{"required":["title"]}],
+				"if":{"required":["kind"]},"then":{"properties":{"kind":{}}},
+				"unevaluatedProperties":false
+			}`,
+			value: `{"id":1,"name":"a","kind":"b","title":"c","extra":true}`,
+			wantViolations: []string{
+				`property "extra" is not allowed`,
+				`property "title" is not allowed`,
+			},
+		},
+		{
+			name:    "unevaluated properties of failed subschema",
+			dialect: jsonschema.Draft2020,
+			schema: `{
+				"oneOf":[{"properties":{"id":{"type":"string"}}},{"required":["name"]}],
+				"unevaluatedProperties":{"type":"string"}
+			}`,
+			value:          `{"id":1,"name":"a"}`,
+			wantViolations: []string{`at "id": must be of type string, actual is integer`},
+		},
+		{
+			name:    "unevaluated properties through reference",
+			dialect: jsonschema.Draft2020,
+			schema: `{
+				"$defs":{"base":{"properties":{"id":{}}}},
+				"$ref":"#/$defs/base",
+				"properties":{"name":{}},
+				"unevaluatedProperties":false
+			}`,
+			value:          `{"id":1,"name":"a","extra":true}`,
+			wantViolations: []string{`property "extra" is not allowed`},
+		},
+		{
+			name:    "unevaluated items",
+			dialect: jsonschema.Draft2020,
+			schema: `{
+				"prefixItems":[{"type":"string"}],
+				"contains":{"type":"integer"},
+				"unevaluatedItems":false
+			}`,
+			value:          `["a",1,true,2]`,
+			wantViolations: []string{`at "[2]": value is not allowed`},
+		},
+		{
+			name:    "unevaluated items in subschemas",
+			dialect: jsonschema.Draft2020,
+			schema: `{
+				"allOf":[{"prefixItems":[{"type":"string"}]},{"contains":{"type":"integer"}}],
+				"unevaluatedItems":false
+			}`,
+			value: `["a",1,2]`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var schema, value interface{}
+			require.NoError(t, json.Unmarshal([]byte(test.schema), &schema))
+			require.NoError(t, json.Unmarshal([]byte(test.value), &value))
+
+			violations := jsonschema.NewValidator(schema, test.dialect).Validate(schema, value)
+
+			var messages []string
+			for _, violation := range violations {
+				messages = append(messages, violation.String())
+			}
+			assert.Equal(t, test.wantViolations, messages)
+		})
+	}
+}
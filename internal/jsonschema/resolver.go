@@ -0,0 +1,247 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Scope is a schema resource (a document or a subschema with "$id") that contains
+// the schema being evaluated. References are resolved relative to the scope.
+type Scope struct {
+	// base is an URI used to resolve relative references, it is set by "$id"
+	base *url.URL
+	// location is an URI of the file the resource was loaded from
+	location *url.URL
+	document interface{}
+}
+
+// Resolver is used to resolve references to the schemas. It loads documents
+// referenced by relative file paths or "file://" URIs on demand.
+type Resolver struct {
+	root      *Scope
+	resources map[string]*Scope
+	anchors   map[string]interface{}
+}
+
+// NewResolver creates a Resolver for the root document without base URI.
+func NewResolver(root interface{}) *Resolver {
+	r := &Resolver{
+		resources: make(map[string]*Scope),
+		anchors:   make(map[string]interface{}),
+	}
+	r.root = r.register(&url.URL{}, nil, root)
+
+	return r
+}
+
+// NewFileResolver creates a Resolver for the document loaded from the file.
+// References to other files are resolved relative to the directory of the file.
+func NewFileResolver(filename string, root interface{}) (*Resolver, error) {
+	location, err := fileURI(filename)
+	if err != nil {
+		return nil, err
+	}
+	r := &Resolver{
+		resources: make(map[string]*Scope),
+		anchors:   make(map[string]interface{}),
+	}
+	r.root = r.register(location, location, root)
+
+	return r, nil
+}
+
+// Root returns scope of the root document.
+func (r *Resolver) Root() *Scope {
+	return r.root
+}
+
+// Resolve finds the schema by the reference. The reference can point to the same document
+// (JSON Pointer like "#/$defs/User" or anchor like "#user"), to the schema with "$id"
+// or to another local file (like "definitions.json#/User").
+func (r *Resolver) Resolve(scope *Scope, ref string) (*Scope, interface{}, error) {
+	target, err := scope.base.Parse(ref)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`%w "%s": %s`, ErrInvalidReference, ref, err.Error())
+	}
+	fragment := target.EscapedFragment()
+	target.Fragment = ""
+	target.RawFragment = ""
+
+	resource, err := r.find(scope, ref, target)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`%w "%s": %s`, ErrInvalidReference, ref, err.Error())
+	}
+
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		schema, ok := r.anchors[resource.base.String()+"#"+fragment]
+		if !ok {
+			return nil, nil, fmt.Errorf(`%w "%s": anchor "%s" does not exist`, ErrInvalidReference, ref, fragment)
+		}
+		return resource, schema, nil
+	}
+	schema, err := Pointer(resource.document, fragment)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`%w "%s": %s`, ErrInvalidReference, ref, err.Error())
+	}
+
+	return resource, schema, nil
+}
+
+func (r *Resolver) find(scope *Scope, ref string, target *url.URL) (*Scope, error) {
+	if resource, ok := r.resources[target.String()]; ok {
+		return resource, nil
+	}
+	if target.Scheme == "file" {
+		return r.load(target)
+	}
+	// schemas with "$id" like "https://example.com/user.json" usually reference
+	// sibling files, so they are looked up relative to the file location
+	if scope.location != nil {
+		location, err := scope.location.Parse(ref)
+		if err != nil {
+			return nil, err
+		}
+		location.Fragment = ""
+		location.RawFragment = ""
+		if resource, ok := r.resources[location.String()]; ok {
+			return resource, nil
+		}
+		return r.load(location)
+	}
+
+	return nil, fmt.Errorf(`resource "%s" is not found`, target.String())
+}
+
+func (r *Resolver) load(location *url.URL) (*Scope, error) {
+	if location.Scheme != "file" {
+		return nil, fmt.Errorf(`loading of "%s" is not supported, only local files are allowed`, location.String())
+	}
+	data, err := os.ReadFile(filepath.FromSlash(location.Path))
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf(`file "%s" has invalid JSON: %w`, location.Path, err)
+	}
+
+	return r.register(location, location, document), nil
+}
+
+// register indexes the document by its URI, embedded resources with "$id" and anchors.
+func (r *Resolver) register(base, location *url.URL, document interface{}) *Scope {
+	scope := &Scope{base: base, location: location, document: document}
+	if object, ok := document.(map[string]interface{}); ok {
+		scope = scope.enter(object)
+	}
+	// resource is available both by retrieval URI and by "$id"
+	r.resources[base.String()] = scope
+	r.resources[scope.base.String()] = scope
+	r.index(scope, document, true)
+
+	return scope
+}
+
+func (r *Resolver) index(scope *Scope, value interface{}, isRoot bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if !isRoot {
+			if id, ok := v["$id"].(string); ok && !strings.HasPrefix(id, "#") {
+				scope = scope.enter(v)
+				r.resources[scope.base.String()] = scope
+			}
+		}
+		if anchor, ok := v["$anchor"].(string); ok {
+			r.anchors[scope.base.String()+"#"+anchor] = v
+		}
+		if anchor, ok := v["$dynamicAnchor"].(string); ok {
+			r.anchors[scope.base.String()+"#"+anchor] = v
+		}
+		// in draft-07 anchors are defined by "$id" with a fragment
+		if id, ok := v["$id"].(string); ok && strings.HasPrefix(id, "#") {
+			r.anchors[scope.base.String()+id] = v
+		}
+		for key, element := range v {
+			// values of these keywords are not schemas
+			if key == "enum" || key == "const" || key == "examples" || key == "default" {
+				continue
+			}
+			r.index(scope, element, false)
+		}
+	case []interface{}:
+		for _, element := range v {
+			r.index(scope, element, false)
+		}
+	}
+}
+
+// enter returns the scope of the schema: if the schema has "$id" it is a new resource
+// with its own base URI, otherwise it is the current scope.
+func (s *Scope) enter(schema map[string]interface{}) *Scope {
+	id, ok := schema["$id"].(string)
+	if !ok || id == "" || strings.HasPrefix(id, "#") {
+		return s
+	}
+	base, err := s.base.Parse(id)
+	if err != nil {
+		return s
+	}
+	base.Fragment = ""
+	base.RawFragment = ""
+	if base.String() == s.base.String() {
+		return s
+	}
+
+	return &Scope{base: base, location: s.location, document: schema}
+}
+
+// Pointer returns the value of the document located by the JSON Pointer (RFC 6901).
+// The pointer may be percent-encoded as a URI fragment.
+func Pointer(document interface{}, pointer string) (interface{}, error) {
+	if decoded, err := url.PathUnescape(pointer); err == nil {
+		pointer = decoded
+	}
+	if pointer == "" {
+		return document, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf(`invalid JSON pointer "%s"`, pointer)
+	}
+
+	value := document
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch v := value.(type) {
+		case map[string]interface{}:
+			element, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf(`property "%s" does not exist`, token)
+			}
+			value = element
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, fmt.Errorf(`index "%s" is out of range`, token)
+			}
+			value = v[index]
+		default:
+			return nil, fmt.Errorf(`cannot resolve "%s" in scalar value`, token)
+		}
+	}
+
+	return value, nil
+}
+
+func fileURI(filename string) (*url.URL, error) {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &url.URL{Scheme: "file", Path: filepath.ToSlash(path)}, nil
+}
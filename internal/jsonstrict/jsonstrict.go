@@ -0,0 +1,177 @@
+// Package jsonstrict detects problems of JSON documents that are silently accepted by encoding/json:
+// duplicate keys, invalid UTF-8 and lone UTF-16 surrogates.
+package jsonstrict
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/muonsoft/api-testing/internal/js"
+)
+
+// Violation describes a problem of the JSON document.
+type Violation struct {
+	// Path is a path to the invalid value or to the duplicated key.
+	Path    *js.Path
+	Message string
+}
+
+// String formats violation with its path.
+func (v Violation) String() string {
+	if v.Path == nil {
+		return v.Message
+	}
+	return fmt.Sprintf(`%s at "%s"`, v.Message, v.Path.String())
+}
+
+// Check returns violations of the syntactically valid JSON document. Syntax errors
+// and trailing data should be detected by the decoder before calling Check.
+func Check(data []byte) []Violation {
+	if !json.Valid(data) {
+		return nil
+	}
+	s := &scanner{data: data}
+	s.value(nil)
+
+	return s.violations
+}
+
+type scanner struct {
+	data       []byte
+	pos        int
+	violations []Violation
+}
+
+func (s *scanner) value(path *js.Path) {
+	s.skipSpace()
+	switch s.data[s.pos] {
+	case '{':
+		s.object(path)
+	case '[':
+		s.array(path)
+	case '"':
+		s.string(path)
+	default:
+		for s.pos < len(s.data) && !isDelimiter(s.data[s.pos]) {
+			s.pos++
+		}
+	}
+}
+
+func (s *scanner) object(path *js.Path) {
+	s.pos++
+	keys := make(map[string]bool)
+	s.skipSpace()
+	if s.data[s.pos] == '}' {
+		s.pos++
+		return
+	}
+	for {
+		s.skipSpace()
+		key := s.string(path)
+		if keys[key] {
+			s.violations = append(s.violations, Violation{Path: path.WithProperty(key), Message: "duplicate key"})
+		}
+		keys[key] = true
+		s.skipSpace()
+		s.pos++ // colon
+		s.value(path.WithProperty(key))
+		s.skipSpace()
+		if s.data[s.pos] == '}' {
+			s.pos++
+			return
+		}
+		s.pos++ // comma
+	}
+}
+
+func (s *scanner) array(path *js.Path) {
+	s.pos++
+	s.skipSpace()
+	if s.data[s.pos] == ']' {
+		s.pos++
+		return
+	}
+	for i := 0; ; i++ {
+		s.value(path.WithIndex(i))
+		s.skipSpace()
+		if s.data[s.pos] == ']' {
+			s.pos++
+			return
+		}
+		s.pos++ // comma
+	}
+}
+
+// string scans the string and returns its decoded value.
+func (s *scanner) string(path *js.Path) string {
+	start := s.pos
+	s.pos++
+	invalidUTF8 := false
+	for s.data[s.pos] != '"' {
+		c := s.data[s.pos]
+		switch {
+		case c == '\\' && s.data[s.pos+1] == 'u':
+			s.escape(path)
+		case c == '\\':
+			s.pos += 2
+		case c < utf8.RuneSelf:
+			s.pos++
+		default:
+			r, size := utf8.DecodeRune(s.data[s.pos:])
+			if r == utf8.RuneError && size == 1 && !invalidUTF8 {
+				invalidUTF8 = true
+				s.violations = append(s.violations, Violation{Path: path, Message: "invalid UTF-8 in string"})
+			}
+			s.pos += size
+		}
+	}
+	s.pos++
+
+	var value string
+	_ = json.Unmarshal(s.data[start:s.pos], &value)
+
+	return value
+}
+
+// escape scans "\uXXXX" sequence and checks that surrogates are paired.
+func (s *scanner) escape(path *js.Path) {
+	r := s.hex()
+	if !utf16.IsSurrogate(r) {
+		return
+	}
+	if r < 0xDC00 && s.pos+1 < len(s.data) && s.data[s.pos] == '\\' && s.data[s.pos+1] == 'u' {
+		start := s.pos
+		if low := s.hex(); utf16.DecodeRune(r, low) != utf8.RuneError {
+			return
+		}
+		s.pos = start
+	}
+	s.violations = append(s.violations, Violation{
+		Path:    path,
+		Message: fmt.Sprintf(`lone surrogate "\u%04x" in string`, r),
+	})
+}
+
+func (s *scanner) hex() rune {
+	n, _ := strconv.ParseUint(string(s.data[s.pos+2:s.pos+6]), 16, 32)
+	s.pos += 6
+	return rune(n)
+}
+
+func (s *scanner) skipSpace() {
+	for s.pos < len(s.data) && isSpace(s.data[s.pos]) {
+		s.pos++
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDelimiter(c byte) bool {
+	return isSpace(c) || c == ',' || c == ']' || c == '}'
+}
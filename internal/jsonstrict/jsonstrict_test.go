@@ -0,0 +1,68 @@
+package jsonstrict_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/internal/jsonstrict"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "valid document",
+			data: `{"a": [1, "😀", {"b": null}], "c": "\"\\", "d": true, "é": -1.5e3}`,
+		},
+		{
+			name: "duplicate keys",
+			data: `{"id": 1, "items": [{"id": 1, "id": 2}], "id": 3, "id": 4}`,
+			want: []string{
+				`duplicate key at "items[0].id"`,
+				`duplicate key at "id"`,
+				`duplicate key at "id"`,
+			},
+		},
+		{
+			name: "invalid UTF-8",
+			data: "{\"name\": \"a\xffb\xfe\", \"items\": [\"\xc3\"]}",
+			want: []string{
+				`invalid UTF-8 in string at "name"`,
+				`invalid UTF-8 in string at "items[0]"`,
+			},
+		},
+		{
+			name: "lone surrogates",
+			data: `["\ud83d", "\ude00x", "\ud83dA", "\ud83d😀"]`,
+			want: []string{
+				`lone surrogate "\ud83d" in string at "[0]"`,
+				`lone surrogate "\ude00" in string at "[1]"`,
+				`lone surrogate "\ud83d" in string at "[2]"`,
+				`lone surrogate "\ud83d" in string at "[3]"`,
+			},
+		},
+		{
+			name: "root string",
+			data: `"\udfff"`,
+			want: []string{`lone surrogate "\udfff" in string`},
+		},
+		{
+			name: "invalid syntax is skipped",
+			data: `{"a": 1, "a": 2`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations := jsonstrict.Check([]byte(test.data))
+
+			var got []string
+			for _, violation := range violations {
+				got = append(got, violation.String())
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
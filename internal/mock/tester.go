@@ -2,12 +2,14 @@ package mock
 
 import (
 	"fmt"
+	"runtime"
 	"strings"
 	"testing"
 )
 
 type Tester struct {
 	messages []string
+	stopped  bool
 }
 
 func (tester *Tester) Helper() {}
@@ -24,6 +26,27 @@ func (tester *Tester) Fatal(args ...interface{}) {
 	tester.messages = append(tester.messages, fmt.Sprint(args...))
 }
 
+// FailNow stops the goroutine, so assertions calling it should be run by the Run method.
+func (tester *Tester) FailNow() {
+	tester.stopped = true
+	runtime.Goexit()
+}
+
+// Run runs the function in a separate goroutine that can be stopped by FailNow.
+func (tester *Tester) Run(f func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	<-done
+}
+
+// Stopped reports whether FailNow was called.
+func (tester *Tester) Stopped() bool {
+	return tester.stopped
+}
+
 func (tester *Tester) Log(args ...interface{}) {
 	tester.messages = append(tester.messages, fmt.Sprint(args...))
 }
@@ -0,0 +1,145 @@
+// Package snapshot contains common functions for golden-file testing.
+// Snapshot files are stored under "testdata" directory of the tested package.
+// To rewrite stored files run tests with UPDATE_SNAPSHOTS=1 environment variable
+// or with "-update" flag, if it is defined by the test binary.
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/muonsoft/api-testing/internal/js"
+	"github.com/muonsoft/api-testing/internal/jsondiff"
+)
+
+// Directory is a directory where snapshot files are stored.
+const Directory = "testdata"
+
+// UpdateEnv is an environment variable used to rewrite stored snapshot files.
+const UpdateEnv = "UPDATE_SNAPSHOTS"
+
+var ErrNotExist = errors.New("snapshot does not exist")
+
+// Mask is used to replace volatile value at the path. It returns replacement and true if the value is masked.
+type Mask func(path *js.Path, value interface{}) (interface{}, bool)
+
+// Options holds up settings of the snapshot.
+type Options struct {
+	// Headers is a list of HTTP headers stored in the snapshot.
+	Headers []string
+	// Masks is a list of rules to replace volatile values.
+	Masks []Mask
+	// Errors holds errors of invalid options, they are reported before matching the snapshot.
+	Errors []error
+}
+
+// Filename returns path to the snapshot file.
+func Filename(name, extension string) string {
+	return filepath.Join(Directory, filepath.FromSlash(name)+extension)
+}
+
+// IsUpdate returns true if tests are run with UPDATE_SNAPSHOTS environment variable set to true value.
+// The flag is not registered by the package to avoid conflicts with the flags of the test binary,
+// but if the test binary defines boolean "-update" flag, it is respected too.
+func IsUpdate() bool {
+	if value, err := strconv.ParseBool(os.Getenv(UpdateEnv)); err == nil && value {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		if getter, ok := f.Value.(flag.Getter); ok {
+			value, _ := getter.Get().(bool)
+			return value
+		}
+	}
+
+	return false
+}
+
+// Load reads the stored snapshot. It returns ErrNotExist if snapshot file is not found.
+func Load(filename string) ([]byte, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf(`%w: "%s", run tests with %s=1 to create it`, ErrNotExist, filename, UpdateEnv)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(`read snapshot "%s": %w`, filename, err)
+	}
+
+	return data, nil
+}
+
+// Save writes the snapshot file and creates directories if needed.
+func Save(filename string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf(`create snapshot directory: %w`, err)
+	}
+	if err := os.WriteFile(filename, data, 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf(`write snapshot "%s": %w`, filename, err)
+	}
+
+	return nil
+}
+
+// NormalizeJSON applies masks to decoded JSON data and formats it for storing in snapshot.
+// Data is indented with tabs, object keys are sorted.
+func NormalizeJSON(data interface{}, masks []Mask) ([]byte, error) {
+	data = Apply(data, masks)
+
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "\t")
+	if err := encoder.Encode(data); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Apply walks through decoded JSON data and replaces values by masks.
+// The first matching mask wins. Data is modified in place.
+func Apply(data interface{}, masks []Mask) interface{} {
+	if len(masks) == 0 {
+		return data
+	}
+
+	return apply(nil, data, masks)
+}
+
+func apply(path *js.Path, value interface{}, masks []Mask) interface{} {
+	for _, mask := range masks {
+		if masked, ok := mask(path, value); ok {
+			return masked
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, element := range v {
+			v[key] = apply(path.WithProperty(key), element, masks)
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = apply(path.WithIndex(i), element, masks)
+		}
+	}
+
+	return value
+}
+
+// CompareJSON returns structural differences between the stored and the actual JSON snapshots.
+// The second result is false if one of the snapshots is not a valid JSON.
+func CompareJSON(expected, actual []byte) ([]jsondiff.Difference, bool) {
+	var expectedValue, actualValue interface{}
+	if json.Unmarshal(expected, &expectedValue) != nil || json.Unmarshal(actual, &actualValue) != nil {
+		return nil, false
+	}
+
+	return jsondiff.Compare(nil, expectedValue, actualValue), true
+}
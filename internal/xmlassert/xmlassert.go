@@ -0,0 +1,17 @@
+// Package xmlassert gives other packages of the module access to the assertions of assertxml
+// package for the already parsed documents, without exposing internal types in its public API.
+package xmlassert
+
+import "github.com/muonsoft/api-testing/internal/xmldom"
+
+// TestingT is the same interface as assertxml.TestingT.
+type TestingT interface {
+	Helper()
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+	Log(args ...interface{})
+}
+
+// New creates *assertxml.AssertXML for the parsed document. It is set by assertxml package
+// on initialization, so it can be used by any package that imports assertxml.
+var New func(t TestingT, document *xmldom.Node) interface{}
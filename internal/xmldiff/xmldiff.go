@@ -0,0 +1,297 @@
+// Package xmldiff implements structural comparison of XML trees after canonicalization:
+// whitespace-only text nodes are skipped, text is compared without leading and trailing
+// whitespace, attributes are compared regardless of their order and names are compared
+// by namespace URIs, so the choice of namespace prefixes does not matter.
+package xmldiff
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+)
+
+// Kind is a kind of the difference.
+type Kind int
+
+const (
+	// Changed means that the node at the path differs.
+	Changed Kind = iota
+	// Added means that the actual document has a node that does not exist in the expected one.
+	Added
+	// Removed means that the actual document has no node that exists in the expected one.
+	Removed
+)
+
+// String returns name of the difference kind.
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	}
+	return "changed"
+}
+
+// Difference describes one difference between the expected and the actual nodes.
+type Difference struct {
+	Kind Kind
+	// Path is XPath location of the node in the actual document.
+	Path     string
+	Expected string
+	Actual   string
+}
+
+// String formats the difference as a single line.
+func (d Difference) String() string {
+	switch d.Kind {
+	case Added:
+		return fmt.Sprintf(`added "%s": %s`, d.Path, d.Actual)
+	case Removed:
+		return fmt.Sprintf(`removed "%s": %s`, d.Path, d.Expected)
+	}
+
+	return fmt.Sprintf(`changed "%s": %s -> %s`, d.Path, d.Expected, d.Actual)
+}
+
+// Options sets up the comparison.
+type Options struct {
+	IgnoreComments bool
+	// PreserveWhitespace makes whitespace significant: text is compared as is
+	// and whitespace-only text nodes are not skipped.
+	PreserveWhitespace bool
+}
+
+// Compare returns differences between the expected and the actual nodes.
+// Child nodes are compared one by one in the document order.
+func Compare(expected, actual *xmldom.Node, options Options) []Difference {
+	c := &comparator{options: options}
+	c.compare(actual.Path(), expected, actual)
+	return c.differences
+}
+
+// Format formats the differences line by line, each line is indented by the tab.
+func Format(differences []Difference) string {
+	lines := make([]string, len(differences))
+	for i, difference := range differences {
+		lines[i] = "\t" + difference.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+type comparator struct {
+	options     Options
+	differences []Difference
+}
+
+func (c *comparator) add(kind Kind, path string, expected, actual *xmldom.Node) {
+	difference := Difference{Kind: kind, Path: path}
+	if expected != nil {
+		difference.Expected = c.describe(expected)
+	}
+	if actual != nil {
+		difference.Actual = c.describe(actual)
+	}
+	c.differences = append(c.differences, difference)
+}
+
+func (c *comparator) compare(path string, expected, actual *xmldom.Node) {
+	if expected.Type != actual.Type || expected.Name != actual.Name {
+		c.add(Changed, path, expected, actual)
+		return
+	}
+	switch expected.Type {
+	case xmldom.DocumentNode:
+		c.compareChildren(path, expected, actual)
+	case xmldom.ElementNode:
+		c.compareAttributes(path, expected, actual)
+		c.compareChildren(path, expected, actual)
+	case xmldom.TextNode:
+		if c.text(expected) != c.text(actual) {
+			c.add(Changed, path, expected, actual)
+		}
+	default:
+		if expected.Data != actual.Data {
+			c.add(Changed, path, expected, actual)
+		}
+	}
+}
+
+func (c *comparator) compareAttributes(path string, expected, actual *xmldom.Node) {
+	expectedAttributes := attributesByName(expected)
+	actualAttributes := attributesByName(actual)
+	names := make([]xml.Name, 0, len(expectedAttributes)+len(actualAttributes))
+	for name := range expectedAttributes {
+		names = append(names, name)
+	}
+	for name := range actualAttributes {
+		if _, exists := expectedAttributes[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].Space != names[j].Space {
+			return names[i].Space < names[j].Space
+		}
+		return names[i].Local < names[j].Local
+	})
+
+	for _, name := range names {
+		e, inExpected := expectedAttributes[name]
+		a, inActual := actualAttributes[name]
+		switch {
+		case !inActual:
+			c.add(Removed, join(path, "@"+e.QualifiedName()), e, nil)
+		case !inExpected:
+			c.add(Added, join(path, "@"+a.QualifiedName()), nil, a)
+		case e.Data != a.Data:
+			c.add(Changed, join(path, "@"+a.QualifiedName()), e, a)
+		}
+	}
+}
+
+func (c *comparator) compareChildren(path string, expected, actual *xmldom.Node) {
+	expectedChildren := c.significant(expected.Children)
+	actualChildren := c.significant(actual.Children)
+	for i := 0; i < len(expectedChildren) || i < len(actualChildren); i++ {
+		switch {
+		case i >= len(actualChildren):
+			c.add(Removed, join(path, step(expectedChildren, i)), expectedChildren[i], nil)
+		case i >= len(expectedChildren):
+			c.add(Added, join(path, step(actualChildren, i)), nil, actualChildren[i])
+		default:
+			c.compare(join(path, step(actualChildren, i)), expectedChildren[i], actualChildren[i])
+		}
+	}
+}
+
+// significant returns child nodes without whitespace-only text nodes and comments, if they are ignored.
+func (c *comparator) significant(nodes []*xmldom.Node) []*xmldom.Node {
+	filtered := make([]*xmldom.Node, 0, len(nodes))
+	for _, node := range nodes {
+		switch {
+		case node.Type == xmldom.TextNode && c.text(node) == "" && !c.options.PreserveWhitespace:
+		case node.Type == xmldom.CommentNode && c.options.IgnoreComments:
+		default:
+			filtered = append(filtered, node)
+		}
+	}
+	return filtered
+}
+
+func attributesByName(element *xmldom.Node) map[xml.Name]*xmldom.Node {
+	attributes := make(map[xml.Name]*xmldom.Node, len(element.Attributes))
+	for _, attribute := range element.Attributes {
+		attributes[attribute.Name] = attribute
+	}
+	return attributes
+}
+
+func join(path, step string) string {
+	return strings.TrimSuffix(path, "/") + "/" + step
+}
+
+// step returns the location step of the node in the list of siblings,
+// the position is added only when there are several siblings of the same kind.
+func step(siblings []*xmldom.Node, index int) string {
+	node := siblings[index]
+	position, count := 0, 0
+	for i, sibling := range siblings {
+		if sibling.Type == node.Type && sibling.Name == node.Name {
+			count++
+			if i == index {
+				position = count
+			}
+		}
+	}
+
+	var s string
+	switch node.Type {
+	case xmldom.TextNode:
+		s = "text()"
+	case xmldom.CommentNode:
+		s = "comment()"
+	case xmldom.ProcessingInstructionNode:
+		s = "processing-instruction('" + node.Name.Local + "')"
+	default:
+		s = node.QualifiedName()
+	}
+	if count > 1 {
+		s += "[" + strconv.Itoa(position) + "]"
+	}
+
+	return s
+}
+
+// maxDescriptionLength limits the length of serialized elements in the differences.
+const maxDescriptionLength = 80
+
+// text returns the data of the text node without leading and trailing whitespace,
+// unless whitespace is preserved.
+func (c *comparator) text(node *xmldom.Node) string {
+	if c.options.PreserveWhitespace {
+		return node.Data
+	}
+	return strings.TrimSpace(node.Data)
+}
+
+func (c *comparator) describe(node *xmldom.Node) string {
+	switch node.Type {
+	case xmldom.ElementNode:
+		s := &strings.Builder{}
+		serialize(s, node)
+		return truncate(s.String())
+	case xmldom.TextNode:
+		return truncate(strconv.Quote(c.text(node)))
+	case xmldom.AttributeNode:
+		return strconv.Quote(node.Data)
+	case xmldom.CommentNode:
+		return truncate("<!--" + node.Data + "-->")
+	case xmldom.ProcessingInstructionNode:
+		return truncate("<?" + node.Name.Local + " " + node.Data + "?>")
+	}
+	return "document"
+}
+
+// serialize writes the element in one line with the namespace URI in place of the prefix,
+// so elements with the same local name from different namespaces are distinguishable.
+func serialize(s *strings.Builder, node *xmldom.Node) {
+	switch node.Type {
+	case xmldom.ElementNode:
+		s.WriteString("<" + clarkName(node.Name))
+		for _, attribute := range node.Attributes {
+			s.WriteString(" " + clarkName(attribute.Name) + "=" + strconv.Quote(attribute.Data))
+		}
+		s.WriteString(">")
+		for _, child := range node.Children {
+			serialize(s, child)
+		}
+		s.WriteString("</" + clarkName(node.Name) + ">")
+	case xmldom.TextNode:
+		s.WriteString(strings.Join(strings.Fields(node.Data), " "))
+	case xmldom.CommentNode:
+		s.WriteString("<!--" + node.Data + "-->")
+	case xmldom.ProcessingInstructionNode:
+		s.WriteString("<?" + node.Name.Local + " " + node.Data + "?>")
+	}
+}
+
+// clarkName formats the name in the Clark notation: "{namespace-uri}local".
+func clarkName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+func truncate(s string) string {
+	if utf8.RuneCountInString(s) <= maxDescriptionLength {
+		return s
+	}
+	return string([]rune(s)[:maxDescriptionLength]) + "..."
+}
@@ -0,0 +1,134 @@
+package xmldiff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muonsoft/api-testing/internal/xmldiff"
+	"github.com/muonsoft/api-testing/internal/xmldom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		options  xmldiff.Options
+		want     []string
+	}{
+		{
+			name:     "equal after canonicalization",
+			expected: `<a:root xmlns:a="urn:a" x="1" y="2"><a:item>  text </a:item><empty/></a:root>`,
+			actual: `<?xml version="1.0"?>
+<root xmlns="urn:a" y="2" x="1">
+	<item>text</item>
+	<empty xmlns=""></empty>
+</root>`,
+		},
+		{
+			name:     "changed values",
+			expected: `<root id="1"><name>John</name><!--note--></root>`,
+			actual:   `<root id="2"><name>Jane</name><!--other--></root>`,
+			want: []string{
+				`changed "/root/@id": "1" -> "2"`,
+				`changed "/root/name/text()": "John" -> "Jane"`,
+				`changed "/root/comment()": <!--note--> -> <!--other-->`,
+			},
+		},
+		{
+			name:     "added and removed",
+			expected: `<root a="1"><item>1</item><item>2</item><item>3</item></root>`,
+			actual:   `<root b="2"><item>1</item></root>`,
+			want: []string{
+				`removed "/root/@a": "1"`,
+				`added "/root/@b": "2"`,
+				`removed "/root/item[2]": <item>2</item>`,
+				`removed "/root/item[3]": <item>3</item>`,
+			},
+		},
+		{
+			name:     "changed namespace",
+			expected: `<root xmlns:p="urn:a"><p:item/></root>`,
+			actual:   `<root xmlns:p="urn:b"><p:item/></root>`,
+			want: []string{
+				`changed "/root/p:item": <{urn:a}item></{urn:a}item> -> <{urn:b}item></{urn:b}item>`,
+			},
+		},
+		{
+			name:     "changed node type",
+			expected: `<root><item/></root>`,
+			actual:   `<root>item</root>`,
+			want: []string{
+				`changed "/root/text()": <item></item> -> "item"`,
+			},
+		},
+		{
+			name:     "ignored comments",
+			expected: `<root><!--a--><item/></root>`,
+			actual:   `<root><item/><!--b--><!--c--></root>`,
+			options:  xmldiff.Options{IgnoreComments: true},
+		},
+		{
+			name:     "mixed content with trimmed whitespace",
+			expected: `<p>a <b/></p>`,
+			actual:   `<p>a<b/></p>`,
+		},
+		{
+			name:     "mixed content with preserved whitespace",
+			expected: `<p>a <b/></p>`,
+			actual:   `<p>a<b/></p>`,
+			options:  xmldiff.Options{PreserveWhitespace: true},
+			want: []string{
+				`changed "/p/text()": "a " -> "a"`,
+			},
+		},
+		{
+			name:     "whitespace-only text with preserved whitespace",
+			expected: `<p><b/></p>`,
+			actual: `<p>
+	<b/>
+</p>`,
+			options: xmldiff.Options{PreserveWhitespace: true},
+			want: []string{
+				`changed "/p/text()[1]": <b></b> -> "\n\t"`,
+				`added "/p/b": <b></b>`,
+				`added "/p/text()[2]": "\n"`,
+			},
+		},
+		{
+			name:     "long element is truncated",
+			expected: `<root/>`,
+			actual:   `<root><item>` + strings.Repeat("x", 100) + `</item></root>`,
+			want: []string{
+				`added "/root/item": <item>` + strings.Repeat("x", 74) + `...`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected, err := xmldom.Parse(strings.NewReader(test.expected))
+			require.NoError(t, err)
+			actual, err := xmldom.Parse(strings.NewReader(test.actual))
+			require.NoError(t, err)
+
+			differences := xmldiff.Compare(expected, actual, test.options)
+
+			var got []string
+			for _, difference := range differences {
+				got = append(got, difference.String())
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	formatted := xmldiff.Format([]xmldiff.Difference{
+		{Kind: xmldiff.Added, Path: "/a", Actual: `"x"`},
+		{Kind: xmldiff.Removed, Path: "/b", Expected: `"y"`},
+	})
+
+	assert.Equal(t, "\tadded \"/a\": \"x\"\n\tremoved \"/b\": \"y\"", formatted)
+}
@@ -0,0 +1,442 @@
+// Package xmldom provides a tree model of XML documents used by XPath evaluation,
+// canonical comparison and schema validation. Namespaces are resolved while parsing,
+// so names of nodes hold namespace URIs, while original prefixes are kept for printing.
+package xmldom
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// XMLNamespace is a namespace URI bound to the "xml" prefix.
+const XMLNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// NodeType is a type of the node defined by XPath data model.
+type NodeType int
+
+// Types of nodes.
+const (
+	DocumentNode NodeType = iota
+	ElementNode
+	AttributeNode
+	TextNode
+	CommentNode
+	ProcessingInstructionNode
+	NamespaceNode
+)
+
+// Node is a node of the XML document.
+type Node struct {
+	Type NodeType
+	// Name of element, attribute, processing instruction (target) or namespace node (prefix).
+	// Name.Space holds namespace URI.
+	Name xml.Name
+	// Prefix is a namespace prefix used in the source document.
+	Prefix string
+	// Data holds a value of text, attribute, comment, processing instruction or namespace URI.
+	Data       string
+	Parent     *Node
+	Children   []*Node
+	Attributes []*Node
+	// Namespaces holds namespace declarations of the element: prefix to URI,
+	// empty prefix is used for the default namespace.
+	Namespaces map[string]string
+
+	// order is a position of the node in the document order, namespace nodes
+	// share the position of the element and are ordered by sub.
+	order int
+	sub   int
+}
+
+// Parse reads XML document and builds its tree. Adjacent character data (including CDATA sections)
+// is joined into one text node. Document type declarations are skipped.
+func Parse(r io.Reader) (*Node, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = charsetReader
+
+	p := &parser{decoder: decoder, root: &Node{Type: DocumentNode}}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+
+	return p.root, nil
+}
+
+type parser struct {
+	decoder *xml.Decoder
+	root    *Node
+	order   int
+}
+
+func (p *parser) parse() error {
+	current := p.root
+	hasElement := false
+	for {
+		token, err := p.decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if current == p.root && hasElement {
+				return fmt.Errorf("line %d: document has more than one root element", p.line())
+			}
+			hasElement = true
+			element, err := p.element(current, token)
+			if err != nil {
+				return err
+			}
+			current = element
+		case xml.EndElement:
+			if current.Type != ElementNode || current.Prefix != token.Name.Space || current.Name.Local != token.Name.Local {
+				return fmt.Errorf("line %d: unexpected end element </%s>", p.line(), qualified(token.Name.Space, token.Name.Local))
+			}
+			current = current.Parent
+		case xml.CharData:
+			if current == p.root {
+				if strings.TrimSpace(string(token)) != "" {
+					return fmt.Errorf("line %d: text is not allowed outside of the root element", p.line())
+				}
+				continue
+			}
+			p.text(current, string(token))
+		case xml.Comment:
+			p.append(current, &Node{Type: CommentNode, Data: string(token)})
+		case xml.ProcInst:
+			if token.Target != "xml" {
+				p.append(current, &Node{Type: ProcessingInstructionNode, Name: xml.Name{Local: token.Target}, Data: string(token.Inst)})
+			}
+		}
+	}
+	if current != p.root {
+		return fmt.Errorf("unexpected EOF: element <%s> is not closed", qualified(current.Prefix, current.Name.Local))
+	}
+	if !hasElement {
+		return fmt.Errorf("document has no root element")
+	}
+
+	return nil
+}
+
+func (p *parser) element(parent *Node, token xml.StartElement) (*Node, error) {
+	element := &Node{Type: ElementNode, Prefix: token.Name.Space, Name: xml.Name{Local: token.Name.Local}}
+	p.append(parent, element)
+
+	for _, attr := range token.Attr {
+		switch {
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			element.declare("", attr.Value)
+		case attr.Name.Space == "xmlns":
+			element.declare(attr.Name.Local, attr.Value)
+		}
+	}
+
+	space, err := element.resolve(element.Prefix, true)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", p.line(), err)
+	}
+	element.Name.Space = space
+
+	seen := make(map[xml.Name]bool, len(token.Attr))
+	for _, attr := range token.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			continue
+		}
+		space, err := element.resolve(attr.Name.Space, false)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", p.line(), err)
+		}
+		name := xml.Name{Space: space, Local: attr.Name.Local}
+		if seen[name] {
+			return nil, fmt.Errorf("line %d: duplicate attribute %s", p.line(), qualified(attr.Name.Space, attr.Name.Local))
+		}
+		seen[name] = true
+		p.order++
+		element.Attributes = append(element.Attributes, &Node{
+			Type:   AttributeNode,
+			Name:   name,
+			Prefix: attr.Name.Space,
+			Data:   attr.Value,
+			Parent: element,
+			order:  p.order,
+		})
+	}
+
+	return element, nil
+}
+
+func (p *parser) text(parent *Node, data string) {
+	if n := len(parent.Children); n > 0 && parent.Children[n-1].Type == TextNode {
+		parent.Children[n-1].Data += data
+		return
+	}
+	p.append(parent, &Node{Type: TextNode, Data: data})
+}
+
+func (p *parser) append(parent, node *Node) {
+	p.order++
+	node.order = p.order
+	node.Parent = parent
+	parent.Children = append(parent.Children, node)
+}
+
+func (p *parser) line() int {
+	line, _ := p.decoder.InputPos()
+	return line
+}
+
+func (n *Node) declare(prefix, uri string) {
+	if n.Namespaces == nil {
+		n.Namespaces = make(map[string]string)
+	}
+	n.Namespaces[prefix] = uri
+}
+
+// resolve returns namespace URI bound to the prefix in the scope of the element.
+// The default namespace is applied only to elements.
+func (n *Node) resolve(prefix string, isElement bool) (string, error) {
+	if prefix == "xml" {
+		return XMLNamespace, nil
+	}
+	if prefix == "" && !isElement {
+		return "", nil
+	}
+	if uri, ok := n.LookupNamespace(prefix); ok {
+		return uri, nil
+	}
+	if prefix == "" {
+		return "", nil
+	}
+
+	return "", fmt.Errorf("namespace prefix %q is not declared", prefix)
+}
+
+// LookupNamespace returns namespace URI bound to the prefix in the scope of the node.
+func (n *Node) LookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return XMLNamespace, true
+	}
+	for node := n; node != nil; node = node.Parent {
+		if uri, ok := node.Namespaces[prefix]; ok {
+			return uri, uri != "" || prefix == ""
+		}
+	}
+
+	return "", false
+}
+
+// NamespaceNodes returns namespace nodes of the element: all namespaces in scope
+// (including the "xml" one) ordered by prefix. Undeclared default namespace is skipped.
+func (n *Node) NamespaceNodes() []*Node {
+	if n.Type != ElementNode {
+		return nil
+	}
+	scope := map[string]string{"xml": XMLNamespace}
+	for node := n; node != nil; node = node.Parent {
+		for prefix, uri := range node.Namespaces {
+			if _, exists := scope[prefix]; !exists {
+				scope[prefix] = uri
+			}
+		}
+	}
+	prefixes := make([]string, 0, len(scope))
+	for prefix, uri := range scope {
+		if uri != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	sort.Strings(prefixes)
+
+	nodes := make([]*Node, len(prefixes))
+	for i, prefix := range prefixes {
+		nodes[i] = &Node{
+			Type:   NamespaceNode,
+			Name:   xml.Name{Local: prefix},
+			Data:   scope[prefix],
+			Parent: n,
+			order:  n.order,
+			sub:    i + 1,
+		}
+	}
+
+	return nodes
+}
+
+// Before reports whether the node precedes the other one in the document order.
+// Both nodes must belong to the same document.
+func (n *Node) Before(other *Node) bool {
+	if n.order != other.order {
+		return n.order < other.order
+	}
+	return n.sub < other.sub
+}
+
+// Same reports whether both values refer to the same node. Namespace nodes are created
+// on each request, so they are compared by their parent and prefix.
+func (n *Node) Same(other *Node) bool {
+	return n == other || n.Type == NamespaceNode && other.Type == NamespaceNode &&
+		n.Parent == other.Parent && n.Name.Local == other.Name.Local
+}
+
+// StringValue returns string-value of the node defined by XPath: concatenation of all
+// descendant text nodes for document and element, the value of other nodes.
+func (n *Node) StringValue() string {
+	if n.Type != DocumentNode && n.Type != ElementNode {
+		return n.Data
+	}
+	var s strings.Builder
+	n.writeText(&s)
+	return s.String()
+}
+
+func (n *Node) writeText(s *strings.Builder) {
+	for _, child := range n.Children {
+		switch child.Type {
+		case TextNode:
+			s.WriteString(child.Data)
+		case ElementNode:
+			child.writeText(s)
+		}
+	}
+}
+
+// QualifiedName returns the name of the node with the prefix used in the source document.
+func (n *Node) QualifiedName() string {
+	return qualified(n.Prefix, n.Name.Local)
+}
+
+// Root returns the document node.
+func (n *Node) Root() *Node {
+	root := n
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	return root
+}
+
+// Path returns XPath location of the node from the root of the document, for example
+// "/root/items/item[2]/@id". Positions are added only when the parent has several
+// children with the same name. Unprefixed names in XPath select elements without a namespace,
+// so elements of the default namespace are selected by a step like
+// "*[local-name()='item' and namespace-uri()='urn:items']".
+func (n *Node) Path() string {
+	return n.PathWith(nil)
+}
+
+// PathWith returns XPath location of the node the same way as Path, but elements are named
+// by the prefixes registered for XPath expressions (prefix to namespace URI), so the location
+// can be evaluated with the same prefixes.
+func (n *Node) PathWith(namespaces map[string]string) string {
+	switch n.Type {
+	case DocumentNode:
+		return "/"
+	case AttributeNode:
+		return n.Parent.childPath(namespaces) + "/@" + n.QualifiedName()
+	case NamespaceNode:
+		return n.Parent.childPath(namespaces) + "/namespace::" + n.Name.Local
+	}
+	return n.childPath(namespaces)
+}
+
+func (n *Node) childPath(namespaces map[string]string) string {
+	if n.Type == DocumentNode {
+		return ""
+	}
+	position, count := 0, 0
+	for _, sibling := range n.Parent.Children {
+		if sibling.Type == n.Type && sibling.Name == n.Name {
+			count++
+			if sibling == n {
+				position = count
+			}
+		}
+	}
+	step := n.step(namespaces)
+	if count > 1 {
+		step += "[" + strconv.Itoa(position) + "]"
+	}
+
+	return n.Parent.childPath(namespaces) + "/" + step
+}
+
+func (n *Node) step(namespaces map[string]string) string {
+	switch n.Type {
+	case TextNode:
+		return "text()"
+	case CommentNode:
+		return "comment()"
+	case ProcessingInstructionNode:
+		return "processing-instruction('" + n.Name.Local + "')"
+	case ElementNode:
+		return n.elementStep(namespaces)
+	}
+	return n.QualifiedName()
+}
+
+// elementStep returns the name test that selects the element by XPath. The prefix of the source
+// document is used when it is bound to the same namespace or is not registered at all.
+func (n *Node) elementStep(namespaces map[string]string) string {
+	uri, bound := namespaces[n.Prefix]
+	if bound && uri == n.Name.Space {
+		return n.QualifiedName()
+	}
+	prefixes := make([]string, 0, len(namespaces))
+	for prefix, uri := range namespaces {
+		if uri == n.Name.Space && prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	if len(prefixes) > 0 {
+		sort.Strings(prefixes)
+		return qualified(prefixes[0], n.Name.Local)
+	}
+	if !bound && (n.Prefix != "" || n.Name.Space == "") {
+		return n.QualifiedName()
+	}
+
+	return fmt.Sprintf("*[local-name()='%s' and namespace-uri()='%s']", n.Name.Local, n.Name.Space)
+}
+
+func qualified(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "latin-1":
+		return &latin1Reader{r: input}, nil
+	}
+	return nil, fmt.Errorf("unsupported charset %q", charset)
+}
+
+// latin1Reader converts ISO-8859-1 input into UTF-8.
+type latin1Reader struct {
+	r   io.Reader
+	buf []byte
+}
+
+func (l *latin1Reader) Read(p []byte) (int, error) {
+	if len(l.buf) == 0 {
+		raw := make([]byte, len(p)/2+1)
+		n, err := l.r.Read(raw)
+		for _, b := range raw[:n] {
+			l.buf = append(l.buf, string(rune(b))...)
+		}
+		if n == 0 {
+			return 0, err
+		}
+	}
+	n := copy(p, l.buf)
+	l.buf = l.buf[n:]
+	return n, nil
+}
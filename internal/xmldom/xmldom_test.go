@@ -0,0 +1,63 @@
+package xmldom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	root, err := xmldom.Parse(strings.NewReader(
+		`<s:root xmlns:s="urn:s" xmlns="urn:default" a="1" s:b="2"><item>x<![CDATA[<y>]]></item><item/><!--c--></s:root>`,
+	))
+
+	require.NoError(t, err)
+	element := root.Children[0]
+	assert.Equal(t, "urn:s", element.Name.Space)
+	assert.Equal(t, "s:root", element.QualifiedName())
+	assert.Equal(t, "", element.Attributes[0].Name.Space)
+	assert.Equal(t, "urn:s", element.Attributes[1].Name.Space)
+	item := element.Children[0]
+	assert.Equal(t, "urn:default", item.Name.Space)
+	assert.Equal(t, "x<y>", item.StringValue())
+	assert.Equal(t, "/s:root/*[local-name()='item' and namespace-uri()='urn:default'][1]", item.Path())
+	assert.Equal(t, "/s:root/d:item[1]", item.PathWith(map[string]string{"s": "urn:s", "d": "urn:default"}))
+	assert.Equal(t, "/x:root/item[1]", item.PathWith(map[string]string{"x": "urn:s", "": "urn:default"}))
+	assert.Equal(t, "/*[local-name()='root' and namespace-uri()='urn:s']", element.PathWith(map[string]string{"s": "urn:other"}))
+	assert.Equal(t, "/s:root/@s:b", element.Attributes[1].Path())
+	assert.Equal(t, "/s:root/comment()", element.Children[2].Path())
+	assert.True(t, element.Before(element.Attributes[0]))
+	assert.True(t, element.Attributes[1].Before(item))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{data: `<a><b></a>`, want: "line 1: unexpected end element </a>"},
+		{data: `<a>`, want: "unexpected EOF: element <a> is not closed"},
+		{data: `<a/><b/>`, want: "line 1: document has more than one root element"},
+		{data: `<p:a/>`, want: `line 1: namespace prefix "p" is not declared`},
+		{data: `<a x="1" x="2"/>`, want: "line 1: duplicate attribute x"},
+		{data: `text`, want: "line 1: text is not allowed outside of the root element"},
+		{data: ``, want: "document has no root element"},
+	}
+	for _, test := range tests {
+		t.Run(test.data, func(t *testing.T) {
+			_, err := xmldom.Parse(strings.NewReader(test.data))
+
+			assert.EqualError(t, err, test.want)
+		})
+	}
+}
+
+func TestParse_Latin1(t *testing.T) {
+	root, err := xmldom.Parse(strings.NewReader("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><a>caf\xe9</a>"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "café", root.StringValue())
+}
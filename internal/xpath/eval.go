@@ -0,0 +1,491 @@
+package xpath
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+)
+
+type context struct {
+	node     *xmldom.Node
+	position int
+	size     int
+}
+
+type expr interface {
+	evaluate(ctx *context) (Value, error)
+}
+
+type constant struct {
+	value Value
+}
+
+func (c *constant) evaluate(*context) (Value, error) {
+	return c.value, nil
+}
+
+type logical struct {
+	isAnd       bool
+	left, right expr
+}
+
+func newLogical(op string, left, right expr) expr {
+	return &logical{isAnd: op == "and", left: left, right: right}
+}
+
+func (l *logical) evaluate(ctx *context) (Value, error) {
+	left, err := l.left.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the right operand is not evaluated if the result is known
+	if Boolean(left) != l.isAnd {
+		return !l.isAnd, nil
+	}
+	right, err := l.right.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Boolean(right), nil
+}
+
+type comparison struct {
+	op          string
+	left, right expr
+}
+
+func newComparison(op string, left, right expr) expr {
+	return &comparison{op: op, left: left, right: right}
+}
+
+func (c *comparison) evaluate(ctx *context) (Value, error) {
+	left, err := c.left.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.right.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return compare(c.op, left, right), nil
+}
+
+// compare implements comparison of objects defined in section 3.4 of the specification.
+func compare(op string, left, right Value) bool {
+	leftNodes, leftIsSet := left.(NodeSet)
+	rightNodes, rightIsSet := right.(NodeSet)
+	switch {
+	case leftIsSet && rightIsSet:
+		for _, l := range leftNodes {
+			for _, r := range rightNodes {
+				if compareAtoms(op, l.StringValue(), r.StringValue()) {
+					return true
+				}
+			}
+		}
+		return false
+	case leftIsSet:
+		return compareNodes(op, leftNodes, right, false)
+	case rightIsSet:
+		return compareNodes(op, rightNodes, left, true)
+	}
+	return compareAtoms(op, left, right)
+}
+
+func compareNodes(op string, nodes NodeSet, other Value, swapped bool) bool {
+	if b, ok := other.(bool); ok {
+		if swapped {
+			return compareAtoms(op, b, Boolean(nodes))
+		}
+		return compareAtoms(op, Boolean(nodes), b)
+	}
+	for _, node := range nodes {
+		var matched bool
+		if swapped {
+			matched = compareAtoms(op, other, node.StringValue())
+		} else {
+			matched = compareAtoms(op, node.StringValue(), other)
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func compareAtoms(op string, left, right Value) bool {
+	if op == "=" || op == "!=" {
+		var equal bool
+		_, leftIsBool := left.(bool)
+		_, rightIsBool := right.(bool)
+		_, leftIsNumber := left.(float64)
+		_, rightIsNumber := right.(float64)
+		switch {
+		case leftIsBool || rightIsBool:
+			equal = Boolean(left) == Boolean(right)
+		case leftIsNumber || rightIsNumber:
+			equal = Number(left) == Number(right)
+		default:
+			equal = String(left) == String(right)
+		}
+		return equal == (op == "=")
+	}
+
+	l, r := Number(left), Number(right)
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	}
+	return l >= r
+}
+
+type arithmetic struct {
+	op          string
+	left, right expr
+}
+
+func newArithmetic(op string, left, right expr) expr {
+	return &arithmetic{op: op, left: left, right: right}
+}
+
+func (a *arithmetic) evaluate(ctx *context) (Value, error) {
+	left, err := a.left.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	right, err := a.right.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l, r := Number(left), Number(right)
+	switch a.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "div":
+		return l / r, nil
+	}
+	return math.Mod(l, r), nil
+}
+
+type negation struct {
+	operand expr
+}
+
+func (n *negation) evaluate(ctx *context) (Value, error) {
+	value, err := n.operand.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return -Number(value), nil
+}
+
+type union struct {
+	left, right expr
+}
+
+func (u *union) evaluate(ctx *context) (Value, error) {
+	left, err := evaluateNodes(u.left, ctx, "operand of union")
+	if err != nil {
+		return nil, err
+	}
+	right, err := evaluateNodes(u.right, ctx, "operand of union")
+	if err != nil {
+		return nil, err
+	}
+	nodes := make(NodeSet, 0, len(left)+len(right))
+	nodes = append(nodes, left...)
+	nodes = append(nodes, right...)
+	return sortNodes(nodes), nil
+}
+
+type filter struct {
+	primary    expr
+	predicates []expr
+}
+
+func (f *filter) evaluate(ctx *context) (Value, error) {
+	nodes, err := evaluateNodes(f.primary, ctx, "filtered expression")
+	if err != nil {
+		return nil, err
+	}
+	return applyPredicates(nodes, f.predicates)
+}
+
+type functionCall struct {
+	name string
+	args []expr
+	call func(ctx *context, args []expr) (Value, error)
+}
+
+func (f *functionCall) evaluate(ctx *context) (Value, error) {
+	return f.call(ctx, f.args)
+}
+
+type locationPath struct {
+	// start is a filter expression the relative path starts with
+	start    expr
+	absolute bool
+	steps    []*step
+}
+
+func (p *locationPath) evaluate(ctx *context) (Value, error) {
+	var nodes NodeSet
+	switch {
+	case p.start != nil:
+		start, err := evaluateNodes(p.start, ctx, "start of the path")
+		if err != nil {
+			return nil, err
+		}
+		nodes = start
+	case p.absolute:
+		nodes = NodeSet{ctx.node.Root()}
+	default:
+		nodes = NodeSet{ctx.node}
+	}
+
+	for _, s := range p.steps {
+		var selected NodeSet
+		for _, node := range nodes {
+			found, err := s.selectNodes(node)
+			if err != nil {
+				return nil, err
+			}
+			selected = append(selected, found...)
+		}
+		nodes = sortNodes(selected)
+	}
+
+	return nodes, nil
+}
+
+type testKind int
+
+const (
+	testName testKind = iota
+	testNode
+	testText
+	testComment
+	testProcessingInstruction
+)
+
+type nodeTest struct {
+	kind testKind
+	// space is namespace URI of the name test
+	space string
+	// local is a local name of the name test ("*" for any) or a target of processing instruction test
+	local    string
+	anySpace bool
+}
+
+func (t nodeTest) matches(node *xmldom.Node, principal xmldom.NodeType) bool {
+	switch t.kind {
+	case testNode:
+		return true
+	case testText:
+		return node.Type == xmldom.TextNode
+	case testComment:
+		return node.Type == xmldom.CommentNode
+	case testProcessingInstruction:
+		return node.Type == xmldom.ProcessingInstructionNode && (t.local == "" || node.Name.Local == t.local)
+	}
+	if node.Type != principal {
+		return false
+	}
+	if !t.anySpace && node.Name.Space != t.space {
+		return false
+	}
+	return t.local == "*" || node.Name.Local == t.local
+}
+
+type step struct {
+	axis       axis
+	test       nodeTest
+	predicates []expr
+}
+
+// selectNodes returns nodes selected by the step from the context node in the order of the axis.
+func (s *step) selectNodes(node *xmldom.Node) (NodeSet, error) {
+	principal := s.axis.principalType()
+	var nodes NodeSet
+	for _, candidate := range axisNodes(s.axis, node) {
+		if s.test.matches(candidate, principal) {
+			nodes = append(nodes, candidate)
+		}
+	}
+	return applyPredicates(nodes, s.predicates)
+}
+
+// applyPredicates filters nodes by predicates, positions of nodes are their indexes in the list.
+func applyPredicates(nodes NodeSet, predicates []expr) (NodeSet, error) {
+	for _, predicate := range predicates {
+		filtered := make(NodeSet, 0, len(nodes))
+		for i, node := range nodes {
+			value, err := predicate.evaluate(&context{node: node, position: i + 1, size: len(nodes)})
+			if err != nil {
+				return nil, err
+			}
+			if number, ok := value.(float64); ok {
+				if number == float64(i+1) {
+					filtered = append(filtered, node)
+				}
+			} else if Boolean(value) {
+				filtered = append(filtered, node)
+			}
+		}
+		nodes = filtered
+	}
+	return nodes, nil
+}
+
+func evaluateNodes(e expr, ctx *context, description string) (NodeSet, error) {
+	value, err := e.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodes, ok := value.(NodeSet)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a node-set, actual is %s", description, typeName(value))
+	}
+	return nodes, nil
+}
+
+func typeName(value Value) string {
+	switch value.(type) {
+	case NodeSet:
+		return "node-set"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "string"
+}
+
+// axisNodes returns nodes of the axis in the axis order: reverse axes list nodes
+// in the reverse document order.
+func axisNodes(a axis, node *xmldom.Node) NodeSet {
+	switch a {
+	case axisChild:
+		return childNodes(node)
+	case axisDescendant:
+		return descendants(node, nil)
+	case axisDescendantOrSelf:
+		return descendants(node, NodeSet{node})
+	case axisParent:
+		if node.Parent != nil {
+			return NodeSet{node.Parent}
+		}
+		return nil
+	case axisAncestor:
+		return ancestors(node.Parent)
+	case axisAncestorOrSelf:
+		return ancestors(node)
+	case axisFollowingSibling:
+		return siblings(node, true)
+	case axisPrecedingSibling:
+		return siblings(node, false)
+	case axisFollowing:
+		return following(node)
+	case axisPreceding:
+		return preceding(node)
+	case axisAttribute:
+		if node.Type == xmldom.ElementNode {
+			return node.Attributes
+		}
+		return nil
+	case axisNamespace:
+		return node.NamespaceNodes()
+	}
+	return NodeSet{node}
+}
+
+func childNodes(node *xmldom.Node) NodeSet {
+	if node.Type != xmldom.ElementNode && node.Type != xmldom.DocumentNode {
+		return nil
+	}
+	return node.Children
+}
+
+func descendants(node *xmldom.Node, nodes NodeSet) NodeSet {
+	for _, child := range childNodes(node) {
+		nodes = append(nodes, child)
+		nodes = descendants(child, nodes)
+	}
+	return nodes
+}
+
+func ancestors(node *xmldom.Node) NodeSet {
+	var nodes NodeSet
+	for ; node != nil; node = node.Parent {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// isChild reports whether the node is a child of its parent, attribute and namespace nodes are not.
+func isChild(node *xmldom.Node) bool {
+	return node.Parent != nil && node.Type != xmldom.AttributeNode && node.Type != xmldom.NamespaceNode
+}
+
+func siblings(node *xmldom.Node, isFollowing bool) NodeSet {
+	if !isChild(node) {
+		return nil
+	}
+	children := node.Parent.Children
+	index := 0
+	for i, child := range children {
+		if child == node {
+			index = i
+		}
+	}
+	var nodes NodeSet
+	if isFollowing {
+		nodes = append(nodes, children[index+1:]...)
+	} else {
+		for i := index - 1; i >= 0; i-- {
+			nodes = append(nodes, children[i])
+		}
+	}
+	return nodes
+}
+
+func following(node *xmldom.Node) NodeSet {
+	var nodes NodeSet
+	if !isChild(node) && node.Parent != nil {
+		// following nodes of attributes and namespaces start with the descendants of their element
+		nodes = descendants(node.Parent, nil)
+		node = node.Parent
+	}
+	for ; isChild(node); node = node.Parent {
+		for _, sibling := range siblings(node, true) {
+			nodes = append(nodes, sibling)
+			nodes = descendants(sibling, nodes)
+		}
+	}
+	return nodes
+}
+
+func preceding(node *xmldom.Node) NodeSet {
+	if !isChild(node) && node.Parent != nil {
+		node = node.Parent
+	}
+	var nodes NodeSet
+	for ; isChild(node); node = node.Parent {
+		for _, sibling := range siblings(node, false) {
+			subtree := descendants(sibling, NodeSet{sibling})
+			for i := len(subtree) - 1; i >= 0; i-- {
+				nodes = append(nodes, subtree[i])
+			}
+		}
+	}
+	return nodes
+}
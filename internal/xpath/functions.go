@@ -0,0 +1,388 @@
+package xpath
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+)
+
+type function struct {
+	minArgs int
+	// maxArgs is -1 for unlimited number of arguments
+	maxArgs int
+	call    func(ctx *context, args []expr) (Value, error)
+}
+
+var functions map[string]function
+
+func init() {
+	functions = map[string]function{
+		// node-set functions
+		"last":          {0, 0, last},
+		"position":      {0, 0, position},
+		"count":         {1, 1, count},
+		"id":            {1, 1, id},
+		"local-name":    {0, 1, localName},
+		"namespace-uri": {0, 1, namespaceURI},
+		"name":          {0, 1, name},
+		// string functions
+		"string":           {0, 1, stringFunction},
+		"concat":           {2, -1, concat},
+		"starts-with":      {2, 2, stringsPredicate(strings.HasPrefix)},
+		"contains":         {2, 2, stringsPredicate(strings.Contains)},
+		"substring-before": {2, 2, substringBefore},
+		"substring-after":  {2, 2, substringAfter},
+		"substring":        {2, 3, substring},
+		"string-length":    {0, 1, stringLength},
+		"normalize-space":  {0, 1, normalizeSpace},
+		"translate":        {3, 3, translate},
+		// boolean functions
+		"boolean": {1, 1, booleanFunction},
+		"not":     {1, 1, not},
+		"true":    {0, 0, constantFunction(true)},
+		"false":   {0, 0, constantFunction(false)},
+		"lang":    {1, 1, lang},
+		// number functions
+		"number":  {0, 1, numberFunction},
+		"sum":     {1, 1, sum},
+		"floor":   {1, 1, numberFunc(math.Floor)},
+		"ceiling": {1, 1, numberFunc(math.Ceil)},
+		"round":   {1, 1, numberFunc(round)},
+	}
+}
+
+func last(ctx *context, _ []expr) (Value, error) {
+	return float64(ctx.size), nil
+}
+
+func position(ctx *context, _ []expr) (Value, error) {
+	return float64(ctx.position), nil
+}
+
+func count(ctx *context, args []expr) (Value, error) {
+	nodes, err := evaluateNodes(args[0], ctx, "argument of count()")
+	if err != nil {
+		return nil, err
+	}
+	return float64(len(nodes)), nil
+}
+
+// id selects elements by the values of "id" and "xml:id" attributes,
+// document type declarations are not used to find attributes of ID type.
+func id(ctx *context, args []expr) (Value, error) {
+	value, err := args[0].evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool)
+	if nodes, ok := value.(NodeSet); ok {
+		for _, node := range nodes {
+			for _, token := range strings.Fields(node.StringValue()) {
+				ids[token] = true
+			}
+		}
+	} else {
+		for _, token := range strings.Fields(String(value)) {
+			ids[token] = true
+		}
+	}
+
+	var found NodeSet
+	for _, node := range descendants(ctx.node.Root(), nil) {
+		if node.Type != xmldom.ElementNode {
+			continue
+		}
+		for _, attr := range node.Attributes {
+			isID := attr.Name.Local == "id" && (attr.Name.Space == "" || attr.Name.Space == xmldom.XMLNamespace)
+			if isID && ids[strings.TrimSpace(attr.Data)] {
+				found = append(found, node)
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+// firstNode returns the first node of the argument or the context node if there is no argument.
+func firstNode(ctx *context, args []expr, function string) (*xmldom.Node, error) {
+	if len(args) == 0 {
+		return ctx.node, nil
+	}
+	nodes, err := evaluateNodes(args[0], ctx, "argument of "+function)
+	if err != nil || len(nodes) == 0 {
+		return nil, err
+	}
+	return nodes[0], nil
+}
+
+func localName(ctx *context, args []expr) (Value, error) {
+	node, err := firstNode(ctx, args, "local-name()")
+	if err != nil || node == nil {
+		return "", err
+	}
+	return node.Name.Local, nil
+}
+
+func namespaceURI(ctx *context, args []expr) (Value, error) {
+	node, err := firstNode(ctx, args, "namespace-uri()")
+	if err != nil || node == nil {
+		return "", err
+	}
+	return node.Name.Space, nil
+}
+
+func name(ctx *context, args []expr) (Value, error) {
+	node, err := firstNode(ctx, args, "name()")
+	if err != nil || node == nil {
+		return "", err
+	}
+	switch node.Type {
+	case xmldom.ElementNode, xmldom.AttributeNode:
+		return node.QualifiedName(), nil
+	}
+	return node.Name.Local, nil
+}
+
+// stringArg evaluates the argument as a string, the context node is used if there is no argument.
+func stringArg(ctx *context, args []expr, i int) (string, error) {
+	if len(args) <= i {
+		return ctx.node.StringValue(), nil
+	}
+	value, err := args[i].evaluate(ctx)
+	if err != nil {
+		return "", err
+	}
+	return String(value), nil
+}
+
+func numberArg(ctx *context, args []expr, i int) (float64, error) {
+	value, err := args[i].evaluate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return Number(value), nil
+}
+
+func stringFunction(ctx *context, args []expr) (Value, error) {
+	return stringArg(ctx, args, 0)
+}
+
+func concat(ctx *context, args []expr) (Value, error) {
+	var s strings.Builder
+	for i := range args {
+		value, err := stringArg(ctx, args, i)
+		if err != nil {
+			return nil, err
+		}
+		s.WriteString(value)
+	}
+	return s.String(), nil
+}
+
+func stringsPredicate(predicate func(s, substr string) bool) func(ctx *context, args []expr) (Value, error) {
+	return func(ctx *context, args []expr) (Value, error) {
+		s, err := stringArg(ctx, args, 0)
+		if err != nil {
+			return nil, err
+		}
+		substr, err := stringArg(ctx, args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return predicate(s, substr), nil
+	}
+}
+
+func substringBefore(ctx *context, args []expr) (Value, error) {
+	s, err := stringArg(ctx, args, 0)
+	if err != nil {
+		return nil, err
+	}
+	separator, err := stringArg(ctx, args, 1)
+	if err != nil {
+		return nil, err
+	}
+	if i := strings.Index(s, separator); i >= 0 {
+		return s[:i], nil
+	}
+	return "", nil
+}
+
+func substringAfter(ctx *context, args []expr) (Value, error) {
+	s, err := stringArg(ctx, args, 0)
+	if err != nil {
+		return nil, err
+	}
+	separator, err := stringArg(ctx, args, 1)
+	if err != nil {
+		return nil, err
+	}
+	if i := strings.Index(s, separator); i >= 0 {
+		return s[i+len(separator):], nil
+	}
+	return "", nil
+}
+
+// substring returns characters at positions p such that round(start) <= p < round(start) + round(length),
+// so NaN and infinite arguments are handled by the rules of IEEE 754 comparison.
+func substring(ctx *context, args []expr) (Value, error) {
+	s, err := stringArg(ctx, args, 0)
+	if err != nil {
+		return nil, err
+	}
+	start, err := numberArg(ctx, args, 1)
+	if err != nil {
+		return nil, err
+	}
+	start = round(start)
+	end := math.Inf(1)
+	if len(args) > 2 {
+		length, err := numberArg(ctx, args, 2)
+		if err != nil {
+			return nil, err
+		}
+		end = start + round(length)
+	}
+
+	var result strings.Builder
+	for i, r := range []rune(s) {
+		if p := float64(i + 1); p >= start && p < end {
+			result.WriteRune(r)
+		}
+	}
+	return result.String(), nil
+}
+
+func stringLength(ctx *context, args []expr) (Value, error) {
+	s, err := stringArg(ctx, args, 0)
+	if err != nil {
+		return nil, err
+	}
+	return float64(utf8.RuneCountInString(s)), nil
+}
+
+func normalizeSpace(ctx *context, args []expr) (Value, error) {
+	s, err := stringArg(ctx, args, 0)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	}), " "), nil
+}
+
+func translate(ctx *context, args []expr) (Value, error) {
+	s, err := stringArg(ctx, args, 0)
+	if err != nil {
+		return nil, err
+	}
+	from, err := stringArg(ctx, args, 1)
+	if err != nil {
+		return nil, err
+	}
+	to, err := stringArg(ctx, args, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	replacements := []rune(to)
+	mapping := make(map[rune]int)
+	for i, r := range []rune(from) {
+		if _, exists := mapping[r]; !exists {
+			mapping[r] = i
+		}
+	}
+	var result strings.Builder
+	for _, r := range s {
+		i, exists := mapping[r]
+		switch {
+		case !exists:
+			result.WriteRune(r)
+		case i < len(replacements):
+			result.WriteRune(replacements[i])
+		}
+	}
+	return result.String(), nil
+}
+
+func booleanFunction(ctx *context, args []expr) (Value, error) {
+	value, err := args[0].evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Boolean(value), nil
+}
+
+func not(ctx *context, args []expr) (Value, error) {
+	value, err := args[0].evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return !Boolean(value), nil
+}
+
+func constantFunction(value Value) func(ctx *context, args []expr) (Value, error) {
+	return func(*context, []expr) (Value, error) {
+		return value, nil
+	}
+}
+
+// lang checks xml:lang attribute of the context node or its nearest ancestor.
+func lang(ctx *context, args []expr) (Value, error) {
+	expected, err := stringArg(ctx, args, 0)
+	if err != nil {
+		return nil, err
+	}
+	for node := ctx.node; node != nil; node = node.Parent {
+		for _, attr := range node.Attributes {
+			if attr.Name.Space == xmldom.XMLNamespace && attr.Name.Local == "lang" {
+				language := strings.ToLower(attr.Data)
+				expected = strings.ToLower(expected)
+				return language == expected || strings.HasPrefix(language, expected+"-"), nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func numberFunction(ctx *context, args []expr) (Value, error) {
+	if len(args) == 0 {
+		return parseNumber(ctx.node.StringValue()), nil
+	}
+	return numberArg(ctx, args, 0)
+}
+
+func sum(ctx *context, args []expr) (Value, error) {
+	nodes, err := evaluateNodes(args[0], ctx, "argument of sum()")
+	if err != nil {
+		return nil, err
+	}
+	total := 0.0
+	for _, node := range nodes {
+		total += parseNumber(node.StringValue())
+	}
+	return total, nil
+}
+
+func numberFunc(f func(float64) float64) func(ctx *context, args []expr) (Value, error) {
+	return func(ctx *context, args []expr) (Value, error) {
+		number, err := numberArg(ctx, args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return f(number), nil
+	}
+}
+
+// round returns the closest integer, halves are rounded towards positive infinity.
+func round(f float64) float64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return f
+	}
+	if f >= -0.5 && f < 0 {
+		return math.Copysign(0, -1)
+	}
+	return math.Floor(f + 0.5)
+}
@@ -0,0 +1,291 @@
+package xpath
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenLiteral
+	tokenVariable
+	tokenNameTest
+	tokenFunction
+	tokenNodeType
+	tokenAxis
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenLeftBracket
+	tokenRightBracket
+	tokenDot
+	tokenDoubleDot
+	tokenAt
+	tokenComma
+	tokenDoubleColon
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q at position %d", t.value, t.pos+1)
+}
+
+var nodeTypes = map[string]bool{
+	"comment":                true,
+	"text":                   true,
+	"processing-instruction": true,
+	"node":                   true,
+}
+
+var operatorNames = map[string]bool{
+	"and": true,
+	"or":  true,
+	"mod": true,
+	"div": true,
+}
+
+// lexer splits the expression into tokens applying disambiguation rules of XPath 1.0:
+// "*" and names "and", "or", "mod", "div" are operators only if there is a preceding token
+// that is not an operator or one of "@", "::", "(", "[" and ",".
+type lexer struct {
+	input  string
+	pos    int
+	tokens []token
+}
+
+func tokenize(input string) ([]token, error) {
+	l := &lexer{input: input}
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.input) {
+			l.tokens = append(l.tokens, token{kind: tokenEOF, pos: l.pos})
+			return l.tokens, nil
+		}
+		if err := l.next(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (l *lexer) next() error {
+	start := l.pos
+	c := l.input[l.pos]
+	switch {
+	case c == '(':
+		l.emit(tokenLeftParen, start, 1)
+	case c == ')':
+		l.emit(tokenRightParen, start, 1)
+	case c == '[':
+		l.emit(tokenLeftBracket, start, 1)
+	case c == ']':
+		l.emit(tokenRightBracket, start, 1)
+	case c == '@':
+		l.emit(tokenAt, start, 1)
+	case c == ',':
+		l.emit(tokenComma, start, 1)
+	case strings.HasPrefix(l.input[start:], "::"):
+		l.emit(tokenDoubleColon, start, 2)
+	case strings.HasPrefix(l.input[start:], ".."):
+		l.emit(tokenDoubleDot, start, 2)
+	case c == '.' && !l.isDigitAt(start+1):
+		l.emit(tokenDot, start, 1)
+	case c == '.' || isDigit(c):
+		l.number()
+	case c == '"' || c == '\'':
+		return l.literal()
+	case c == '*':
+		if l.precedingAllowsOperator() {
+			l.emit(tokenOperator, start, 1)
+		} else {
+			l.emit(tokenNameTest, start, 1)
+		}
+	case c == '$':
+		l.pos++
+		name, err := l.qname()
+		if err != nil {
+			return err
+		}
+		l.tokens = append(l.tokens, token{kind: tokenVariable, value: name, pos: start})
+	default:
+		if operator := l.operator(); operator != "" {
+			l.emit(tokenOperator, start, len(operator))
+			return nil
+		}
+		return l.name()
+	}
+
+	return nil
+}
+
+func (l *lexer) operator() string {
+	for _, operator := range []string{"//", "!=", "<=", ">=", "/", "|", "+", "-", "=", "<", ">"} {
+		if strings.HasPrefix(l.input[l.pos:], operator) {
+			return operator
+		}
+	}
+	return ""
+}
+
+func (l *lexer) emit(kind tokenKind, start, length int) {
+	l.pos = start + length
+	l.tokens = append(l.tokens, token{kind: kind, value: l.input[start:l.pos], pos: start})
+}
+
+func (l *lexer) number() {
+	start := l.pos
+	for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos < len(l.input) && l.input[l.pos] == '.' {
+		l.pos++
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.pos++
+		}
+	}
+	l.tokens = append(l.tokens, token{kind: tokenNumber, value: l.input[start:l.pos], pos: start})
+}
+
+func (l *lexer) literal() error {
+	start := l.pos
+	quote := l.input[l.pos]
+	end := strings.IndexByte(l.input[start+1:], quote)
+	if end < 0 {
+		return fmt.Errorf("unterminated string literal at position %d", start+1)
+	}
+	l.pos = start + end + 2
+	l.tokens = append(l.tokens, token{kind: tokenLiteral, value: l.input[start+1 : l.pos-1], pos: start})
+	return nil
+}
+
+func (l *lexer) name() error {
+	start := l.pos
+	prefix, ok := l.ncname()
+	if !ok {
+		r, _ := utf8.DecodeRuneInString(l.input[l.pos:])
+		return fmt.Errorf("unexpected character %q at position %d", r, start+1)
+	}
+	name := prefix
+	if l.pos+1 < len(l.input) && l.input[l.pos] == ':' && l.input[l.pos+1] != ':' {
+		l.pos++
+		if l.pos < len(l.input) && l.input[l.pos] == '*' {
+			l.pos++
+			name += ":*"
+		} else {
+			local, ok := l.ncname()
+			if !ok {
+				return fmt.Errorf("invalid qualified name at position %d", start+1)
+			}
+			name += ":" + local
+		}
+	}
+
+	kind := tokenNameTest
+	switch {
+	case l.precedingAllowsOperator():
+		if !operatorNames[name] {
+			return fmt.Errorf("unexpected name %q at position %d, operator expected", name, start+1)
+		}
+		kind = tokenOperator
+	case l.lookahead("("):
+		kind = tokenFunction
+		if nodeTypes[name] {
+			kind = tokenNodeType
+		}
+	case l.lookahead("::"):
+		kind = tokenAxis
+	}
+	l.tokens = append(l.tokens, token{kind: kind, value: name, pos: start})
+
+	return nil
+}
+
+func (l *lexer) qname() (string, error) {
+	start := l.pos
+	name, ok := l.ncname()
+	if !ok {
+		return "", fmt.Errorf("invalid name at position %d", start+1)
+	}
+	if l.pos+1 < len(l.input) && l.input[l.pos] == ':' && l.input[l.pos+1] != ':' {
+		l.pos++
+		local, ok := l.ncname()
+		if !ok {
+			return "", fmt.Errorf("invalid qualified name at position %d", start+1)
+		}
+		name += ":" + local
+	}
+	return name, nil
+}
+
+func (l *lexer) ncname() (string, bool) {
+	start := l.pos
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !isNameChar(r, l.pos == start) {
+			break
+		}
+		l.pos += size
+	}
+	return l.input[start:l.pos], l.pos > start
+}
+
+// lookahead reports whether the next non-space characters are equal to s.
+func (l *lexer) lookahead(s string) bool {
+	pos := l.pos
+	for pos < len(l.input) && isSpace(l.input[pos]) {
+		pos++
+	}
+	return strings.HasPrefix(l.input[pos:], s)
+}
+
+func (l *lexer) precedingAllowsOperator() bool {
+	if len(l.tokens) == 0 {
+		return false
+	}
+	switch l.tokens[len(l.tokens)-1].kind {
+	case tokenAt, tokenDoubleColon, tokenLeftParen, tokenLeftBracket, tokenComma, tokenOperator:
+		return false
+	}
+	return true
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.input) && isSpace(l.input[l.pos]) {
+		l.pos++
+	}
+}
+
+func (l *lexer) isDigitAt(pos int) bool {
+	return pos < len(l.input) && isDigit(l.input[pos])
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isNameChar(r rune, first bool) bool {
+	if r == '_' || unicode.IsLetter(r) {
+		return true
+	}
+	if first {
+		return false
+	}
+	return r == '-' || r == '.' || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) ||
+		unicode.Is(unicode.Mc, r) || r == '·'
+}
@@ -0,0 +1,432 @@
+package xpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+)
+
+type axis int
+
+const (
+	axisChild axis = iota
+	axisDescendant
+	axisParent
+	axisAncestor
+	axisFollowingSibling
+	axisPrecedingSibling
+	axisFollowing
+	axisPreceding
+	axisAttribute
+	axisNamespace
+	axisSelf
+	axisDescendantOrSelf
+	axisAncestorOrSelf
+)
+
+var axes = map[string]axis{
+	"child":              axisChild,
+	"descendant":         axisDescendant,
+	"parent":             axisParent,
+	"ancestor":           axisAncestor,
+	"following-sibling":  axisFollowingSibling,
+	"preceding-sibling":  axisPrecedingSibling,
+	"following":          axisFollowing,
+	"preceding":          axisPreceding,
+	"attribute":          axisAttribute,
+	"namespace":          axisNamespace,
+	"self":               axisSelf,
+	"descendant-or-self": axisDescendantOrSelf,
+	"ancestor-or-self":   axisAncestorOrSelf,
+}
+
+// isReverse reports whether nodes of the axis are numbered in the reverse document order.
+func (a axis) isReverse() bool {
+	return a == axisAncestor || a == axisAncestorOrSelf || a == axisPreceding || a == axisPrecedingSibling
+}
+
+// principalType returns the type of the nodes selected by the name test on the axis.
+func (a axis) principalType() xmldom.NodeType {
+	switch a {
+	case axisAttribute:
+		return xmldom.AttributeNode
+	case axisNamespace:
+		return xmldom.NamespaceNode
+	}
+	return xmldom.ElementNode
+}
+
+type parser struct {
+	tokens     []token
+	pos        int
+	namespaces map[string]string
+}
+
+func parse(expression string, namespaces map[string]string) (expr, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, namespaces: namespaces}
+	e, err := p.orExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s", p.peek())
+	}
+
+	return e, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(values ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return false
+	}
+	for _, value := range values {
+		if t.value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, description string) (token, error) {
+	t := p.advance()
+	if t.kind != kind {
+		return t, fmt.Errorf("expected %s, found %s", description, t)
+	}
+	return t, nil
+}
+
+// binary parses left-associative binary expressions with the operators.
+func (p *parser) binary(operand func() (expr, error), build func(op string, left, right expr) expr, operators ...string) (expr, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(operators...) {
+		op := p.advance().value
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = build(op, left, right)
+	}
+	return left, nil
+}
+
+func (p *parser) orExpr() (expr, error) {
+	return p.binary(p.andExpr, newLogical, "or")
+}
+
+func (p *parser) andExpr() (expr, error) {
+	return p.binary(p.equalityExpr, newLogical, "and")
+}
+
+func (p *parser) equalityExpr() (expr, error) {
+	return p.binary(p.relationalExpr, newComparison, "=", "!=")
+}
+
+func (p *parser) relationalExpr() (expr, error) {
+	return p.binary(p.additiveExpr, newComparison, "<", "<=", ">", ">=")
+}
+
+func (p *parser) additiveExpr() (expr, error) {
+	return p.binary(p.multiplicativeExpr, newArithmetic, "+", "-")
+}
+
+func (p *parser) multiplicativeExpr() (expr, error) {
+	return p.binary(p.unaryExpr, newArithmetic, "*", "div", "mod")
+}
+
+func (p *parser) unaryExpr() (expr, error) {
+	if p.isOperator("-") {
+		p.advance()
+		operand, err := p.unaryExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &negation{operand: operand}, nil
+	}
+	return p.unionExpr()
+}
+
+func (p *parser) unionExpr() (expr, error) {
+	return p.binary(p.pathExpr, func(op string, left, right expr) expr {
+		return &union{left: left, right: right}
+	}, "|")
+}
+
+func (p *parser) pathExpr() (expr, error) {
+	switch p.peek().kind {
+	case tokenNumber, tokenLiteral, tokenVariable, tokenFunction, tokenLeftParen:
+		return p.filterPath()
+	}
+	return p.locationPath()
+}
+
+func (p *parser) filterPath() (expr, error) {
+	primary, err := p.primaryExpr()
+	if err != nil {
+		return nil, err
+	}
+	predicates, err := p.predicates()
+	if err != nil {
+		return nil, err
+	}
+	var e expr = primary
+	if len(predicates) > 0 {
+		e = &filter{primary: primary, predicates: predicates}
+	}
+	if !p.isOperator("/", "//") {
+		return e, nil
+	}
+
+	path := &locationPath{start: e}
+	if err := p.relativePath(path); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+func (p *parser) locationPath() (expr, error) {
+	path := &locationPath{}
+	switch {
+	case p.isOperator("/"):
+		p.advance()
+		path.absolute = true
+		if !p.startsStep() {
+			return path, nil
+		}
+	case p.isOperator("//"):
+		p.advance()
+		path.absolute = true
+		path.steps = append(path.steps, &step{axis: axisDescendantOrSelf, test: nodeTest{kind: testNode}})
+	}
+	s, err := p.step()
+	if err != nil {
+		return nil, err
+	}
+	path.steps = append(path.steps, s)
+	if err := p.relativePath(path); err != nil {
+		return nil, err
+	}
+
+	return path, nil
+}
+
+// relativePath parses the rest of steps separated by "/" or "//".
+func (p *parser) relativePath(path *locationPath) error {
+	for p.isOperator("/", "//") {
+		if p.advance().value == "//" {
+			path.steps = append(path.steps, &step{axis: axisDescendantOrSelf, test: nodeTest{kind: testNode}})
+		}
+		s, err := p.step()
+		if err != nil {
+			return err
+		}
+		path.steps = append(path.steps, s)
+	}
+	return nil
+}
+
+func (p *parser) startsStep() bool {
+	switch p.peek().kind {
+	case tokenAxis, tokenAt, tokenNameTest, tokenNodeType, tokenDot, tokenDoubleDot:
+		return true
+	}
+	return false
+}
+
+func (p *parser) step() (*step, error) {
+	switch p.peek().kind {
+	case tokenDot:
+		p.advance()
+		return &step{axis: axisSelf, test: nodeTest{kind: testNode}}, nil
+	case tokenDoubleDot:
+		p.advance()
+		return &step{axis: axisParent, test: nodeTest{kind: testNode}}, nil
+	}
+
+	s := &step{axis: axisChild}
+	switch p.peek().kind {
+	case tokenAt:
+		p.advance()
+		s.axis = axisAttribute
+	case tokenAxis:
+		name := p.advance().value
+		a, ok := axes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown axis %q", name)
+		}
+		s.axis = a
+		p.advance() // "::"
+	}
+
+	test, err := p.nodeTest(s.axis)
+	if err != nil {
+		return nil, err
+	}
+	s.test = test
+	s.predicates, err = p.predicates()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (p *parser) nodeTest(stepAxis axis) (nodeTest, error) {
+	t := p.advance()
+	switch t.kind {
+	case tokenNameTest:
+		return p.nameTest(t.value, stepAxis)
+	case tokenNodeType:
+		if _, err := p.expect(tokenLeftParen, `"("`); err != nil {
+			return nodeTest{}, err
+		}
+		test := nodeTest{}
+		switch t.value {
+		case "node":
+			test.kind = testNode
+		case "text":
+			test.kind = testText
+		case "comment":
+			test.kind = testComment
+		default:
+			test.kind = testProcessingInstruction
+			if p.peek().kind == tokenLiteral {
+				test.local = p.advance().value
+			}
+		}
+		if _, err := p.expect(tokenRightParen, `")"`); err != nil {
+			return nodeTest{}, err
+		}
+		return test, nil
+	}
+
+	return nodeTest{}, fmt.Errorf("expected node test, found %s", t)
+}
+
+// nameTest resolves the prefix of the name. Unprefixed names of elements are in the namespace
+// registered with the empty prefix, if any, while unprefixed names of attributes have no namespace.
+func (p *parser) nameTest(name string, stepAxis axis) (nodeTest, error) {
+	if name == "*" {
+		return nodeTest{kind: testName, local: "*", anySpace: true}, nil
+	}
+	test := nodeTest{kind: testName, local: name}
+	if stepAxis != axisAttribute && stepAxis != axisNamespace {
+		test.space = p.namespaces[""]
+	}
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		uri, err := p.namespace(name[:i])
+		if err != nil {
+			return nodeTest{}, err
+		}
+		test.space = uri
+		test.local = name[i+1:]
+	}
+	return test, nil
+}
+
+func (p *parser) namespace(prefix string) (string, error) {
+	if prefix == "xml" {
+		return xmldom.XMLNamespace, nil
+	}
+	if uri, ok := p.namespaces[prefix]; ok {
+		return uri, nil
+	}
+	return "", fmt.Errorf("namespace prefix %q is not registered", prefix)
+}
+
+func (p *parser) predicates() ([]expr, error) {
+	var predicates []expr
+	for p.peek().kind == tokenLeftBracket {
+		p.advance()
+		predicate, err := p.orExpr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRightBracket, `"]"`); err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+	return predicates, nil
+}
+
+func (p *parser) primaryExpr() (expr, error) {
+	t := p.advance()
+	switch t.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", t)
+		}
+		return &constant{value: value}, nil
+	case tokenLiteral:
+		return &constant{value: t.value}, nil
+	case tokenVariable:
+		return nil, fmt.Errorf("variable $%s is not defined", t.value)
+	case tokenLeftParen:
+		e, err := p.orExpr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRightParen, `")"`); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case tokenFunction:
+		return p.functionCall(t)
+	}
+
+	return nil, fmt.Errorf("unexpected %s", t)
+}
+
+func (p *parser) functionCall(name token) (expr, error) {
+	p.advance() // "("
+	var args []expr
+	if p.peek().kind != tokenRightParen {
+		for {
+			arg, err := p.orExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.advance()
+		}
+	}
+	if _, err := p.expect(tokenRightParen, `")"`); err != nil {
+		return nil, err
+	}
+
+	f, ok := functions[name.value]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s()", name.value)
+	}
+	if len(args) < f.minArgs || f.maxArgs >= 0 && len(args) > f.maxArgs {
+		return nil, fmt.Errorf("function %s() does not accept %d arguments", name.value, len(args))
+	}
+
+	return &functionCall{name: name.value, args: args, call: f.call}, nil
+}
@@ -0,0 +1,133 @@
+// Package xpath implements XPath 1.0 (https://www.w3.org/TR/1999/REC-xpath-19991116/)
+// over documents of xmldom package: all axes, predicates, operators and the core function library.
+// Namespace prefixes used by the expression are resolved by the registered namespaces,
+// unprefixed names match nodes without a namespace unless the default namespace of elements
+// is registered with the empty prefix. Variables are not supported.
+package xpath
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+)
+
+// Value is a result of the expression: NodeSet, string, float64 or bool.
+type Value interface{}
+
+// NodeSet is a set of nodes in the document order.
+type NodeSet []*xmldom.Node
+
+// Expression is a compiled XPath expression.
+type Expression struct {
+	source string
+	root   expr
+}
+
+// Compile parses the expression. Namespaces map prefixes used in the expression to the namespace URIs.
+func Compile(expression string, namespaces map[string]string) (*Expression, error) {
+	root, err := parse(expression, namespaces)
+	if err != nil {
+		return nil, err
+	}
+	return &Expression{source: expression, root: root}, nil
+}
+
+// Evaluate evaluates the expression with the node as a context node.
+func (e *Expression) Evaluate(node *xmldom.Node) (Value, error) {
+	return e.root.evaluate(&context{node: node, position: 1, size: 1})
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// String converts the value into a string by the rules of string() function.
+func String(value Value) string {
+	switch v := value.(type) {
+	case NodeSet:
+		if len(v) == 0 {
+			return ""
+		}
+		return v[0].StringValue()
+	case float64:
+		return formatNumber(v)
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	}
+	return value.(string)
+}
+
+// Number converts the value into a number by the rules of number() function.
+func Number(value Value) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	}
+	return parseNumber(String(value))
+}
+
+// Boolean converts the value into a boolean by the rules of boolean() function.
+func Boolean(value Value) bool {
+	switch v := value.(type) {
+	case NodeSet:
+		return len(v) > 0
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	}
+	return value.(bool)
+}
+
+func formatNumber(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	case f == 0:
+		return "0"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func parseNumber(s string) float64 {
+	s = strings.Trim(s, " \t\r\n")
+	digits := strings.TrimPrefix(s, "-")
+	if digits == "" || digits == "." || strings.Trim(digits, "0123456789.") != "" || strings.Count(digits, ".") > 1 {
+		return math.NaN()
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return f
+}
+
+// sortNodes sorts nodes in the document order and removes duplicates.
+func sortNodes(nodes NodeSet) NodeSet {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Before(nodes[j])
+	})
+	unique := nodes[:0]
+	for i, node := range nodes {
+		if i == 0 || !node.Same(unique[len(unique)-1]) {
+			unique = append(unique, node)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,172 @@
+package xpath_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+	"github.com/muonsoft/api-testing/internal/xpath"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const document = `<?xml version="1.0"?>
+<catalog xmlns:a="urn:atom" xml:lang="en-US">
+	<!-- books -->
+	<book id="b1" price="10.5"><title>Go</title><author>Alan</author><author>Brian</author></book>
+	<book id="b2" price="20"><title>XML</title><author>Tim</author></book>
+	<a:entry><a:title>Atom</a:title></a:entry>
+	<?page break?>
+	<empty/>
+</catalog>`
+
+func TestExpression_Evaluate(t *testing.T) {
+	root, err := xmldom.Parse(strings.NewReader(document))
+	require.NoError(t, err)
+	namespaces := map[string]string{"atom": "urn:atom"}
+
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		// location paths
+		{expression: "/catalog/book/title", want: "Go"},
+		{expression: "//book[2]/title", want: "XML"},
+		{expression: "//book[last()]/@id", want: "b2"},
+		{expression: "//author[. = 'Tim']/../title", want: "XML"},
+		{expression: "//book[@price > 15]/title", want: "XML"},
+		{expression: "//book[author = 'Brian']/@id", want: "b1"},
+		{expression: "(//author)[3]", want: "Tim"},
+		{expression: "//book[1]/author[position() = 2]", want: "Brian"},
+		{expression: "//title[ancestor::book[@id = 'b2']]", want: "XML"},
+		{expression: "//author[2]/preceding-sibling::*[1]", want: "Alan"},
+		{expression: "//author[1]/following-sibling::author", want: "Brian"},
+		{expression: "//book[1]/following::title", want: "XML"},
+		{expression: "//title[. = 'XML']/preceding::author[1]", want: "Brian"},
+		{expression: "//book/ancestor-or-self::*[last()]/@xml:lang", want: "en-US"},
+		{expression: "/descendant::title[2]", want: "XML"},
+		{expression: "/catalog/comment()", want: " books "},
+		{expression: "/catalog/processing-instruction('page')", want: "break"},
+		{expression: "name(/catalog/node()[2])", want: ""},
+		{expression: "/catalog/atom:entry/atom:title", want: "Atom"},
+		{expression: "/catalog/atom:*/atom:title", want: "Atom"},
+		{expression: "name(/catalog/*[3])", want: "a:entry"},
+		{expression: "local-name(/catalog/*[3])", want: "entry"},
+		{expression: "namespace-uri(//atom:title)", want: "urn:atom"},
+		{expression: "count(/catalog/namespace::*)", want: "2"},
+		{expression: "/catalog/namespace::a", want: "urn:atom"},
+		{expression: "count(//book | //book[1] | //title)", want: "4"},
+		{expression: "id('b2 b1')[1]/title", want: "Go"},
+		{expression: "//book[1]/title/text()", want: "Go"},
+		{expression: "count(//@*)", want: "5"},
+		// operators and functions
+		{expression: "1 + 2 * 3 - 4 div 2", want: "5"},
+		{expression: "7 mod 3", want: "1"},
+		{expression: "-(2 + 3)", want: "-5"},
+		{expression: "1 div 0", want: "Infinity"},
+		{expression: "0 div 0", want: "NaN"},
+		{expression: "sum(//book/@price)", want: "30.5"},
+		{expression: "count(//book) = 2 and not(//missing)", want: "true"},
+		{expression: "//book/@price = 20", want: "true"},
+		{expression: "//book/@price != 20", want: "true"},
+		{expression: "//book/@price < 10", want: "false"},
+		{expression: "true() = 'x'", want: "true"},
+		{expression: "concat('a', 1, true())", want: "a1true"},
+		{expression: "substring('12345', 1.5, 2.6)", want: "234"},
+		{expression: "substring('12345', 0, 3)", want: "12"},
+		{expression: "substring('12345', -42, 1 div 0)", want: "12345"},
+		{expression: "substring-before('1999/04/01', '/')", want: "1999"},
+		{expression: "substring-after('1999/04/01', '/')", want: "04/01"},
+		{expression: "string-length('héllo')", want: "5"},
+		{expression: "normalize-space('  a \n b  ')", want: "a b"},
+		{expression: "translate('--aaa--', 'abc-', 'ABC')", want: "AAA"},
+		{expression: "starts-with(//book[1]/title, 'G')", want: "true"},
+		{expression: "contains(//book[2]/title, 'M')", want: "true"},
+		{expression: "round(2.5) + floor(-1.5) + ceiling(1.2)", want: "3"},
+		{expression: "round(-0.4)", want: "0"},
+		{expression: "number(' 12.5 ')", want: "12.5"},
+		{expression: "number('1e3')", want: "NaN"},
+		{expression: "boolean(//empty)", want: "true"},
+		{expression: "string(//empty)", want: ""},
+		{expression: "//book[1][lang('en')]/@id", want: "b1"},
+		{expression: "count(//*[string-length(name()) > 5])", want: "6"},
+		{expression: "//book[title='Go']/author[last()]", want: "Brian"},
+		{expression: "div", want: ""},
+		{expression: "2*3", want: "6"},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			expression, err := xpath.Compile(test.expression, namespaces)
+			require.NoError(t, err)
+
+			value, err := expression.Evaluate(root)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, xpath.String(value))
+		})
+	}
+}
+
+func TestExpression_NodeSet(t *testing.T) {
+	root, err := xmldom.Parse(strings.NewReader(document))
+	require.NoError(t, err)
+	expression, err := xpath.Compile("//author | //title", nil)
+	require.NoError(t, err)
+
+	value, err := expression.Evaluate(root)
+
+	require.NoError(t, err)
+	var values []string
+	for _, node := range value.(xpath.NodeSet) {
+		values = append(values, node.StringValue())
+	}
+	assert.Equal(t, []string{"Go", "Alan", "Brian", "XML", "Tim"}, values)
+}
+
+func TestExpression_DefaultNamespace(t *testing.T) {
+	root, err := xmldom.Parse(strings.NewReader(`<feed xmlns="urn:atom" id="1"><title>Feed</title></feed>`))
+	require.NoError(t, err)
+	expression, err := xpath.Compile("/feed[@id = 1]/title", map[string]string{"": "urn:atom"})
+	require.NoError(t, err)
+
+	value, err := expression.Evaluate(root)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Feed", xpath.String(value))
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		{expression: "/a/", want: `expected node test, found end of expression`},
+		{expression: "//a[1", want: `expected "]", found end of expression`},
+		{expression: "unknown(1)", want: `unknown function unknown()`},
+		{expression: "count()", want: `function count() does not accept 0 arguments`},
+		{expression: "/p:a", want: `namespace prefix "p" is not registered`},
+		{expression: "$var", want: `variable $var is not defined`},
+		{expression: "'abc", want: `unterminated string literal at position 1`},
+		{expression: "a b", want: `unexpected name "b" at position 3, operator expected`},
+		{expression: "foo::a", want: `unknown axis "foo"`},
+		{expression: "1 #", want: `unexpected character '#' at position 3`},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			_, err := xpath.Compile(test.expression, nil)
+
+			assert.EqualError(t, err, test.want)
+		})
+	}
+}
+
+func TestExpression_EvaluationErrors(t *testing.T) {
+	root, err := xmldom.Parse(strings.NewReader(document))
+	require.NoError(t, err)
+	expression, err := xpath.Compile("count('a')", nil)
+	require.NoError(t, err)
+
+	_, err = expression.Evaluate(root)
+
+	assert.EqualError(t, err, "argument of count() must be a node-set, actual is string")
+}
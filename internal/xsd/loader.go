@@ -0,0 +1,803 @@
+package xsd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+)
+
+// Load reads the schema from the file. Included and imported schema documents are loaded
+// from the locations relative to the directory of the file.
+func Load(filename string) (*Schema, error) {
+	l := newLoader()
+	if err := l.loadFile(filename, nil); err != nil {
+		return nil, err
+	}
+	return l.finish()
+}
+
+// Parse parses the schema from the byte slice. Included and imported schema documents
+// are loaded from the locations relative to the working directory.
+func Parse(data []byte) (*Schema, error) {
+	l := newLoader()
+	if err := l.parse(data, "schema.xsd", nil); err != nil {
+		return nil, err
+	}
+	return l.finish()
+}
+
+type loader struct {
+	schema *Schema
+	// loaded holds absolute paths of loaded files
+	loaded map[string]bool
+	// references are resolved after all schema documents are loaded
+	references   []func() error
+	elements     []*element
+	complexTypes []*complexType
+}
+
+// document holds properties of the schema document that are used for its components.
+type document struct {
+	location           string
+	targetNamespace    string
+	elementQualified   bool
+	attributeQualified bool
+	// chameleon is set for included documents without target namespace,
+	// their unqualified references use the target namespace of the including document
+	chameleon bool
+}
+
+func newLoader() *loader {
+	return &loader{schema: newSchema(), loaded: make(map[string]bool)}
+}
+
+// loadFile loads the schema document. The including document is passed for included files.
+func (l *loader) loadFile(filename string, including *document) error {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return err
+	}
+	if l.loaded[path] {
+		return nil
+	}
+	l.loaded[path] = true
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("read schema: %w", err)
+	}
+
+	return l.parse(data, filename, including)
+}
+
+func (l *loader) parse(data []byte, location string, including *document) error {
+	root, err := xmldom.Parse(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf(`schema "%s" has invalid XML: %w`, location, err)
+	}
+	var schema *xmldom.Node
+	for _, child := range root.Children {
+		if child.Type == xmldom.ElementNode {
+			schema = child
+		}
+	}
+	if schema == nil || schema.Name != (xml.Name{Space: Namespace, Local: "schema"}) {
+		return fmt.Errorf(`schema "%s": root element must be xs:schema`, location)
+	}
+
+	doc := &document{
+		location:           location,
+		targetNamespace:    attributeValue(schema, "targetNamespace"),
+		elementQualified:   attributeValue(schema, "elementFormDefault") == "qualified",
+		attributeQualified: attributeValue(schema, "attributeFormDefault") == "qualified",
+	}
+	if including != nil {
+		if doc.targetNamespace == "" {
+			doc.targetNamespace = including.targetNamespace
+			doc.chameleon = true
+		} else if doc.targetNamespace != including.targetNamespace {
+			return fmt.Errorf(`schema "%s": included schema has different target namespace "%s"`, location, doc.targetNamespace)
+		}
+	}
+	if err := l.schemaDocument(doc, schema); err != nil {
+		return fmt.Errorf(`schema "%s": %w`, location, err)
+	}
+
+	return nil
+}
+
+func (l *loader) schemaDocument(doc *document, schema *xmldom.Node) error {
+	for _, node := range children(schema) {
+		var err error
+		switch node.Name.Local {
+		case "include":
+			err = l.loadFile(l.relative(doc, node), doc)
+		case "import":
+			if attributeValue(node, "schemaLocation") != "" {
+				err = l.loadFile(l.relative(doc, node), nil)
+			}
+		case "element":
+			var e *element
+			e, err = l.element(doc, node, true)
+			if err == nil {
+				err = l.register(e.name, "element", l.schema.elements[e.name] != nil)
+				l.schema.elements[e.name] = e
+			}
+		case "attribute":
+			var a *attribute
+			a, err = l.attribute(doc, node, true)
+			if err == nil {
+				l.schema.attributes[a.name] = a
+			}
+		case "complexType", "simpleType":
+			var t typeDefinition
+			if node.Name.Local == "complexType" {
+				t, err = l.complexType(doc, node)
+			} else {
+				t, err = l.simpleType(doc, node)
+			}
+			if err == nil {
+				err = l.register(t.typeName(), "type", l.schema.types[t.typeName()] != nil)
+				l.schema.types[t.typeName()] = t
+			}
+		case "group":
+			err = l.group(doc, node)
+		case "attributeGroup":
+			set := &attributeSet{}
+			name := l.name(doc, node, true)
+			l.schema.attributeGroups[name] = set
+			err = l.attributes(doc, node, set)
+		case "redefine", "override":
+			err = fmt.Errorf("xs:%s is not supported", node.Name.Local)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *loader) register(name xml.Name, kind string, exists bool) error {
+	if exists {
+		return fmt.Errorf(`%s "%s" is already defined`, kind, formatName(name))
+	}
+	return nil
+}
+
+func (l *loader) relative(doc *document, node *xmldom.Node) string {
+	location := attributeValue(node, "schemaLocation")
+	if filepath.IsAbs(location) {
+		return location
+	}
+	return filepath.Join(filepath.Dir(doc.location), location)
+}
+
+// finish resolves references between components and builds effective complex types.
+func (l *loader) finish() (*Schema, error) {
+	for _, resolve := range l.references {
+		if err := resolve(); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range l.elements {
+		for head := e.head; e.typ == nil && head != nil; head = head.head {
+			e.typ = head.typ
+		}
+		if e.typ == nil {
+			e.typ = anyType
+		}
+	}
+	for _, t := range l.complexTypes {
+		if err := t.complete(); err != nil {
+			return nil, err
+		}
+	}
+
+	return l.schema, nil
+}
+
+func (l *loader) later(doc *document, resolve func() error) {
+	l.references = append(l.references, func() error {
+		if err := resolve(); err != nil {
+			return fmt.Errorf(`schema "%s": %w`, doc.location, err)
+		}
+		return nil
+	})
+}
+
+func (l *loader) element(doc *document, node *xmldom.Node, global bool) (*element, error) {
+	e := &element{
+		name:     l.name(doc, node, global || qualified(node, doc.elementQualified)),
+		nillable: isTrue(attributeValue(node, "nillable")),
+		abstract: isTrue(attributeValue(node, "abstract")),
+	}
+	e.fixed, e.defaultValue = optionalValue(node, "fixed"), optionalValue(node, "default")
+	l.elements = append(l.elements, e)
+
+	if err := l.typeOf(doc, node, func(t typeDefinition) { e.typ = t }); err != nil {
+		return nil, err
+	}
+	if head := attributeValue(node, "substitutionGroup"); head != "" && global {
+		name, err := l.qname(doc, node, head)
+		if err != nil {
+			return nil, err
+		}
+		l.later(doc, func() error {
+			h, ok := l.schema.elements[name]
+			if !ok {
+				return fmt.Errorf(`element "%s" is not defined`, formatName(name))
+			}
+			e.head = h
+			l.schema.substitutes[name] = append(l.schema.substitutes[name], e)
+			return nil
+		})
+	}
+
+	return e, nil
+}
+
+// typeOf resolves the type set by the "type" attribute or by the anonymous type definition.
+func (l *loader) typeOf(doc *document, node *xmldom.Node, set func(t typeDefinition)) error {
+	if typeName := attributeValue(node, "type"); typeName != "" {
+		name, err := l.qname(doc, node, typeName)
+		if err != nil {
+			return err
+		}
+		l.later(doc, func() error {
+			t, ok := l.schema.typeByName(name)
+			if !ok {
+				return fmt.Errorf(`type "%s" is not defined`, formatName(name))
+			}
+			set(t)
+			return nil
+		})
+		return nil
+	}
+	for _, child := range children(node) {
+		var t typeDefinition
+		var err error
+		switch child.Name.Local {
+		case "complexType":
+			t, err = l.complexType(doc, child)
+		case "simpleType":
+			t, err = l.simpleType(doc, child)
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		set(t)
+	}
+	return nil
+}
+
+func (l *loader) particle(doc *document, node *xmldom.Node) (*particle, error) {
+	p := &particle{}
+	var err error
+	p.minOccurs, p.maxOccurs, err = occurs(node)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node.Name.Local {
+	case "element":
+		p.kind = particleElement
+		if ref := attributeValue(node, "ref"); ref != "" {
+			name, err := l.qname(doc, node, ref)
+			if err != nil {
+				return nil, err
+			}
+			l.later(doc, func() error {
+				e, ok := l.schema.elements[name]
+				if !ok {
+					return fmt.Errorf(`element "%s" is not defined`, formatName(name))
+				}
+				p.element = e
+				return nil
+			})
+			return p, nil
+		}
+		p.element, err = l.element(doc, node, false)
+		return p, err
+	case "any":
+		p.kind = particleAny
+		p.wildcard = newWildcard(doc, node)
+		return p, nil
+	case "group":
+		name, err := l.qname(doc, node, attributeValue(node, "ref"))
+		if err != nil {
+			return nil, err
+		}
+		l.later(doc, func() error {
+			group, ok := l.schema.groups[name]
+			if !ok {
+				return fmt.Errorf(`group "%s" is not defined`, formatName(name))
+			}
+			p.kind, p.children = group.kind, group.children
+			return nil
+		})
+		return p, nil
+	case "sequence":
+		p.kind = particleSequence
+	case "choice":
+		p.kind = particleChoice
+	case "all":
+		p.kind = particleAll
+	default:
+		return nil, fmt.Errorf("unexpected xs:%s in content model", node.Name.Local)
+	}
+
+	for _, child := range children(node) {
+		c, err := l.particle(doc, child)
+		if err != nil {
+			return nil, err
+		}
+		p.children = append(p.children, c)
+	}
+	return p, nil
+}
+
+func (l *loader) group(doc *document, node *xmldom.Node) error {
+	name := l.name(doc, node, true)
+	for _, child := range children(node) {
+		p, err := l.particle(doc, child)
+		if err != nil {
+			return err
+		}
+		if err := l.register(name, "group", l.schema.groups[name] != nil); err != nil {
+			return err
+		}
+		l.schema.groups[name] = p
+	}
+	return nil
+}
+
+func newWildcard(doc *document, node *xmldom.Node) *wildcard {
+	w := &wildcard{targetNamespace: doc.targetNamespace, process: attributeValue(node, "processContents")}
+	if w.process == "" {
+		w.process = "strict"
+	}
+	namespace := attributeValue(node, "namespace")
+	switch namespace {
+	case "", "##any":
+		w.any = true
+	case "##other":
+		w.other = true
+	default:
+		w.namespaces = make(map[string]bool)
+		for _, uri := range strings.Fields(namespace) {
+			switch uri {
+			case "##targetNamespace":
+				w.namespaces[doc.targetNamespace] = true
+			case "##local":
+				w.namespaces[""] = true
+			default:
+				w.namespaces[uri] = true
+			}
+		}
+	}
+	return w
+}
+
+func (l *loader) complexType(doc *document, node *xmldom.Node) (*complexType, error) {
+	t := &complexType{
+		abstract: isTrue(attributeValue(node, "abstract")),
+		mixed:    isTrue(attributeValue(node, "mixed")),
+	}
+	if attributeValue(node, "name") != "" {
+		t.name = l.name(doc, node, true)
+	}
+	l.complexTypes = append(l.complexTypes, t)
+
+	for _, child := range children(node) {
+		switch child.Name.Local {
+		case "simpleContent", "complexContent":
+			t.simple = child.Name.Local == "simpleContent"
+			if isTrue(attributeValue(child, "mixed")) {
+				t.mixed = true
+			}
+			for _, d := range children(child) {
+				if err := l.derivation(doc, t, d); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			if err := l.content(doc, t, child); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return t, nil
+}
+
+// derivation reads xs:extension or xs:restriction of simple or complex content.
+func (l *loader) derivation(doc *document, t *complexType, node *xmldom.Node) error {
+	switch node.Name.Local {
+	case "extension":
+		t.derivation = derivationExtension
+	case "restriction":
+		t.derivation = derivationRestriction
+	default:
+		return fmt.Errorf("unexpected xs:%s in type derivation", node.Name.Local)
+	}
+	base, err := l.qname(doc, node, attributeValue(node, "base"))
+	if err != nil {
+		return err
+	}
+	l.later(doc, func() error {
+		b, ok := l.schema.typeByName(base)
+		if !ok {
+			return fmt.Errorf(`type "%s" is not defined`, formatName(base))
+		}
+		t.base = b
+		return nil
+	})
+
+	for _, child := range children(node) {
+		if isFacet(child.Name.Local) {
+			if err := readFacet(&t.facets, child); err != nil {
+				return err
+			}
+			continue
+		}
+		if child.Name.Local == "simpleType" {
+			continue
+		}
+		if err := l.content(doc, t, child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// content reads particles and attributes of complex type.
+func (l *loader) content(doc *document, t *complexType, node *xmldom.Node) error {
+	switch node.Name.Local {
+	case "sequence", "choice", "all", "group":
+		p, err := l.particle(doc, node)
+		if err != nil {
+			return err
+		}
+		t.content = p
+		return nil
+	}
+	return l.attributeDeclaration(doc, &t.attributes, node)
+}
+
+// attributes reads attribute declarations of the attribute group.
+func (l *loader) attributes(doc *document, node *xmldom.Node, set *attributeSet) error {
+	for _, child := range children(node) {
+		if err := l.attributeDeclaration(doc, set, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *loader) attributeDeclaration(doc *document, set *attributeSet, node *xmldom.Node) error {
+	switch node.Name.Local {
+	case "attribute":
+		use, err := l.attributeUse(doc, node)
+		if err != nil {
+			return err
+		}
+		set.uses = append(set.uses, use)
+	case "attributeGroup":
+		name, err := l.qname(doc, node, attributeValue(node, "ref"))
+		if err != nil {
+			return err
+		}
+		l.later(doc, func() error {
+			group, ok := l.schema.attributeGroups[name]
+			if !ok {
+				return fmt.Errorf(`attribute group "%s" is not defined`, formatName(name))
+			}
+			set.groups = append(set.groups, group)
+			return nil
+		})
+	case "anyAttribute":
+		set.anyAttribute = newWildcard(doc, node)
+	default:
+		return fmt.Errorf("unexpected xs:%s in complex type", node.Name.Local)
+	}
+	return nil
+}
+
+func (l *loader) attributeUse(doc *document, node *xmldom.Node) (*attributeUse, error) {
+	use := &attributeUse{
+		required:   attributeValue(node, "use") == "required",
+		prohibited: attributeValue(node, "use") == "prohibited",
+		fixed:      optionalValue(node, "fixed"),
+	}
+	if ref := attributeValue(node, "ref"); ref != "" {
+		name, err := l.qname(doc, node, ref)
+		if err != nil {
+			return nil, err
+		}
+		use.attribute = &attribute{name: name}
+		l.later(doc, func() error {
+			a, ok := l.schema.attributes[name]
+			if !ok {
+				return fmt.Errorf(`attribute "%s" is not defined`, formatName(name))
+			}
+			use.attribute = a
+			return nil
+		})
+		return use, nil
+	}
+
+	a, err := l.attribute(doc, node, false)
+	use.attribute = a
+	return use, err
+}
+
+func (l *loader) attribute(doc *document, node *xmldom.Node, global bool) (*attribute, error) {
+	a := &attribute{
+		name:         l.name(doc, node, global || qualified(node, doc.attributeQualified)),
+		typ:          builtinTypes["anySimpleType"],
+		fixed:        optionalValue(node, "fixed"),
+		defaultValue: optionalValue(node, "default"),
+	}
+	err := l.typeOf(doc, node, func(t typeDefinition) {
+		if s, ok := t.(*simpleType); ok {
+			a.typ = s
+		}
+	})
+	return a, err
+}
+
+func (l *loader) simpleType(doc *document, node *xmldom.Node) (*simpleType, error) {
+	t := &simpleType{}
+	if attributeValue(node, "name") != "" {
+		t.name = l.name(doc, node, true)
+	}
+
+	for _, child := range children(node) {
+		var err error
+		switch child.Name.Local {
+		case "restriction":
+			err = l.restriction(doc, t, child)
+		case "list":
+			t.variety = varietyList
+			err = l.simpleTypeReference(doc, child, "itemType", func(s *simpleType) { t.itemType = s })
+		case "union":
+			t.variety = varietyUnion
+			err = l.union(doc, t, child)
+		default:
+			err = fmt.Errorf("unexpected xs:%s in simple type", child.Name.Local)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func (l *loader) restriction(doc *document, t *simpleType, node *xmldom.Node) error {
+	err := l.simpleTypeReference(doc, node, "base", func(s *simpleType) { t.base = s })
+	if err != nil {
+		return err
+	}
+	for _, child := range children(node) {
+		if isFacet(child.Name.Local) {
+			if err := readFacet(&t.facets, child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (l *loader) union(doc *document, t *simpleType, node *xmldom.Node) error {
+	for _, member := range strings.Fields(attributeValue(node, "memberTypes")) {
+		name, err := l.qname(doc, node, member)
+		if err != nil {
+			return err
+		}
+		index := len(t.members)
+		t.members = append(t.members, nil)
+		l.later(doc, func() error {
+			s, err := l.simpleTypeByName(name)
+			t.members[index] = s
+			return err
+		})
+	}
+	for _, child := range children(node) {
+		if child.Name.Local == "simpleType" {
+			s, err := l.simpleType(doc, child)
+			if err != nil {
+				return err
+			}
+			t.members = append(t.members, s)
+		}
+	}
+	return nil
+}
+
+// simpleTypeReference resolves the simple type set by the attribute or by the anonymous simple type.
+func (l *loader) simpleTypeReference(doc *document, node *xmldom.Node, attributeName string, set func(s *simpleType)) error {
+	if value := attributeValue(node, attributeName); value != "" {
+		name, err := l.qname(doc, node, value)
+		if err != nil {
+			return err
+		}
+		l.later(doc, func() error {
+			s, err := l.simpleTypeByName(name)
+			set(s)
+			return err
+		})
+		return nil
+	}
+	for _, child := range children(node) {
+		if child.Name.Local == "simpleType" {
+			s, err := l.simpleType(doc, child)
+			if err != nil {
+				return err
+			}
+			set(s)
+			return nil
+		}
+	}
+	return fmt.Errorf(`xs:%s must have "%s" attribute or anonymous simple type`, node.Name.Local, attributeName)
+}
+
+func (l *loader) simpleTypeByName(name xml.Name) (*simpleType, error) {
+	t, ok := l.schema.typeByName(name)
+	if !ok {
+		return nil, fmt.Errorf(`type "%s" is not defined`, formatName(name))
+	}
+	s, ok := t.(*simpleType)
+	if !ok {
+		return nil, fmt.Errorf(`type "%s" is not a simple type`, formatName(name))
+	}
+	return s, nil
+}
+
+func isFacet(name string) bool {
+	switch name {
+	case "enumeration", "pattern", "length", "minLength", "maxLength", "minInclusive", "maxInclusive",
+		"minExclusive", "maxExclusive", "totalDigits", "fractionDigits", "whiteSpace":
+		return true
+	}
+	return false
+}
+
+func readFacet(f *facets, node *xmldom.Node) error {
+	value := attributeValue(node, "value")
+	switch node.Name.Local {
+	case "enumeration":
+		f.enumeration = append(f.enumeration, value)
+	case "pattern":
+		rx, err := compilePattern(value)
+		if err != nil {
+			return err
+		}
+		f.patterns = append(f.patterns, rx)
+		f.patternSources = append(f.patternSources, value)
+	case "minInclusive":
+		f.minInclusive = &value
+	case "maxInclusive":
+		f.maxInclusive = &value
+	case "minExclusive":
+		f.minExclusive = &value
+	case "maxExclusive":
+		f.maxExclusive = &value
+	case "whiteSpace":
+		f.whiteSpace = value
+	default:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf(`xs:%s has invalid value "%s"`, node.Name.Local, value)
+		}
+		switch node.Name.Local {
+		case "length":
+			f.length = &n
+		case "minLength":
+			f.minLength = &n
+		case "maxLength":
+			f.maxLength = &n
+		case "totalDigits":
+			f.totalDigits = &n
+		case "fractionDigits":
+			f.fractionDigits = &n
+		}
+	}
+	return nil
+}
+
+// name returns the name of the declared component, qualified names use the target namespace.
+func (l *loader) name(doc *document, node *xmldom.Node, isQualified bool) xml.Name {
+	name := xml.Name{Local: attributeValue(node, "name")}
+	if isQualified {
+		name.Space = doc.targetNamespace
+	}
+	return name
+}
+
+// qname resolves the prefix of the reference to the component by namespace declarations of the schema.
+func (l *loader) qname(doc *document, node *xmldom.Node, value string) (xml.Name, error) {
+	prefix, local := "", value
+	if i := strings.IndexByte(value, ':'); i >= 0 {
+		prefix, local = value[:i], value[i+1:]
+	}
+	if local == "" {
+		return xml.Name{}, fmt.Errorf(`xs:%s has invalid reference "%s"`, node.Name.Local, value)
+	}
+	uri, ok := node.LookupNamespace(prefix)
+	if !ok && prefix != "" {
+		return xml.Name{}, fmt.Errorf(`namespace prefix "%s" is not declared`, prefix)
+	}
+	if uri == "" && doc.chameleon {
+		uri = doc.targetNamespace
+	}
+	return xml.Name{Space: uri, Local: local}, nil
+}
+
+func qualified(node *xmldom.Node, byDefault bool) bool {
+	switch attributeValue(node, "form") {
+	case "qualified":
+		return true
+	case "unqualified":
+		return false
+	}
+	return byDefault
+}
+
+// children returns child elements of the schema component except annotations.
+func children(node *xmldom.Node) []*xmldom.Node {
+	var elements []*xmldom.Node
+	for _, child := range node.Children {
+		if child.Type == xmldom.ElementNode && child.Name.Space == Namespace && child.Name.Local != "annotation" {
+			elements = append(elements, child)
+		}
+	}
+	return elements
+}
+
+func attributeValue(node *xmldom.Node, name string) string {
+	value := optionalValue(node, name)
+	if value == nil {
+		return ""
+	}
+	return strings.TrimSpace(*value)
+}
+
+func optionalValue(node *xmldom.Node, name string) *string {
+	for _, attribute := range node.Attributes {
+		if attribute.Name.Space == "" && attribute.Name.Local == name {
+			return &attribute.Data
+		}
+	}
+	return nil
+}
+
+func isTrue(value string) bool {
+	return value == "true" || value == "1"
+}
+
+func occurs(node *xmldom.Node) (minOccurs, maxOccurs int, err error) {
+	minOccurs, maxOccurs = 1, 1
+	if value := attributeValue(node, "minOccurs"); value != "" {
+		if minOccurs, err = strconv.Atoi(value); err != nil || minOccurs < 0 {
+			return 0, 0, fmt.Errorf(`minOccurs has invalid value "%s"`, value)
+		}
+	}
+	if value := attributeValue(node, "maxOccurs"); value == "unbounded" {
+		maxOccurs = -1
+	} else if value != "" {
+		if maxOccurs, err = strconv.Atoi(value); err != nil || maxOccurs < 0 {
+			return 0, 0, fmt.Errorf(`maxOccurs has invalid value "%s"`, value)
+		}
+	}
+	return minOccurs, maxOccurs, nil
+}
@@ -0,0 +1,78 @@
+package xsd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Classes of XML names are not available in Go regular expressions, so they are approximated
+// by Unicode categories.
+var escapes = map[byte]struct{ outside, inside string }{
+	'i': {`[\p{L}_:]`, `\p{L}_:`},
+	'I': {`[^\p{L}_:]`, ""},
+	'c': {`[\p{L}\p{N}\p{M}._:\-]`, `\p{L}\p{N}\p{M}._:\-`},
+	'C': {`[^\p{L}\p{N}\p{M}._:\-]`, ""},
+	'd': {`\p{Nd}`, `\p{Nd}`},
+	'D': {`\P{Nd}`, `\P{Nd}`},
+	'w': {`[\p{L}\p{M}\p{N}\p{S}]`, `\p{L}\p{M}\p{N}\p{S}`},
+	'W': {`[^\p{L}\p{M}\p{N}\p{S}]`, ""},
+}
+
+// compilePattern translates the regular expression of XML Schema into Go syntax.
+// Expressions of XML Schema are implicitly anchored and have no "^" and "$" anchors.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	var s strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			next := pattern[i]
+			escape, ok := escapes[next]
+			switch {
+			case !ok:
+				s.WriteByte('\\')
+				s.WriteByte(next)
+			case !inClass:
+				s.WriteString(escape.outside)
+			case escape.inside != "":
+				s.WriteString(escape.inside)
+			default:
+				return nil, fmt.Errorf(`pattern "%s": escape \%c in character class is not supported`, pattern, next)
+			}
+		case c == '[' && !inClass:
+			inClass = true
+			s.WriteByte(c)
+			if strings.HasPrefix(pattern[i+1:], "^") {
+				s.WriteByte('^')
+				i++
+			}
+		case c == '[':
+			return nil, fmt.Errorf(`pattern "%s": character class subtraction is not supported`, pattern)
+		case c == ']' && inClass:
+			inClass = false
+			s.WriteByte(c)
+		case inClass:
+			s.WriteByte(c)
+		case c == '^' || c == '$':
+			s.WriteByte('\\')
+			s.WriteByte(c)
+		case c == '.':
+			s.WriteString(`[^\n\r]`)
+		default:
+			s.WriteByte(c)
+		}
+	}
+	if inClass {
+		return nil, errors.New(`pattern "` + pattern + `": character class is not closed`)
+	}
+
+	rx, err := regexp.Compile(`^(?:` + s.String() + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf(`pattern "%s": %w`, pattern, err)
+	}
+	return rx, nil
+}
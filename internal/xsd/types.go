@@ -0,0 +1,535 @@
+package xsd
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+)
+
+type variety int
+
+const (
+	varietyAtomic variety = iota
+	varietyList
+	varietyUnion
+)
+
+type simpleType struct {
+	name xml.Name
+	// variety is set for list and union types, restrictions inherit variety of the base type
+	variety  variety
+	base     *simpleType
+	builtin  *builtin
+	itemType *simpleType
+	members  []*simpleType
+	facets   facets
+}
+
+type facets struct {
+	enumeration    []string
+	patterns       []*regexp.Regexp
+	patternSources []string
+	length         *int
+	minLength      *int
+	maxLength      *int
+	minInclusive   *string
+	maxInclusive   *string
+	minExclusive   *string
+	maxExclusive   *string
+	totalDigits    *int
+	fractionDigits *int
+	whiteSpace     string
+}
+
+func (t *simpleType) typeName() xml.Name {
+	return t.name
+}
+
+func (t *simpleType) kind() variety {
+	for ; t != nil; t = t.base {
+		if t.variety != varietyAtomic {
+			return t.variety
+		}
+	}
+	return varietyAtomic
+}
+
+// primitive returns the built-in type the atomic type is derived from.
+func (t *simpleType) primitive() *builtin {
+	for ; t != nil; t = t.base {
+		if t.builtin != nil {
+			return t.builtin
+		}
+	}
+	return builtinTypes["anySimpleType"].builtin
+}
+
+func (t *simpleType) whiteSpace() string {
+	for ; t != nil; t = t.base {
+		switch {
+		case t.facets.whiteSpace != "":
+			return t.facets.whiteSpace
+		case t.variety == varietyList:
+			return "collapse"
+		case t.variety == varietyUnion:
+			return "preserve"
+		case t.builtin != nil:
+			return t.builtin.whiteSpace
+		}
+	}
+	return "preserve"
+}
+
+// validate checks the value and returns the description of the violation or empty string.
+// The context node is used to resolve prefixes of QName values.
+func (t *simpleType) validate(value string, context *xmldom.Node) string {
+	return t.check(normalize(value, t.whiteSpace()), context)
+}
+
+func (t *simpleType) check(value string, context *xmldom.Node) string {
+	switch t.variety {
+	case varietyList:
+		for _, item := range strings.Fields(value) {
+			if message := t.itemType.validate(item, context); message != "" {
+				return message
+			}
+		}
+	case varietyUnion:
+		if !t.matchesMember(value, context) {
+			return fmt.Sprintf(`must match one of the member types of the union, actual is "%s"`, value)
+		}
+	}
+	if t.builtin != nil && !t.builtin.check(value, context) {
+		return fmt.Sprintf(`must be of type xs:%s, actual is "%s"`, t.builtin.name, value)
+	}
+	if t.base != nil {
+		if message := t.base.check(value, context); message != "" {
+			return message
+		}
+	}
+
+	return t.checkFacets(value)
+}
+
+func (t *simpleType) matchesMember(value string, context *xmldom.Node) bool {
+	for _, member := range t.members {
+		if member.validate(value, context) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *simpleType) checkFacets(value string) string {
+	f := &t.facets
+	if len(f.enumeration) > 0 && !t.isEnumerated(value) {
+		return fmt.Sprintf(`must be one of %s, actual is "%s"`, quoteAll(f.enumeration), value)
+	}
+	if len(f.patterns) > 0 && !matchesAny(f.patterns, value) {
+		return fmt.Sprintf(`must match pattern "%s", actual is "%s"`, strings.Join(f.patternSources, "|"), value)
+	}
+	if message := t.checkLength(value); message != "" {
+		return message
+	}
+	if message := t.checkBounds(value); message != "" {
+		return message
+	}
+
+	return t.checkDigits(value)
+}
+
+func (t *simpleType) isEnumerated(value string) bool {
+	primitive := t.primitive()
+	for _, enumerated := range t.facets.enumeration {
+		if primitive.compare != nil {
+			if c, ok := primitive.compare(value, enumerated); ok && c == 0 {
+				return true
+			}
+		} else if value == normalize(enumerated, t.whiteSpace()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *simpleType) checkLength(value string) string {
+	f := &t.facets
+	if f.length == nil && f.minLength == nil && f.maxLength == nil {
+		return ""
+	}
+	length := t.lengthOf(value)
+	switch {
+	case f.length != nil && length != *f.length:
+		return fmt.Sprintf(`must have length %d, actual is %d`, *f.length, length)
+	case f.minLength != nil && length < *f.minLength:
+		return fmt.Sprintf(`must have length greater than or equal to %d, actual is %d`, *f.minLength, length)
+	case f.maxLength != nil && length > *f.maxLength:
+		return fmt.Sprintf(`must have length less than or equal to %d, actual is %d`, *f.maxLength, length)
+	}
+	return ""
+}
+
+// lengthOf returns length in units defined for the type: list items, octets of binary data or characters.
+func (t *simpleType) lengthOf(value string) int {
+	if t.kind() == varietyList {
+		return len(strings.Fields(value))
+	}
+	switch t.primitive().name {
+	case "hexBinary":
+		return len(value) / 2
+	case "base64Binary":
+		data, _ := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+		return len(data)
+	}
+	return utf8.RuneCountInString(value)
+}
+
+func (t *simpleType) checkBounds(value string) string {
+	f := &t.facets
+	compare := t.primitive().compare
+	if compare == nil {
+		return ""
+	}
+	bounds := []struct {
+		limit   *string
+		valid   func(c int) bool
+		message string
+	}{
+		{f.minInclusive, func(c int) bool { return c >= 0 }, "greater than or equal to"},
+		{f.maxInclusive, func(c int) bool { return c <= 0 }, "less than or equal to"},
+		{f.minExclusive, func(c int) bool { return c > 0 }, "greater than"},
+		{f.maxExclusive, func(c int) bool { return c < 0 }, "less than"},
+	}
+	for _, bound := range bounds {
+		if bound.limit == nil {
+			continue
+		}
+		if c, ok := compare(value, *bound.limit); ok && !bound.valid(c) {
+			return fmt.Sprintf(`must be %s %s, actual is %s`, bound.message, *bound.limit, value)
+		}
+	}
+	return ""
+}
+
+func (t *simpleType) checkDigits(value string) string {
+	f := &t.facets
+	if f.totalDigits == nil && f.fractionDigits == nil {
+		return ""
+	}
+	total, fraction := countDigits(value)
+	switch {
+	case f.totalDigits != nil && total > *f.totalDigits:
+		return fmt.Sprintf(`must have at most %d digits, actual is %d`, *f.totalDigits, total)
+	case f.fractionDigits != nil && fraction > *f.fractionDigits:
+		return fmt.Sprintf(`must have at most %d fraction digits, actual is %d`, *f.fractionDigits, fraction)
+	}
+	return ""
+}
+
+// countDigits counts significant digits of the decimal value: leading zeros of the integer part
+// and trailing zeros of the fraction part are not counted.
+func countDigits(value string) (total, fraction int) {
+	value = strings.TrimLeft(value, "+-")
+	integer, fractional := value, ""
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		integer, fractional = value[:i], value[i+1:]
+	}
+	integer = strings.TrimLeft(integer, "0")
+	fractional = strings.TrimRight(fractional, "0")
+	return len(integer) + len(fractional), len(fractional)
+}
+
+func normalize(value, whiteSpace string) string {
+	switch whiteSpace {
+	case "replace":
+		return strings.Map(func(r rune) rune {
+			if r == '\t' || r == '\n' || r == '\r' {
+				return ' '
+			}
+			return r
+		}, value)
+	case "collapse":
+		return strings.Join(strings.Fields(value), " ")
+	}
+	return value
+}
+
+func matchesAny(patterns []*regexp.Regexp, value string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// builtin describes a built-in simple type of XML Schema.
+type builtin struct {
+	name       string
+	whiteSpace string
+	check      func(value string, context *xmldom.Node) bool
+	// compare is used by enumeration and range facets of ordered types
+	compare func(a, b string) (int, bool)
+}
+
+var builtinTypes = map[string]*simpleType{}
+
+func init() {
+	patterns := map[string]string{
+		"language":   `[a-zA-Z]{1,8}(-[a-zA-Z0-9]{1,8})*`,
+		"Name":       `[\p{L}_:][\p{L}\p{N}\p{M}._:\-]*`,
+		"NCName":     `[\p{L}_][\p{L}\p{N}\p{M}._\-]*`,
+		"NMTOKEN":    `[\p{L}\p{N}\p{M}._:\-]+`,
+		"boolean":    `true|false|1|0`,
+		"decimal":    `[+\-]?(\d+(\.\d*)?|\.\d+)`,
+		"integer":    `[+\-]?\d+`,
+		"float":      `[+\-]?(\d+(\.\d*)?|\.\d+)([eE][+\-]?\d+)?|[+\-]?INF|NaN`,
+		"dateTime":   `-?\d{4,}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?` + timezonePattern,
+		"date":       `-?\d{4,}-\d{2}-\d{2}` + timezonePattern,
+		"time":       `\d{2}:\d{2}:\d{2}(\.\d+)?` + timezonePattern,
+		"gYear":      `-?\d{4,}` + timezonePattern,
+		"gYearMonth": `-?\d{4,}-\d{2}` + timezonePattern,
+		"gMonth":     `--\d{2}` + timezonePattern,
+		"gMonthDay":  `--\d{2}-\d{2}` + timezonePattern,
+		"gDay":       `---\d{2}` + timezonePattern,
+		"duration":   `-?P(\d+Y)?(\d+M)?(\d+D)?(T(\d+H)?(\d+M)?(\d+(\.\d+)?S)?)?`,
+		"hexBinary":  `([0-9a-fA-F]{2})*`,
+	}
+	lexical := func(name string) func(string, *xmldom.Node) bool {
+		rx := regexp.MustCompile(`^(?:` + patterns[name] + `)$`)
+		return func(value string, _ *xmldom.Node) bool {
+			return rx.MatchString(value)
+		}
+	}
+	anyValue := func(string, *xmldom.Node) bool { return true }
+
+	define := func(name, whiteSpace string, check func(string, *xmldom.Node) bool, compare func(a, b string) (int, bool)) {
+		builtinTypes[name] = &simpleType{
+			name:    xml.Name{Space: Namespace, Local: name},
+			builtin: &builtin{name: name, whiteSpace: whiteSpace, check: check, compare: compare},
+		}
+	}
+
+	define("anySimpleType", "preserve", anyValue, nil)
+	define("string", "preserve", anyValue, nil)
+	define("normalizedString", "replace", anyValue, nil)
+	define("token", "collapse", anyValue, nil)
+	define("anyURI", "collapse", isURI, nil)
+	define("base64Binary", "collapse", isBase64, nil)
+	define("hexBinary", "collapse", lexical("hexBinary"), nil)
+	define("boolean", "collapse", lexical("boolean"), nil)
+	define("decimal", "collapse", lexical("decimal"), compareDecimals)
+	define("float", "collapse", lexical("float"), compareFloats)
+	define("double", "collapse", lexical("float"), compareFloats)
+	define("duration", "collapse", isDuration(lexical("duration")), nil)
+	define("QName", "collapse", isQName, nil)
+	define("NOTATION", "collapse", isQName, nil)
+	for _, name := range []string{"language", "Name", "NCName", "NMTOKEN"} {
+		define(name, "collapse", lexical(name), nil)
+	}
+	for _, name := range []string{"ID", "IDREF", "ENTITY"} {
+		define(name, "collapse", lexical("NCName"), nil)
+	}
+	for _, layout := range []struct{ name, layout string }{
+		{"dateTime", "2006-01-02T15:04:05"},
+		{"date", "2006-01-02"},
+		{"time", "15:04:05"},
+	} {
+		define(layout.name, "collapse", isTime(lexical(layout.name), layout.layout), compareTimes(layout.layout))
+	}
+	for _, name := range []string{"gYear", "gYearMonth", "gMonth", "gMonthDay", "gDay"} {
+		define(name, "collapse", lexical(name), nil)
+	}
+
+	integers := []struct {
+		name         string
+		lower, upper string
+	}{
+		{"integer", "", ""},
+		{"nonPositiveInteger", "", "0"},
+		{"negativeInteger", "", "-1"},
+		{"nonNegativeInteger", "0", ""},
+		{"positiveInteger", "1", ""},
+		{"long", "-9223372036854775808", "9223372036854775807"},
+		{"int", "-2147483648", "2147483647"},
+		{"short", "-32768", "32767"},
+		{"byte", "-128", "127"},
+		{"unsignedLong", "0", "18446744073709551615"},
+		{"unsignedInt", "0", "4294967295"},
+		{"unsignedShort", "0", "65535"},
+		{"unsignedByte", "0", "255"},
+	}
+	for _, integer := range integers {
+		define(integer.name, "collapse", isInteger(lexical("integer"), integer.lower, integer.upper), compareDecimals)
+	}
+
+	for name, item := range map[string]string{"IDREFS": "IDREF", "ENTITIES": "ENTITY", "NMTOKENS": "NMTOKEN"} {
+		minLength := 1
+		builtinTypes[name] = &simpleType{
+			name: xml.Name{Space: Namespace, Local: name},
+			base: &simpleType{variety: varietyList, itemType: builtinTypes[item]},
+			facets: facets{
+				minLength: &minLength,
+			},
+		}
+	}
+}
+
+const timezonePattern = `(Z|[+\-]\d{2}:\d{2})?`
+
+func isURI(value string, _ *xmldom.Node) bool {
+	_, err := url.Parse(value)
+	return err == nil
+}
+
+func isBase64(value string, _ *xmldom.Node) bool {
+	_, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+	return err == nil
+}
+
+func isDuration(lexical func(string, *xmldom.Node) bool) func(string, *xmldom.Node) bool {
+	return func(value string, context *xmldom.Node) bool {
+		return lexical(value, context) && !strings.HasSuffix(value, "P") && !strings.HasSuffix(value, "T")
+	}
+}
+
+func isQName(value string, context *xmldom.Node) bool {
+	prefix, local := "", value
+	if i := strings.IndexByte(value, ':'); i >= 0 {
+		prefix, local = value[:i], value[i+1:]
+	}
+	if !builtinTypes["NCName"].builtin.check(local, context) {
+		return false
+	}
+	if prefix == "" || context == nil {
+		return true
+	}
+	_, ok := context.LookupNamespace(prefix)
+	return ok
+}
+
+func isInteger(lexical func(string, *xmldom.Node) bool, lower, upper string) func(string, *xmldom.Node) bool {
+	return func(value string, context *xmldom.Node) bool {
+		if !lexical(value, context) {
+			return false
+		}
+		if c, ok := compareDecimals(value, lower); lower != "" && ok && c < 0 {
+			return false
+		}
+		if c, ok := compareDecimals(value, upper); upper != "" && ok && c > 0 {
+			return false
+		}
+		return true
+	}
+}
+
+func isTime(lexical func(string, *xmldom.Node) bool, layout string) func(string, *xmldom.Node) bool {
+	return func(value string, context *xmldom.Node) bool {
+		if !lexical(value, context) {
+			return false
+		}
+		_, ok := parseTime(value, layout)
+		return ok
+	}
+}
+
+func parseTime(value, layout string) (time.Time, bool) {
+	value = strings.Replace(value, "T24:00:00", "T00:00:00", 1)
+	if strings.HasPrefix(value, "24:00:00") {
+		value = "00:00:00" + strings.TrimPrefix(value, "24:00:00")
+	}
+	layout += "Z07:00"
+	if !strings.HasSuffix(value, "Z") && !hasOffset(value) {
+		value += "Z"
+	}
+	if strings.Contains(value, ".") {
+		layout = strings.Replace(layout, "05Z", "05.999999999Z", 1)
+	}
+	t, err := time.Parse(layout, value)
+	return t, err == nil
+}
+
+func hasOffset(value string) bool {
+	return len(value) > 6 && (value[len(value)-6] == '+' || value[len(value)-6] == '-') && value[len(value)-3] == ':'
+}
+
+func compareTimes(layout string) func(a, b string) (int, bool) {
+	return func(a, b string) (int, bool) {
+		x, ok := parseTime(a, layout)
+		if !ok {
+			return 0, false
+		}
+		y, ok := parseTime(b, layout)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x.Before(y):
+			return -1, true
+		case x.After(y):
+			return 1, true
+		}
+		return 0, true
+	}
+}
+
+func compareDecimals(a, b string) (int, bool) {
+	x, ok := new(big.Rat).SetString(strings.TrimPrefix(a, "+"))
+	if !ok {
+		return 0, false
+	}
+	y, ok := new(big.Rat).SetString(strings.TrimPrefix(b, "+"))
+	if !ok {
+		return 0, false
+	}
+	return x.Cmp(y), true
+}
+
+func compareFloats(a, b string) (int, bool) {
+	x, ok := parseFloat(a)
+	if !ok {
+		return 0, false
+	}
+	y, ok := parseFloat(b)
+	if !ok || math.IsNaN(x) || math.IsNaN(y) {
+		return 0, false
+	}
+	switch {
+	case x < y:
+		return -1, true
+	case x > y:
+		return 1, true
+	}
+	return 0, true
+}
+
+func parseFloat(s string) (float64, bool) {
+	switch s {
+	case "INF", "+INF":
+		return math.Inf(1), true
+	case "-INF":
+		return math.Inf(-1), true
+	case "NaN":
+		return math.NaN(), true
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
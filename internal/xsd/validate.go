@@ -0,0 +1,471 @@
+package xsd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+)
+
+type validator struct {
+	schema     *Schema
+	violations []Violation
+}
+
+func (v *validator) fail(node *xmldom.Node, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Path: node.Path(), Message: fmt.Sprintf(format, args...), Node: node})
+}
+
+func (v *validator) validateElement(node *xmldom.Node, declaration *element) {
+	if declaration.abstract {
+		v.fail(node, `element "%s" is abstract`, node.QualifiedName())
+		return
+	}
+	t := declaration.typ
+	if typeAttribute := instanceAttribute(node, "type"); typeAttribute != nil {
+		instanceType, ok := v.instanceType(node, typeAttribute.Data)
+		if !ok {
+			return
+		}
+		t = instanceType
+	}
+	if nilAttribute := instanceAttribute(node, "nil"); nilAttribute != nil && isTrue(strings.TrimSpace(nilAttribute.Data)) {
+		v.validateNil(node, declaration, t)
+		return
+	}
+
+	switch t := t.(type) {
+	case *simpleType:
+		for _, attribute := range node.Attributes {
+			if attribute.Name.Space != InstanceNamespace {
+				v.fail(attribute, `unexpected attribute "%s"`, attribute.QualifiedName())
+			}
+		}
+		if !v.rejectElements(node) {
+			v.validateText(node, declaration, t)
+		}
+	case *complexType:
+		v.validateComplexElement(node, declaration, t)
+	}
+}
+
+// instanceType resolves the type set by "xsi:type" attribute.
+func (v *validator) instanceType(node *xmldom.Node, value string) (typeDefinition, bool) {
+	value = strings.TrimSpace(value)
+	prefix, local := "", value
+	if i := strings.IndexByte(value, ':'); i >= 0 {
+		prefix, local = value[:i], value[i+1:]
+	}
+	uri, _ := node.LookupNamespace(prefix)
+	t, ok := v.schema.typeByName(xml.Name{Space: uri, Local: local})
+	if !ok {
+		v.fail(node, `type "%s" of xsi:type is not defined`, value)
+	}
+	return t, ok
+}
+
+func (v *validator) validateNil(node *xmldom.Node, declaration *element, t typeDefinition) {
+	if !declaration.nillable {
+		v.fail(node, `element "%s" is not nillable`, node.QualifiedName())
+		return
+	}
+	for _, child := range node.Children {
+		if child.Type == xmldom.ElementNode || child.Type == xmldom.TextNode && strings.TrimSpace(child.Data) != "" {
+			v.fail(node, `element with xsi:nil must be empty`)
+			break
+		}
+	}
+	if complex, ok := t.(*complexType); ok {
+		v.validateAttributes(node, complex)
+	}
+}
+
+func (v *validator) validateComplexElement(node *xmldom.Node, declaration *element, t *complexType) {
+	if t.isAny {
+		return
+	}
+	if t.abstract {
+		v.fail(node, `type "%s" is abstract`, displayName(node, t.name))
+		return
+	}
+	v.validateAttributes(node, t)
+	if t.simple {
+		if !v.rejectElements(node) {
+			v.validateText(node, declaration, t.textType)
+		}
+		return
+	}
+
+	if !t.mixed {
+		for _, child := range node.Children {
+			if child.Type == xmldom.TextNode && strings.TrimSpace(child.Data) != "" {
+				v.fail(child, `must not contain text, actual is "%s"`, strings.TrimSpace(child.Data))
+				break
+			}
+		}
+	}
+	elements := childElements(node)
+	if t.effectiveContent == nil {
+		v.rejectElements(node)
+		return
+	}
+	v.validateContent(node, t.effectiveContent, elements)
+}
+
+// rejectElements reports child elements of the element with simple or empty content.
+func (v *validator) rejectElements(node *xmldom.Node) bool {
+	elements := childElements(node)
+	for _, child := range elements {
+		v.fail(child, `unexpected element "%s", no elements expected`, child.QualifiedName())
+	}
+	return len(elements) > 0
+}
+
+func (v *validator) validateText(node *xmldom.Node, declaration *element, t *simpleType) {
+	value := node.StringValue()
+	if value == "" && declaration.defaultValue != nil {
+		value = *declaration.defaultValue
+	}
+	if declaration.fixed != nil {
+		if value == "" {
+			value = *declaration.fixed
+		}
+		if !v.checkFixed(node, t, value, *declaration.fixed) {
+			return
+		}
+	}
+	if message := t.validate(value, node); message != "" {
+		v.fail(node, "%s", message)
+	}
+}
+
+func (v *validator) checkFixed(node *xmldom.Node, t *simpleType, value, fixed string) bool {
+	whiteSpace := t.whiteSpace()
+	if normalize(value, whiteSpace) != normalize(fixed, whiteSpace) {
+		v.fail(node, `must be equal to fixed value "%s", actual is "%s"`, fixed, value)
+		return false
+	}
+	return true
+}
+
+func (v *validator) validateAttributes(node *xmldom.Node, t *complexType) {
+	if t.isAny {
+		return
+	}
+	uses := make(map[xml.Name]*attributeUse, len(t.effectiveAttributes))
+	for _, use := range t.effectiveAttributes {
+		uses[use.attribute.name] = use
+	}
+
+	present := make(map[xml.Name]bool, len(node.Attributes))
+	for _, attribute := range node.Attributes {
+		if attribute.Name.Space == InstanceNamespace {
+			continue
+		}
+		present[attribute.Name] = true
+		if use, ok := uses[attribute.Name]; ok {
+			if !use.prohibited {
+				fixed := use.fixed
+				if fixed == nil {
+					fixed = use.attribute.fixed
+				}
+				v.validateAttribute(attribute, use.attribute, fixed)
+				continue
+			}
+		} else if t.anyAttribute != nil && t.anyAttribute.allows(attribute.Name.Space) {
+			v.validateWildcardAttribute(attribute, t.anyAttribute)
+			continue
+		}
+		v.fail(attribute, `unexpected attribute "%s"`, attribute.QualifiedName())
+	}
+
+	for _, use := range t.effectiveAttributes {
+		if use.required && !use.prohibited && !present[use.attribute.name] {
+			v.fail(node, `missing required attribute "%s"`, displayName(node, use.attribute.name))
+		}
+	}
+}
+
+func (v *validator) validateWildcardAttribute(node *xmldom.Node, w *wildcard) {
+	if w.process == "skip" {
+		return
+	}
+	if declaration, ok := v.schema.attributes[node.Name]; ok {
+		v.validateAttribute(node, declaration, declaration.fixed)
+	} else if w.process == "strict" {
+		v.fail(node, `attribute "%s" is not declared in the schema`, node.QualifiedName())
+	}
+}
+
+func (v *validator) validateAttribute(node *xmldom.Node, declaration *attribute, fixed *string) {
+	if fixed != nil && !v.checkFixed(node, declaration.typ, node.Data, *fixed) {
+		return
+	}
+	if message := declaration.typ.validate(node.Data, node.Parent); message != "" {
+		v.fail(node, "%s", message)
+	}
+}
+
+func (v *validator) validateContent(node *xmldom.Node, model *particle, elements []*xmldom.Node) {
+	m := &matcher{schema: v.schema, parent: node, elements: elements, bindings: make([]binding, len(elements))}
+	matched := m.match(model, 0, func(end int) bool {
+		if end == len(elements) {
+			return true
+		}
+		m.record(end, "")
+		return false
+	})
+	if matched {
+		for i, child := range elements {
+			v.validateBinding(child, m.bindings[i])
+		}
+		return
+	}
+
+	m.report(v)
+	// children are validated by the declarations with the same names to report as many violations as possible
+	declarations := make(map[xml.Name]*element)
+	collectDeclarations(model, declarations, make(map[*particle]bool))
+	for _, child := range elements {
+		if declaration, ok := declarations[child.Name]; ok {
+			v.validateElement(child, declaration)
+		}
+	}
+}
+
+func (v *validator) validateBinding(node *xmldom.Node, b binding) {
+	if b.element != nil {
+		v.validateElement(node, b.element)
+		return
+	}
+	if b.wildcard == nil || b.wildcard.process == "skip" {
+		return
+	}
+	if declaration, ok := v.schema.elements[node.Name]; ok {
+		v.validateElement(node, declaration)
+	} else if b.wildcard.process == "strict" {
+		v.fail(node, `element "%s" is not declared in the schema`, node.QualifiedName())
+	}
+}
+
+func collectDeclarations(p *particle, declarations map[xml.Name]*element, visited map[*particle]bool) {
+	if visited[p] {
+		return
+	}
+	visited[p] = true
+	if p.element != nil {
+		declarations[p.element.name] = p.element
+	}
+	for _, child := range p.children {
+		collectDeclarations(child, declarations, visited)
+	}
+}
+
+// binding is a declaration matched with the child element.
+type binding struct {
+	element  *element
+	wildcard *wildcard
+}
+
+// maxMatchSteps limits backtracking of ambiguous content models.
+const maxMatchSteps = 100000
+
+// matcher matches child elements with the content model by backtracking. The continuation
+// is called with the position after the matched particle and reports whether the rest is matched.
+type matcher struct {
+	schema   *Schema
+	parent   *xmldom.Node
+	elements []*xmldom.Node
+	bindings []binding
+	steps    int
+	// furthest is the furthest position where the matching failed,
+	// expected holds names of the elements that were expected at this position
+	furthest int
+	expected []string
+}
+
+func (m *matcher) match(p *particle, pos int, k func(int) bool) bool {
+	return m.repeat(p, 0, pos, k)
+}
+
+func (m *matcher) repeat(p *particle, count, pos int, k func(int) bool) bool {
+	m.steps++
+	if m.steps > maxMatchSteps {
+		return false
+	}
+	if p.maxOccurs < 0 || count < p.maxOccurs {
+		matched := m.term(p, pos, func(next int) bool {
+			if next == pos {
+				// the term matched nothing, so the rest of required occurrences can match nothing too
+				return k(next)
+			}
+			return m.repeat(p, count+1, next, k)
+		})
+		if matched {
+			return true
+		}
+	}
+	return count >= p.minOccurs && k(pos)
+}
+
+func (m *matcher) term(p *particle, pos int, k func(int) bool) bool {
+	switch p.kind {
+	case particleElement:
+		if pos < len(m.elements) {
+			if declaration := m.schema.substitute(p.element, m.elements[pos].Name); declaration != nil {
+				m.bindings[pos] = binding{element: declaration}
+				return k(pos + 1)
+			}
+		}
+		m.record(pos, strconv.Quote(displayName(m.parent, p.element.name)))
+	case particleAny:
+		if pos < len(m.elements) && p.wildcard.allows(m.elements[pos].Name.Space) {
+			m.bindings[pos] = binding{wildcard: p.wildcard}
+			return k(pos + 1)
+		}
+		m.record(pos, "any element")
+	case particleSequence:
+		return m.sequence(p.children, pos, k)
+	case particleChoice:
+		for _, child := range p.children {
+			if m.match(child, pos, k) {
+				return true
+			}
+		}
+	case particleAll:
+		return m.all(p, pos, k)
+	}
+	return false
+}
+
+func (m *matcher) sequence(particles []*particle, pos int, k func(int) bool) bool {
+	if len(particles) == 0 {
+		return k(pos)
+	}
+	return m.match(particles[0], pos, func(next int) bool {
+		return m.sequence(particles[1:], next, k)
+	})
+}
+
+// all matches elements of "all" group in any order, each element occurs at most once.
+func (m *matcher) all(p *particle, pos int, k func(int) bool) bool {
+	used := make([]bool, len(p.children))
+	end := pos
+	for ; end < len(m.elements); end++ {
+		index := -1
+		for i, child := range p.children {
+			if declaration := m.schema.substitute(child.element, m.elements[end].Name); !used[i] && declaration != nil {
+				index = i
+				m.bindings[end] = binding{element: declaration}
+				break
+			}
+		}
+		if index < 0 {
+			break
+		}
+		used[index] = true
+	}
+
+	complete := true
+	for i, child := range p.children {
+		if !used[i] {
+			m.record(end, strconv.Quote(displayName(m.parent, child.element.name)))
+			complete = complete && child.minOccurs == 0
+		}
+	}
+	return complete && k(end)
+}
+
+func (m *matcher) record(pos int, expected string) {
+	if pos > m.furthest {
+		m.furthest = pos
+		m.expected = nil
+	}
+	if pos < m.furthest || expected == "" {
+		return
+	}
+	for _, e := range m.expected {
+		if e == expected {
+			return
+		}
+	}
+	m.expected = append(m.expected, expected)
+}
+
+func (m *matcher) report(v *validator) {
+	if m.furthest < len(m.elements) {
+		child := m.elements[m.furthest]
+		switch len(m.expected) {
+		case 0:
+			v.fail(child, `unexpected element "%s"`, child.QualifiedName())
+		case 1:
+			v.fail(child, `unexpected element "%s", expected %s`, child.QualifiedName(), m.expected[0])
+		default:
+			v.fail(child, `unexpected element "%s", expected one of %s`, child.QualifiedName(), strings.Join(m.expected, ", "))
+		}
+		return
+	}
+
+	switch len(m.expected) {
+	case 0:
+		v.fail(m.parent, `missing required elements`)
+	case 1:
+		v.fail(m.parent, `missing required element %s`, m.expected[0])
+	default:
+		v.fail(m.parent, `missing required element, expected one of %s`, strings.Join(m.expected, ", "))
+	}
+}
+
+// substitute returns the declaration of the element with the name: the declaration itself
+// or a member of its substitution group.
+func (s *Schema) substitute(declaration *element, name xml.Name) *element {
+	if declaration.name == name {
+		return declaration
+	}
+	if s.elements[declaration.name] != declaration {
+		return nil
+	}
+	for _, member := range s.substitutes[declaration.name] {
+		if found := s.substitute(member, name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func childElements(node *xmldom.Node) []*xmldom.Node {
+	var elements []*xmldom.Node
+	for _, child := range node.Children {
+		if child.Type == xmldom.ElementNode {
+			elements = append(elements, child)
+		}
+	}
+	return elements
+}
+
+func instanceAttribute(node *xmldom.Node, local string) *xmldom.Node {
+	for _, attribute := range node.Attributes {
+		if attribute.Name.Space == InstanceNamespace && attribute.Name.Local == local {
+			return attribute
+		}
+	}
+	return nil
+}
+
+// displayName formats the name with the prefix declared in the context of the node.
+func displayName(context *xmldom.Node, name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	for _, namespace := range context.NamespaceNodes() {
+		if namespace.Data == name.Space {
+			if namespace.Name.Local == "" {
+				return name.Local
+			}
+			return namespace.Name.Local + ":" + name.Local
+		}
+	}
+	return formatName(name)
+}
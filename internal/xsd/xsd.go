@@ -0,0 +1,327 @@
+// Package xsd implements validation of XML documents against a practical subset of XML Schema 1.0
+// (https://www.w3.org/TR/xmlschema-1/): global and local elements, complex types with sequences,
+// choices, "all" groups, model groups and wildcards, occurrence constraints, simple types with facets,
+// lists and unions, attributes and attribute groups, derivation by extension and restriction,
+// substitution groups, namespaces, includes, imports, "xsi:type" and "xsi:nil".
+// Identity constraints, redefinitions and derivation blocking are not supported.
+package xsd
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+)
+
+// Namespace is a namespace URI of XML Schema definitions.
+const Namespace = "http://www.w3.org/2001/XMLSchema"
+
+// InstanceNamespace is a namespace URI of "xsi:type" and "xsi:nil" attributes.
+const InstanceNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// Schema is a set of components loaded from schema documents.
+type Schema struct {
+	elements        map[xml.Name]*element
+	attributes      map[xml.Name]*attribute
+	types           map[xml.Name]typeDefinition
+	groups          map[xml.Name]*particle
+	attributeGroups map[xml.Name]*attributeSet
+	// substitutes maps heads of substitution groups to their direct members
+	substitutes map[xml.Name][]*element
+}
+
+// Violation describes a node that does not match the schema.
+type Violation struct {
+	// Path is XPath location of the invalid node.
+	Path    string
+	Message string
+	// Node is the invalid node.
+	Node *xmldom.Node
+}
+
+// String formats violation with the path.
+func (v Violation) String() string {
+	return fmt.Sprintf(`at "%s": %s`, v.Path, v.Message)
+}
+
+// Validate validates the document or the element against the global element declaration of the schema.
+func (s *Schema) Validate(node *xmldom.Node) []Violation {
+	if node.Type == xmldom.DocumentNode {
+		for _, child := range node.Children {
+			if child.Type == xmldom.ElementNode {
+				node = child
+			}
+		}
+	}
+
+	v := &validator{schema: s}
+	if declaration, ok := s.elements[node.Name]; ok {
+		v.validateElement(node, declaration)
+	} else {
+		v.fail(node, `element "%s" is not declared in the schema`, node.QualifiedName())
+	}
+
+	return v.violations
+}
+
+func newSchema() *Schema {
+	s := &Schema{
+		elements:        make(map[xml.Name]*element),
+		attributes:      make(map[xml.Name]*attribute),
+		types:           make(map[xml.Name]typeDefinition),
+		groups:          make(map[xml.Name]*particle),
+		attributeGroups: make(map[xml.Name]*attributeSet),
+		substitutes:     make(map[xml.Name][]*element),
+	}
+	// attributes of the "xml" namespace are available without importing their schema
+	for _, local := range []string{"lang", "space", "base", "id"} {
+		name := xml.Name{Space: xmldom.XMLNamespace, Local: local}
+		s.attributes[name] = &attribute{name: name, typ: builtinTypes["string"]}
+	}
+
+	return s
+}
+
+func (s *Schema) typeByName(name xml.Name) (typeDefinition, bool) {
+	if name.Space == Namespace {
+		if name.Local == "anyType" {
+			return anyType, true
+		}
+		if t, ok := builtinTypes[name.Local]; ok {
+			return t, true
+		}
+	}
+	t, ok := s.types[name]
+	return t, ok
+}
+
+// typeDefinition is *simpleType or *complexType.
+type typeDefinition interface {
+	typeName() xml.Name
+}
+
+type element struct {
+	name         xml.Name
+	typ          typeDefinition
+	nillable     bool
+	abstract     bool
+	fixed        *string
+	defaultValue *string
+	// head is the head of the substitution group of the element
+	head *element
+}
+
+type attribute struct {
+	name         xml.Name
+	typ          *simpleType
+	fixed        *string
+	defaultValue *string
+}
+
+type attributeUse struct {
+	attribute  *attribute
+	required   bool
+	prohibited bool
+	fixed      *string
+}
+
+// attributeSet holds attribute declarations of complex types and attribute groups.
+type attributeSet struct {
+	uses         []*attributeUse
+	groups       []*attributeSet
+	anyAttribute *wildcard
+}
+
+// expand returns attribute uses including the ones from referenced attribute groups.
+func (s *attributeSet) expand(visited map[*attributeSet]bool) ([]*attributeUse, *wildcard) {
+	if visited[s] {
+		return nil, nil
+	}
+	visited[s] = true
+	uses := append([]*attributeUse(nil), s.uses...)
+	anyAttribute := s.anyAttribute
+	for _, group := range s.groups {
+		groupUses, groupAny := group.expand(visited)
+		uses = append(uses, groupUses...)
+		if anyAttribute == nil {
+			anyAttribute = groupAny
+		}
+	}
+	return uses, anyAttribute
+}
+
+type particleKind int
+
+const (
+	particleElement particleKind = iota
+	particleSequence
+	particleChoice
+	particleAll
+	particleAny
+)
+
+type particle struct {
+	kind      particleKind
+	minOccurs int
+	// maxOccurs is -1 for unbounded number of occurrences
+	maxOccurs int
+	element   *element
+	children  []*particle
+	wildcard  *wildcard
+}
+
+type wildcard struct {
+	any bool
+	// other allows names from namespaces other than the target namespace (and not unqualified names)
+	other           bool
+	targetNamespace string
+	namespaces      map[string]bool
+	process         string
+}
+
+func (w *wildcard) allows(space string) bool {
+	switch {
+	case w.any:
+		return true
+	case w.other:
+		return space != w.targetNamespace && space != ""
+	}
+	return w.namespaces[space]
+}
+
+type derivation int
+
+const (
+	derivationNone derivation = iota
+	derivationExtension
+	derivationRestriction
+)
+
+type complexType struct {
+	name     xml.Name
+	abstract bool
+	mixed    bool
+	// isAny is set for xs:anyType, its content and attributes are not validated
+	isAny bool
+
+	derivation derivation
+	base       typeDefinition
+	// simple is set for types with simple content
+	simple bool
+	// facets restrict the base of the simple content
+	facets     facets
+	content    *particle
+	attributes attributeSet
+
+	completed  bool
+	completing bool
+	// effective properties are built by complete() from the own properties and the base type
+	effectiveContent    *particle
+	effectiveAttributes []*attributeUse
+	anyAttribute        *wildcard
+	textType            *simpleType
+}
+
+func (t *complexType) typeName() xml.Name {
+	return t.name
+}
+
+var anyType = &complexType{
+	name:      xml.Name{Space: Namespace, Local: "anyType"},
+	isAny:     true,
+	mixed:     true,
+	completed: true,
+}
+
+// complete builds effective content and attributes of the type from its base type.
+func (t *complexType) complete() error {
+	if t.completed {
+		return nil
+	}
+	if t.completing {
+		return fmt.Errorf(`type "%s" has circular derivation`, formatName(t.name))
+	}
+	t.completing = true
+	defer func() { t.completing = false }()
+
+	uses, anyAttribute := t.attributes.expand(make(map[*attributeSet]bool))
+	t.effectiveContent = t.content
+	t.effectiveAttributes = uses
+	t.anyAttribute = anyAttribute
+
+	switch base := t.base.(type) {
+	case *simpleType:
+		if !t.simple {
+			return fmt.Errorf(`type "%s" has complex content with simple base type`, formatName(t.name))
+		}
+		t.textType = base
+		if t.derivation == derivationRestriction {
+			t.textType = &simpleType{base: base, facets: t.facets}
+		}
+	case *complexType:
+		if err := base.complete(); err != nil {
+			return err
+		}
+		if base.isAny {
+			break
+		}
+		t.deriveFrom(base)
+	}
+	if t.simple && t.textType == nil {
+		return fmt.Errorf(`type "%s" has simple content without simple base type`, formatName(t.name))
+	}
+	t.completed = true
+
+	return nil
+}
+
+func (t *complexType) deriveFrom(base *complexType) {
+	if t.simple {
+		t.textType = base.textType
+		if t.derivation == derivationRestriction && base.textType != nil {
+			t.textType = &simpleType{base: base.textType, facets: t.facets}
+		}
+	}
+	if t.anyAttribute == nil {
+		t.anyAttribute = base.anyAttribute
+	}
+	t.effectiveAttributes = mergeAttributes(base.effectiveAttributes, t.effectiveAttributes)
+	if t.derivation != derivationExtension || t.simple {
+		return
+	}
+	switch {
+	case base.effectiveContent == nil:
+	case t.content == nil:
+		t.effectiveContent = base.effectiveContent
+	default:
+		t.effectiveContent = &particle{
+			kind:      particleSequence,
+			minOccurs: 1,
+			maxOccurs: 1,
+			children:  []*particle{base.effectiveContent, t.content},
+		}
+	}
+}
+
+// mergeAttributes overrides attribute uses of the base type by the uses of the derived type.
+func mergeAttributes(base, derived []*attributeUse) []*attributeUse {
+	merged := make([]*attributeUse, 0, len(base)+len(derived))
+	overridden := make(map[xml.Name]bool, len(derived))
+	for _, use := range derived {
+		overridden[use.attribute.name] = true
+	}
+	for _, use := range base {
+		if !overridden[use.attribute.name] {
+			merged = append(merged, use)
+		}
+	}
+	return append(merged, derived...)
+}
+
+// formatName formats the name in the Clark notation: "{namespace-uri}local".
+func formatName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
@@ -0,0 +1,332 @@
+package xsd_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/muonsoft/api-testing/internal/xmldom"
+	"github.com/muonsoft/api-testing/internal/xsd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderSchema = `<?xml version="1.0"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"
+	xmlns:o="urn:order" targetNamespace="urn:order" elementFormDefault="qualified">
+	<xs:element name="order" type="o:Order"/>
+	<xs:complexType name="Order">
+		<xs:sequence>
+			<xs:element name="id" type="o:OrderID"/>
+			<xs:element name="status" type="o:Status" minOccurs="0"/>
+			<xs:choice>
+				<xs:element name="email" type="xs:string"/>
+				<xs:element name="phone" type="xs:string"/>
+			</xs:choice>
+			<xs:element name="item" type="o:Item" maxOccurs="3"/>
+			<xs:element name="note" type="xs:string" minOccurs="0" nillable="true"/>
+		</xs:sequence>
+		<xs:attribute name="version" type="xs:int" use="required"/>
+		<xs:attribute name="channel" type="xs:string" fixed="web"/>
+	</xs:complexType>
+	<xs:complexType name="Item">
+		<xs:simpleContent>
+			<xs:extension base="o:Quantity">
+				<xs:attribute name="sku" use="required">
+					<xs:simpleType>
+						<xs:restriction base="xs:string">
+							<xs:pattern value="[A-Z]{2}-\d+"/>
+						</xs:restriction>
+					</xs:simpleType>
+				</xs:attribute>
+			</xs:extension>
+		</xs:simpleContent>
+	</xs:complexType>
+	<xs:simpleType name="OrderID">
+		<xs:restriction base="xs:token">
+			<xs:minLength value="3"/>
+			<xs:maxLength value="8"/>
+		</xs:restriction>
+	</xs:simpleType>
+	<xs:simpleType name="Status">
+		<xs:restriction base="xs:string">
+			<xs:enumeration value="new"/>
+			<xs:enumeration value="paid"/>
+		</xs:restriction>
+	</xs:simpleType>
+	<xs:simpleType name="Quantity">
+		<xs:restriction base="xs:decimal">
+			<xs:minExclusive value="0"/>
+			<xs:maxInclusive value="100"/>
+			<xs:fractionDigits value="2"/>
+		</xs:restriction>
+	</xs:simpleType>
+</xs:schema>`
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		schema         string
+		document       string
+		wantViolations []string
+	}{
+		{
+			name:   "valid document",
+			schema: orderSchema,
+			document: `<order xmlns="urn:order" version="1" channel="web">
+				<id> A-100 </id>
+				<status>paid</status>
+				<phone>123</phone>
+				<item sku="AB-1">1.50</item>
+				<item sku="CD-2">100</item>
+				<note xsi:nil="true" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"/>
+			</order>`,
+		},
+		{
+			name:   "prefixed names",
+			schema: orderSchema,
+			document: `<o:order xmlns:o="urn:order" version="1">
+				<o:id>A-100</o:id><o:email>a@b.c</o:email><o:item sku="AB-1">1</o:item>
+			</o:order>`,
+		},
+		{
+			name:   "invalid values",
+			schema: orderSchema,
+			document: `<order xmlns="urn:order" version="one" channel="mobile">
+				<id>A1</id>
+				<status>cancelled</status>
+				<email>a@b.c</email>
+				<item sku="AB-1">0</item>
+				<item sku="ab">99.999</item>
+				<item sku="CD-3">101</item>
+			</order>`,
+			wantViolations: []string{
+				`at "/o:order/@version": must be of type xs:int, actual is "one"`,
+				`at "/o:order/@channel": must be equal to fixed value "web", actual is "mobile"`,
+				`at "/o:order/o:id": must have length greater than or equal to 3, actual is 2`,
+				`at "/o:order/o:status": must be one of "new", "paid", actual is "cancelled"`,
+				`at "/o:order/o:item[1]": must be greater than 0, actual is 0`,
+				`at "/o:order/o:item[2]/@sku": must match pattern "[A-Z]{2}-\d+", actual is "ab"`,
+				`at "/o:order/o:item[2]": must have at most 2 fraction digits, actual is 3`,
+				`at "/o:order/o:item[3]": must be less than or equal to 100, actual is 101`,
+			},
+		},
+		{
+			name:   "content model violations",
+			schema: orderSchema,
+			document: `<order xmlns="urn:order">
+				<id>A-100</id>
+				<email>a@b.c</email>
+				<phone>1</phone>
+			</order>`,
+			wantViolations: []string{
+				`at "/o:order": missing required attribute "version"`,
+				`at "/o:order/o:phone": unexpected element "phone", expected "item"`,
+			},
+		},
+		{
+			name:   "missing element",
+			schema: orderSchema,
+			document: `<order xmlns="urn:order" version="1">
+				<id>A-100</id>
+			</order>`,
+			wantViolations: []string{
+				`at "/o:order": missing required element, expected one of "status", "email", "phone"`,
+			},
+		},
+		{
+			name:   "too many occurrences",
+			schema: orderSchema,
+			document: `<order xmlns="urn:order" version="1"><id>A-100</id><email>e</email>
+				<item sku="AB-1">1</item><item sku="AB-1">1</item><item sku="AB-1">1</item><item sku="AB-1">1</item>
+			</order>`,
+			wantViolations: []string{
+				`at "/o:order/o:item[4]": unexpected element "item", expected "note"`,
+			},
+		},
+		{
+			name:   "text, attributes and elements",
+			schema: orderSchema,
+			document: `<order xmlns="urn:order" version="1" extra="1">text<id>A-100<b/></id><email>e</email>
+				<item sku="AB-1">1</item>
+				<note>a</note>
+				<note>b</note>
+			</order>`,
+			wantViolations: []string{
+				`at "/o:order/@extra": unexpected attribute "extra"`,
+				`at "/o:order/text()[1]": must not contain text, actual is "text"`,
+				`at "/o:order/o:note[2]": unexpected element "note"`,
+				`at "/o:order/o:id/o:b": unexpected element "b", no elements expected`,
+			},
+		},
+		{
+			name:     "undeclared root element",
+			schema:   orderSchema,
+			document: `<order version="1"/>`,
+			wantViolations: []string{
+				`at "/order": element "order" is not declared in the schema`,
+			},
+		},
+		{
+			name: "extension, groups and all",
+			schema: `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+				<xs:element name="person" type="Employee"/>
+				<xs:complexType name="Person">
+					<xs:all>
+						<xs:element name="first" type="xs:string"/>
+						<xs:element name="last" type="xs:string"/>
+						<xs:element name="middle" type="xs:string" minOccurs="0"/>
+					</xs:all>
+					<xs:attributeGroup ref="common"/>
+				</xs:complexType>
+				<xs:complexType name="Employee">
+					<xs:complexContent>
+						<xs:extension base="Person">
+							<xs:group ref="contacts"/>
+							<xs:attribute name="department" type="xs:string"/>
+						</xs:extension>
+					</xs:complexContent>
+				</xs:complexType>
+				<xs:group name="contacts">
+					<xs:sequence>
+						<xs:element name="email" type="xs:string" maxOccurs="unbounded"/>
+					</xs:sequence>
+				</xs:group>
+				<xs:attributeGroup name="common">
+					<xs:attribute name="id" type="xs:positiveInteger" use="required"/>
+				</xs:attributeGroup>
+			</xs:schema>`,
+			document: `<person id="0" department="IT">
+				<last>Doe</last><first>John</first>
+				<email>a@b.c</email><email>d@e.f</email>
+			</person>`,
+			wantViolations: []string{
+				`at "/person/@id": must be of type xs:positiveInteger, actual is "0"`,
+			},
+		},
+		{
+			name: "wildcards, lists, unions and substitution groups",
+			schema: `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+				<xs:element name="root">
+					<xs:complexType>
+						<xs:sequence>
+							<xs:element ref="shape" maxOccurs="unbounded"/>
+							<xs:element name="sizes">
+								<xs:simpleType>
+									<xs:list itemType="xs:int"/>
+								</xs:simpleType>
+							</xs:element>
+							<xs:element name="size">
+								<xs:simpleType>
+									<xs:union memberTypes="xs:int">
+										<xs:simpleType>
+											<xs:restriction base="xs:string">
+												<xs:enumeration value="auto"/>
+											</xs:restriction>
+										</xs:simpleType>
+									</xs:union>
+								</xs:simpleType>
+							</xs:element>
+							<xs:any namespace="##other" processContents="skip" minOccurs="0"/>
+						</xs:sequence>
+						<xs:anyAttribute namespace="##other" processContents="lax"/>
+					</xs:complexType>
+				</xs:element>
+				<xs:element name="shape" abstract="true" type="xs:string"/>
+				<xs:element name="circle" substitutionGroup="shape"/>
+				<xs:element name="square" substitutionGroup="shape"/>
+			</xs:schema>`,
+			document: `<root xmlns:x="urn:x" x:any="1">
+				<circle/><square/><shape/>
+				<sizes>1 2 x</sizes>
+				<size>big</size>
+				<x:extension><anything/></x:extension>
+			</root>`,
+			wantViolations: []string{
+				`at "/root/shape": element "shape" is abstract`,
+				`at "/root/sizes": must be of type xs:int, actual is "x"`,
+				`at "/root/size": must match one of the member types of the union, actual is "big"`,
+			},
+		},
+		{
+			name: "xsi:type",
+			schema: `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+				<xs:element name="value" type="xs:anySimpleType"/>
+			</xs:schema>`,
+			document: `<value xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+				xmlns:xs="http://www.w3.org/2001/XMLSchema" xsi:type="xs:date">2021-13-01</value>`,
+			wantViolations: []string{
+				`at "/value": must be of type xs:date, actual is "2021-13-01"`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schema, err := xsd.Parse([]byte(test.schema))
+			require.NoError(t, err)
+			document, err := xmldom.Parse(strings.NewReader(test.document))
+			require.NoError(t, err)
+
+			violations := schema.Validate(document)
+
+			var got []string
+			for _, violation := range violations {
+				path := violation.Node.PathWith(map[string]string{"o": "urn:order"})
+				got = append(got, fmt.Sprintf(`at "%s": %s`, path, violation.Message))
+			}
+			assert.Equal(t, test.wantViolations, got)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{
+			name:    "not a schema",
+			schema:  `<schema/>`,
+			wantErr: `schema "schema.xsd": root element must be xs:schema`,
+		},
+		{
+			name: "undefined type",
+			schema: `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+				<xs:element name="a" type="Unknown"/>
+			</xs:schema>`,
+			wantErr: `schema "schema.xsd": type "Unknown" is not defined`,
+		},
+		{
+			name: "undeclared prefix",
+			schema: `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+				<xs:element name="a" type="t:Type"/>
+			</xs:schema>`,
+			wantErr: `schema "schema.xsd": namespace prefix "t" is not declared`,
+		},
+		{
+			name: "unsupported pattern",
+			schema: `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+				<xs:simpleType name="a">
+					<xs:restriction base="xs:string"><xs:pattern value="[a-z-[aeiou]]"/></xs:restriction>
+				</xs:simpleType>
+			</xs:schema>`,
+			wantErr: `schema "schema.xsd": pattern "[a-z-[aeiou]]": character class subtraction is not supported`,
+		},
+		{
+			name: "circular derivation",
+			schema: `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+				<xs:complexType name="a"><xs:complexContent><xs:extension base="b"/></xs:complexContent></xs:complexType>
+				<xs:complexType name="b"><xs:complexContent><xs:extension base="a"/></xs:complexContent></xs:complexType>
+			</xs:schema>`,
+			wantErr: `type "a" has circular derivation`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := xsd.Parse([]byte(test.schema))
+
+			assert.EqualError(t, err, test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Package openapi provides validation of HTTP requests and responses against OpenAPI 3 documents.
+// It is used by apitest package to check that the tested handler follows the contract.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+var errUnsupportedVersion = errors.New("unsupported OpenAPI version")
+
+// Document is a parsed OpenAPI 3.0 or 3.1 document. Schemas of the document are used
+// as JSON Schema, so "$ref" to the components of the same document are supported.
+type Document struct {
+	root      map[string]interface{}
+	validator *jsonschema.Validator
+	basePaths []string
+	paths     []*pathTemplate
+}
+
+type pathTemplate struct {
+	template string
+	pattern  *regexp.Regexp
+	names    []string
+	item     map[string]interface{}
+}
+
+// Load reads OpenAPI document from the JSON or YAML file.
+func Load(filename string) (*Document, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("read OpenAPI document: %w", err)
+	}
+	document, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf(`parse OpenAPI document "%s": %w`, filename, err)
+	}
+
+	return document, nil
+}
+
+// Parse parses OpenAPI document from JSON or YAML data.
+func Parse(data []byte) (*Document, error) {
+	var root interface{}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(data, &root); err != nil {
+			return nil, fmt.Errorf("decode JSON: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return nil, fmt.Errorf("decode YAML: %w", err)
+		}
+		root = normalizeYAML(root)
+	}
+
+	object, ok := root.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: document is not an object", errUnsupportedVersion)
+	}
+	version, _ := object["openapi"].(string)
+	dialect := jsonschema.OpenAPI30
+	switch {
+	case strings.HasPrefix(version, "3.0"):
+	case strings.HasPrefix(version, "3.1"):
+		dialect = jsonschema.Draft2020
+	default:
+		return nil, fmt.Errorf(`%w "%s"`, errUnsupportedVersion, version)
+	}
+
+	document := &Document{
+		root:      object,
+		validator: jsonschema.NewValidator(object, dialect),
+		basePaths: parseBasePaths(object),
+	}
+	if err := document.parsePaths(); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// Version returns value of the "openapi" field of the document.
+func (d *Document) Version() string {
+	version, _ := d.root["openapi"].(string)
+	return version
+}
+
+func (d *Document) parsePaths() error {
+	paths, _ := d.root["paths"].(map[string]interface{})
+	for template, item := range paths {
+		itemObject, ok := d.resolve(item).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pattern, names, err := compileTemplate(template)
+		if err != nil {
+			return fmt.Errorf(`invalid path "%s": %w`, template, err)
+		}
+		d.paths = append(d.paths, &pathTemplate{template: template, pattern: pattern, names: names, item: itemObject})
+	}
+
+	// concrete paths have priority over templated ones: "/users/me" is matched before "/users/{id}"
+	sort.Slice(d.paths, func(i, j int) bool {
+		if len(d.paths[i].names) != len(d.paths[j].names) {
+			return len(d.paths[i].names) < len(d.paths[j].names)
+		}
+		return d.paths[i].template < d.paths[j].template
+	})
+
+	return nil
+}
+
+// findPath matches the request path with the path templates of the document.
+// It returns matched template and values of the path parameters.
+func (d *Document) findPath(path string) (*pathTemplate, map[string]string) {
+	candidates := []string{path}
+	for _, basePath := range d.basePaths {
+		if basePath != "" && strings.HasPrefix(path, basePath) {
+			candidates = append([]string{strings.TrimPrefix(path, basePath)}, candidates...)
+		}
+	}
+
+	for _, candidate := range candidates {
+		for _, template := range d.paths {
+			matches := template.pattern.FindStringSubmatch(candidate)
+			if matches == nil {
+				continue
+			}
+			values := make(map[string]string, len(template.names))
+			for i, name := range template.names {
+				values[name], _ = url.PathUnescape(matches[i+1])
+			}
+			return template, values
+		}
+	}
+
+	return nil, nil
+}
+
+// resolve follows "$ref" of the document components (parameters, responses, headers, etc.).
+func (d *Document) resolve(value interface{}) interface{} {
+	for i := 0; i < 32; i++ {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return value
+		}
+		resolved, err := jsonschema.Pointer(d.root, ref[1:])
+		if err != nil {
+			return nil
+		}
+		value = resolved
+	}
+
+	return value
+}
+
+var templateParameter = regexp.MustCompile(`{([^{}/]+)}`)
+
+func compileTemplate(template string) (*regexp.Regexp, []string, error) {
+	var pattern strings.Builder
+	var names []string
+	pattern.WriteString("^")
+	last := 0
+	for _, match := range templateParameter.FindAllStringSubmatchIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:match[0]]))
+		pattern.WriteString("([^/]+)")
+		names = append(names, template[match[2]:match[3]])
+		last = match[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("/?$")
+
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return re, names, nil
+}
+
+// parseBasePaths returns paths of the server URLs, server variables are replaced by default values.
+func parseBasePaths(root map[string]interface{}) []string {
+	servers, _ := root["servers"].([]interface{})
+	basePaths := make([]string, 0, len(servers))
+	for _, server := range servers {
+		object, _ := server.(map[string]interface{})
+		rawURL, _ := object["url"].(string)
+		variables, _ := object["variables"].(map[string]interface{})
+		for name, variable := range variables {
+			value, _ := variable.(map[string]interface{})
+			defaultValue, _ := value["default"].(string)
+			rawURL = strings.ReplaceAll(rawURL, "{"+name+"}", defaultValue)
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+		basePaths = append(basePaths, strings.TrimSuffix(u.Path, "/"))
+	}
+
+	// the longest base path is stripped first
+	sort.Slice(basePaths, func(i, j int) bool {
+		return len(basePaths[i]) > len(basePaths[j])
+	})
+
+	return basePaths
+}
+
+// normalizeYAML converts values decoded from YAML to the same types as encoding/json does:
+// all mapping keys are converted to strings (response codes like 200 are decoded as integers)
+// and all numbers are converted to float64.
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, element := range v {
+			v[key] = normalizeYAML(element)
+		}
+		return v
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			object[fmt.Sprint(key)] = normalizeYAML(element)
+		}
+		return object
+	case []interface{}:
+		for i, element := range v {
+			v[i] = normalizeYAML(element)
+		}
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	}
+
+	return value
+}
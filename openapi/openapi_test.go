@@ -0,0 +1,197 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/muonsoft/api-testing/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_WhenUnsupportedVersion_ExpectError(t *testing.T) {
+	_, err := openapi.Parse([]byte(`{"swagger": "2.0"}`))
+
+	assert.EqualError(t, err, `unsupported OpenAPI version ""`)
+}
+
+func TestDocument_ValidateResponse(t *testing.T) {
+	document, err := openapi.Load("testdata/users.yaml")
+	require.NoError(t, err)
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		code           int
+		header         http.Header
+		body           string
+		wantViolations []string
+	}{
+		{
+			name:   "valid response",
+			method: http.MethodGet,
+			url:    "/api/v1/users/1",
+			code:   http.StatusOK,
+			header: http.Header{"Content-Type": {"application/json"}, "X-Rate-Limit": {"100"}},
+			body:   `{"id":1,"name":"John","email":null}`,
+		},
+		{
+			name:   "concrete path has priority",
+			method: http.MethodGet,
+			url:    "/users/me",
+			code:   http.StatusOK,
+			header: http.Header{"Content-Type": {"application/json"}},
+			body:   `{"id":1,"name":"John"}`,
+		},
+		{
+			name:           "undocumented path",
+			method:         http.MethodGet,
+			url:            "/posts",
+			code:           http.StatusOK,
+			wantViolations: []string{`path "/posts" is not documented`},
+		},
+		{
+			name:           "undocumented method",
+			method:         http.MethodPut,
+			url:            "/users/1",
+			code:           http.StatusOK,
+			wantViolations: []string{`operation PUT "/users/{id}" is not documented`},
+		},
+		{
+			name:           "undocumented status code",
+			method:         http.MethodDelete,
+			url:            "/users/1",
+			code:           http.StatusOK,
+			wantViolations: []string{`status code 200 is not documented`},
+		},
+		{
+			name:   "status code range",
+			method: http.MethodPost,
+			url:    "/users",
+			code:   http.StatusUnprocessableEntity,
+			header: http.Header{"Content-Type": {"application/problem+json"}},
+			body:   `{}`,
+			wantViolations: []string{
+				`body: required property "title" is missing`,
+			},
+		},
+		{
+			name:   "invalid headers",
+			method: http.MethodPost,
+			url:    "/users",
+			code:   http.StatusCreated,
+			header: http.Header{"Content-Type": {"text/plain"}},
+			body:   `{"id":1,"name":"John"}`,
+			wantViolations: []string{
+				`header "Location": required header is missing`,
+				`header "Content-Type": content type "text/plain" is not documented, expected one of: application/json`,
+			},
+		},
+		{
+			name:   "invalid body",
+			method: http.MethodGet,
+			url:    "/users/1",
+			code:   http.StatusOK,
+			header: http.Header{"Content-Type": {"application/json"}, "X-Rate-Limit": {"many"}},
+			body:   `{"id":"1","name":"","tags":["a",1],"role":"admin"}`,
+			wantViolations: []string{
+				`header "X-Rate-Limit": must be of type integer, actual is string`,
+				`body at "id": must be of type integer, actual is string`,
+				`body at "name": must be string with length greater than or equal to 1, actual is 0`,
+				`body: property "role" is not allowed`,
+				`body at "tags[1]": must be of type string, actual is integer`,
+			},
+		},
+		{
+			name:           "unexpected body",
+			method:         http.MethodDelete,
+			url:            "/users/1",
+			code:           http.StatusNoContent,
+			body:           `{}`,
+			wantViolations: []string{`body: response body is not expected`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(test.method, test.url, nil)
+
+			violations := document.ValidateResponse(request, test.code, test.header, []byte(test.body))
+
+			assert.Equal(t, test.wantViolations, formatViolations(violations))
+		})
+	}
+}
+
+func TestDocument_ValidateRequest(t *testing.T) {
+	document, err := openapi.Load("testdata/users.yaml")
+	require.NoError(t, err)
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		header         http.Header
+		body           string
+		wantViolations []string
+	}{
+		{
+			name:   "valid request",
+			method: http.MethodPost,
+			url:    "/users",
+			header: http.Header{
+				"Content-Type": {"application/json; charset=utf-8"},
+				"X-Request-Id": {"9b2f5c3e-5d44-4f6c-9d1e-3b8e1c2b7a10"},
+			},
+			body: `{"name":"John","password":"secret"}`,
+		},
+		{
+			name:   "invalid request",
+			method: http.MethodPost,
+			url:    "/users",
+			header: http.Header{"Content-Type": {"application/json"}},
+			body:   `{"name":"John","email":"invalid"}`,
+			wantViolations: []string{
+				`header "X-Request-Id": required parameter is missing`,
+				`body: required property "password" is missing`,
+				`body at "email": must be string of format "email", actual is "invalid"`,
+			},
+		},
+		{
+			name:           "missing body",
+			method:         http.MethodPost,
+			url:            "/users",
+			header:         http.Header{"X-Request-Id": {"9b2f5c3e-5d44-4f6c-9d1e-3b8e1c2b7a10"}},
+			wantViolations: []string{`body: request body is required`},
+		},
+		{
+			name:   "invalid parameters",
+			method: http.MethodGet,
+			url:    "/users/0?fields=name&fields=password",
+			wantViolations: []string{
+				`path "id": must be greater than or equal to 1, actual is 0`,
+				`query "fields": at "[1]": must be one of "name", "email", actual is "password"`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(test.method, test.url, strings.NewReader(test.body))
+			for key, values := range test.header {
+				request.Header[key] = values
+			}
+
+			violations := document.ValidateRequest(request, []byte(test.body))
+
+			assert.Equal(t, test.wantViolations, formatViolations(violations))
+		})
+	}
+}
+
+func formatViolations(violations []openapi.Violation) []string {
+	var messages []string
+	for _, violation := range violations {
+		messages = append(messages, violation.String())
+	}
+	return messages
+}
@@ -0,0 +1,356 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/muonsoft/api-testing/internal/js"
+	"github.com/muonsoft/api-testing/internal/jsonschema"
+)
+
+// Violation describes a part of the request or response that does not follow the contract.
+type Violation struct {
+	// In is a location of the invalid value: "path", "query", "header", "cookie", "body",
+	// or an empty string for the violations of the whole operation (like undocumented status code).
+	In string
+	// Name is a name of the parameter or header.
+	Name string
+	// Path is a path to the invalid JSON node of the body in the format of assertjson package
+	// (like "items[0].id"). It is empty for the root of the body.
+	Path string
+	// Message describes the violation.
+	Message string
+}
+
+// String formats violation with its location.
+func (v Violation) String() string {
+	switch {
+	case v.In == "body" && v.Path != "":
+		return fmt.Sprintf(`body at "%s": %s`, v.Path, v.Message)
+	case v.In == "body":
+		return "body: " + v.Message
+	case v.Name != "":
+		return fmt.Sprintf(`%s "%s": %s`, v.In, v.Name, v.Message)
+	}
+
+	return v.Message
+}
+
+// ValidateRequest checks that the request matches an operation of the document: path parameters,
+// query parameters, headers, cookies and body. The body is passed separately,
+// so the request body is not consumed.
+func (d *Document) ValidateRequest(request *http.Request, body []byte) []Violation {
+	operation, values, violations := d.findOperation(request)
+	if operation == nil {
+		return violations
+	}
+
+	validator := d.validator.WithMode(jsonschema.RequestMode)
+	for _, parameter := range d.parameters(values.item, operation) {
+		violations = append(violations, d.validateParameter(validator, request, values.path, parameter)...)
+	}
+
+	requestBody, ok := d.resolve(operation["requestBody"]).(map[string]interface{})
+	if !ok {
+		return violations
+	}
+	if len(body) == 0 {
+		if requestBody["required"] == true {
+			violations = append(violations, Violation{In: "body", Message: "request body is required"})
+		}
+		return violations
+	}
+	content, _ := requestBody["content"].(map[string]interface{})
+
+	return append(violations, d.validateContent(validator, content, request.Header.Get("Content-Type"), body)...)
+}
+
+// ValidateResponse checks that the response to the request matches the operation of the document:
+// status code, headers, content type and body.
+func (d *Document) ValidateResponse(request *http.Request, code int, header http.Header, body []byte) []Violation {
+	operation, _, violations := d.findOperation(request)
+	if operation == nil {
+		return violations
+	}
+
+	responses, _ := operation["responses"].(map[string]interface{})
+	response, ok := d.resolve(findResponse(responses, code)).(map[string]interface{})
+	if !ok {
+		return []Violation{{Message: fmt.Sprintf("status code %d is not documented", code)}}
+	}
+
+	validator := d.validator.WithMode(jsonschema.ResponseMode)
+	headers, _ := response["headers"].(map[string]interface{})
+	for _, name := range sortedKeys(headers) {
+		if strings.EqualFold(name, "Content-Type") {
+			continue
+		}
+		definition, _ := d.resolve(headers[name]).(map[string]interface{})
+		values := header.Values(name)
+		if len(values) == 0 {
+			if definition["required"] == true {
+				violations = append(violations, Violation{In: "header", Name: name, Message: "required header is missing"})
+			}
+			continue
+		}
+		violations = append(violations, d.validateValues(validator, "header", name, definition, values)...)
+	}
+
+	content, _ := response["content"].(map[string]interface{})
+	if len(content) == 0 {
+		if len(body) > 0 {
+			violations = append(violations, Violation{In: "body", Message: "response body is not expected"})
+		}
+		return violations
+	}
+
+	return append(violations, d.validateContent(validator, content, header.Get("Content-Type"), body)...)
+}
+
+type operationValues struct {
+	item map[string]interface{}
+	path map[string]string
+}
+
+func (d *Document) findOperation(request *http.Request) (map[string]interface{}, operationValues, []Violation) {
+	template, path := d.findPath(request.URL.Path)
+	if template == nil {
+		return nil, operationValues{}, []Violation{{
+			Message: fmt.Sprintf(`path "%s" is not documented`, request.URL.Path),
+		}}
+	}
+	operation, ok := d.resolve(template.item[strings.ToLower(request.Method)]).(map[string]interface{})
+	if !ok {
+		return nil, operationValues{}, []Violation{{
+			Message: fmt.Sprintf(`operation %s "%s" is not documented`, request.Method, template.template),
+		}}
+	}
+
+	return operation, operationValues{item: template.item, path: path}, nil
+}
+
+// parameters merges parameters of the path item and the operation,
+// operation parameters override path item parameters with the same name and location.
+func (d *Document) parameters(item, operation map[string]interface{}) []map[string]interface{} {
+	var parameters []map[string]interface{}
+	index := make(map[string]int)
+	for _, source := range []interface{}{item["parameters"], operation["parameters"]} {
+		list, _ := source.([]interface{})
+		for _, element := range list {
+			parameter, ok := d.resolve(element).(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := parameter["name"].(string)
+			in, _ := parameter["in"].(string)
+			key := in + ":" + name
+			if in == "header" {
+				key = in + ":" + strings.ToLower(name)
+			}
+			if i, exists := index[key]; exists {
+				parameters[i] = parameter
+				continue
+			}
+			index[key] = len(parameters)
+			parameters = append(parameters, parameter)
+		}
+	}
+
+	return parameters
+}
+
+func (d *Document) validateParameter(
+	validator *jsonschema.Validator,
+	request *http.Request,
+	path map[string]string,
+	parameter map[string]interface{},
+) []Violation {
+	name, _ := parameter["name"].(string)
+	in, _ := parameter["in"].(string)
+
+	var values []string
+	switch in {
+	case "path":
+		if value, exists := path[name]; exists {
+			values = []string{value}
+		}
+	case "query":
+		values = request.URL.Query()[name]
+	case "header":
+		values = request.Header.Values(name)
+	case "cookie":
+		if cookie, err := request.Cookie(name); err == nil {
+			values = []string{cookie.Value}
+		}
+	default:
+		return nil
+	}
+
+	if len(values) == 0 {
+		if parameter["required"] == true {
+			return []Violation{{In: in, Name: name, Message: "required parameter is missing"}}
+		}
+		return nil
+	}
+
+	return d.validateValues(validator, in, name, parameter, values)
+}
+
+// validateValues validates string values of the parameter or header against its schema.
+// Values are converted to the type defined by the schema.
+func (d *Document) validateValues(
+	validator *jsonschema.Validator,
+	in, name string,
+	definition map[string]interface{},
+	values []string,
+) []Violation {
+	schema, ok := definition["schema"]
+	if !ok {
+		return nil
+	}
+	resolved, _ := d.resolve(schema).(map[string]interface{})
+
+	var value interface{}
+	if resolved["type"] == "array" {
+		if len(values) == 1 && in != "query" {
+			values = strings.Split(values[0], ",")
+		}
+		itemSchema, _ := d.resolve(resolved["items"]).(map[string]interface{})
+		elements := make([]interface{}, len(values))
+		for i, v := range values {
+			elements[i] = parseValue(itemSchema, v)
+		}
+		value = elements
+	} else {
+		value = parseValue(resolved, values[0])
+	}
+
+	var violations []Violation
+	for _, violation := range validator.Validate(schema, value) {
+		violations = append(violations, Violation{In: in, Name: name, Message: violation.String()})
+	}
+
+	return violations
+}
+
+func (d *Document) validateContent(
+	validator *jsonschema.Validator,
+	content map[string]interface{},
+	contentType string,
+	body []byte,
+) []Violation {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	key, found := findMediaType(content, mediaType)
+	if !found {
+		return []Violation{{
+			In:   "header",
+			Name: "Content-Type",
+			Message: fmt.Sprintf(
+				`content type "%s" is not documented, expected one of: %s`,
+				contentType, strings.Join(sortedKeys(content), ", "),
+			),
+		}}
+	}
+
+	object, _ := content[key].(map[string]interface{})
+	schema, ok := object["schema"]
+	if !ok || !isJSON(mediaType) {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []Violation{{In: "body", Message: fmt.Sprintf("invalid JSON: %s", err.Error())}}
+	}
+
+	var violations []Violation
+	for _, violation := range validator.Validate(schema, value) {
+		violations = append(violations, Violation{In: "body", Path: formatPath(violation.Path), Message: violation.Message})
+	}
+
+	return violations
+}
+
+// findResponse returns the response for exact status code, then for the range (like "2XX"),
+// then the default response.
+func findResponse(responses map[string]interface{}, code int) interface{} {
+	status := strconv.Itoa(code)
+	if response, ok := responses[status]; ok {
+		return response
+	}
+	for key, response := range responses {
+		if len(key) == 3 && strings.EqualFold(key[1:], "XX") && key[0] == status[0] {
+			return response
+		}
+	}
+
+	return responses["default"]
+}
+
+// findMediaType finds the media type of the content, wildcards like "application/*" and "*/*" are supported.
+func findMediaType(content map[string]interface{}, mediaType string) (string, bool) {
+	mediaType = strings.ToLower(mediaType)
+	candidates := []string{mediaType}
+	if i := strings.Index(mediaType, "/"); i >= 0 {
+		candidates = append(candidates, mediaType[:i]+"/*")
+	}
+	candidates = append(candidates, "*/*")
+
+	for _, candidate := range candidates {
+		for key := range content {
+			parsed, _, err := mime.ParseMediaType(key)
+			if err != nil {
+				parsed = strings.ToLower(key)
+			}
+			if parsed == candidate {
+				return key, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func parseValue(schema map[string]interface{}, value string) interface{} {
+	switch schema["type"] {
+	case "integer", "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+
+	return value
+}
+
+func formatPath(path *js.Path) string {
+	if path == nil {
+		return ""
+	}
+	return path.String()
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
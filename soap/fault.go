@@ -0,0 +1,131 @@
+package soap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/muonsoft/api-testing/assertions"
+	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/muonsoft/api-testing/internal/xmldom"
+	"github.com/stretchr/testify/assert"
+)
+
+// FaultAssertion is used to build assertions for SOAP faults. Elements of SOAP 1.1 faults
+// (faultcode, faultstring, detail) and SOAP 1.2 faults (Code, Reason, Detail) are asserted the same way.
+type FaultAssertion struct {
+	t        TestingT
+	envelope *AssertEnvelope
+	fault    *xmldom.Node
+}
+
+// Fault asserts that the body of the envelope contains SOAP fault.
+// Returns struct for asserting the fault values.
+func (e *AssertEnvelope) Fault() *FaultAssertion {
+	e.t.Helper()
+	fault := e.fault()
+	if fault == nil {
+		assert.Fail(e.t, "failed asserting that SOAP body contains fault")
+		return nil
+	}
+
+	return &FaultAssertion{t: e.t, envelope: e, fault: fault}
+}
+
+// HasNoFault asserts that the body of the envelope does not contain SOAP fault.
+func (e *AssertEnvelope) HasNoFault() {
+	e.t.Helper()
+	if fault := e.fault(); fault != nil {
+		f := &FaultAssertion{envelope: e, fault: fault}
+		assert.Fail(e.t, fmt.Sprintf(
+			`failed asserting that SOAP body does not contain fault, actual is "%s: %s"`,
+			f.code(), f.reason(),
+		))
+	}
+}
+
+func (e *AssertEnvelope) fault() *xmldom.Node {
+	return childElement(e.body(), e.envelope.Name.Space, "Fault")
+}
+
+// WithCode asserts local name of the fault code with fluent string assertions,
+// for example "Client" or "Server" for SOAP 1.1 and "Sender" or "Receiver" for SOAP 1.2.
+func (a *FaultAssertion) WithCode() *assertions.StringAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	return assertions.NewStringAssertion(a.t, "failed asserting that SOAP fault code ", a.code())
+}
+
+// WithSubcode asserts local name of the first fault subcode (SOAP 1.2) with fluent string assertions.
+// It is empty for SOAP 1.1 faults.
+func (a *FaultAssertion) WithSubcode() *assertions.StringAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	return assertions.NewStringAssertion(a.t, "failed asserting that SOAP fault subcode ", a.subcode())
+}
+
+// WithReason asserts the fault reason (faultstring for SOAP 1.1, the first Reason/Text
+// for SOAP 1.2) with fluent string assertions.
+func (a *FaultAssertion) WithReason() *assertions.StringAssertion {
+	if a == nil {
+		return nil
+	}
+	a.t.Helper()
+
+	return assertions.NewStringAssertion(a.t, "failed asserting that SOAP fault reason ", a.reason())
+}
+
+// Detail asserts that the fault has detail and runs XML assertions scoped to it.
+// Relative XPath expressions are evaluated from the detail element.
+func (a *FaultAssertion) Detail(xmlAssert assertxml.XMLAssertFunc) {
+	if a == nil {
+		return
+	}
+	a.t.Helper()
+	detail := a.element("detail", "Detail")
+	if detail == nil {
+		assert.Fail(a.t, "failed asserting that SOAP fault has detail")
+		return
+	}
+	xmlAssert(a.envelope.scopeTo(detail))
+}
+
+func (a *FaultAssertion) code() string {
+	return localName(text(a.element("faultcode", "Code", "Value")))
+}
+
+func (a *FaultAssertion) subcode() string {
+	return localName(text(a.element("", "Code", "Subcode", "Value")))
+}
+
+func (a *FaultAssertion) reason() string {
+	return text(a.element("faultstring", "Reason", "Text"))
+}
+
+// element returns the element of the fault: unqualified child element for SOAP 1.1
+// or descendant element of the envelope namespace by the path of local names for SOAP 1.2.
+func (a *FaultAssertion) element(local11 string, path12 ...string) *xmldom.Node {
+	if a.envelope.version == Version11 {
+		return childElement(a.fault, "", local11)
+	}
+	node := a.fault
+	for _, local := range path12 {
+		node = childElement(node, a.envelope.envelope.Name.Space, local)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func text(node *xmldom.Node) string {
+	if node == nil {
+		return ""
+	}
+	return strings.TrimSpace(node.StringValue())
+}
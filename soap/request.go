@@ -0,0 +1,52 @@
+package soap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/muonsoft/api-testing/apitest"
+)
+
+// Envelope wraps the payload into SOAP envelope of the given version. Optional header blocks
+// are placed into the header of the envelope. The payload and header blocks must be valid XML fragments.
+// Unknown version is replaced by SOAP 1.1.
+func Envelope(version Version, payload string, headers ...string) []byte {
+	if version != Version12 {
+		version = Version11
+	}
+	var envelope strings.Builder
+	envelope.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	envelope.WriteString(`<soap:Envelope xmlns:soap="` + version.Namespace() + `">`)
+	if len(headers) > 0 {
+		envelope.WriteString("<soap:Header>")
+		for _, header := range headers {
+			envelope.WriteString(header)
+		}
+		envelope.WriteString("</soap:Header>")
+	}
+	envelope.WriteString("<soap:Body>")
+	envelope.WriteString(payload)
+	envelope.WriteString("</soap:Body></soap:Envelope>")
+
+	return []byte(envelope.String())
+}
+
+// WithAction option sets the content type of SOAP request and the action. For SOAP 1.1 it sets
+// "text/xml" content type and "SOAPAction" header. For SOAP 1.2 it sets "application/soap+xml"
+// content type with "action" parameter, the parameter is omitted when the action is empty.
+// Unknown version is handled as SOAP 1.1.
+func WithAction(version Version, action string) apitest.RequestOption {
+	return func(r *http.Request) {
+		if version != Version12 {
+			r.Header.Set("Content-Type", "text/xml; charset=utf-8")
+			r.Header.Set("SOAPAction", fmt.Sprintf("%q", action))
+			return
+		}
+		contentType := "application/soap+xml; charset=utf-8"
+		if action != "" {
+			contentType += fmt.Sprintf("; action=%q", action)
+		}
+		r.Header.Set("Content-Type", contentType)
+	}
+}
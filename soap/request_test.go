@@ -0,0 +1,100 @@
+package soap_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/muonsoft/api-testing/apitest"
+	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/muonsoft/api-testing/soap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope(t *testing.T) {
+	envelope := soap.Envelope(
+		soap.Version12,
+		`<m:GetPrice xmlns:m="urn:shop"><m:Item>Apple</m:Item></m:GetPrice>`,
+		`<t:Transaction xmlns:t="urn:transaction">5</t:Transaction>`,
+	)
+
+	assertxml.EqualXML(t, []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope">
+			<env:Header><t:Transaction xmlns:t="urn:transaction">5</t:Transaction></env:Header>
+			<env:Body><m:GetPrice xmlns:m="urn:shop"><m:Item>Apple</m:Item></m:GetPrice></env:Body>
+		</env:Envelope>`), envelope)
+	soap.Has(t, envelope, func(envelope *soap.AssertEnvelope) {
+		envelope.HasVersion(soap.Version12)
+	})
+}
+
+func TestEnvelope_WhenVersionIsUnknown_ExpectSOAP11(t *testing.T) {
+	envelope := soap.Envelope(soap.Version(0), `<Ping/>`)
+
+	assertxml.EqualXML(t, soap.Envelope(soap.Version11, `<Ping/>`), envelope)
+	soap.Has(t, envelope, func(envelope *soap.AssertEnvelope) {
+		envelope.HasVersion(soap.Version11)
+	})
+}
+
+func TestWithAction(t *testing.T) {
+	tests := []struct {
+		name            string
+		version         soap.Version
+		action          string
+		wantContentType string
+		wantSOAPAction  string
+	}{
+		{
+			name:            "SOAP 1.1",
+			version:         soap.Version11,
+			action:          "urn:shop/GetPrice",
+			wantContentType: "text/xml; charset=utf-8",
+			wantSOAPAction:  `"urn:shop/GetPrice"`,
+		},
+		{
+			name:            "SOAP 1.1 without action",
+			version:         soap.Version11,
+			wantContentType: "text/xml; charset=utf-8",
+			wantSOAPAction:  `""`,
+		},
+		{
+			name:            "unknown version",
+			version:         soap.Version(0),
+			action:          "urn:shop/GetPrice",
+			wantContentType: "text/xml; charset=utf-8",
+			wantSOAPAction:  `"urn:shop/GetPrice"`,
+		},
+		{
+			name:            "SOAP 1.2",
+			version:         soap.Version12,
+			action:          "urn:shop/GetPrice",
+			wantContentType: `application/soap+xml; charset=utf-8; action="urn:shop/GetPrice"`,
+		},
+		{
+			name:            "SOAP 1.2 without action",
+			version:         soap.Version12,
+			wantContentType: "application/soap+xml; charset=utf-8",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var request *http.Request
+			var body []byte
+			handler := http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+				request = r
+				body, _ = io.ReadAll(r.Body)
+			})
+			payload := soap.Envelope(test.version, "<Ping/>")
+
+			apitest.HandlePOST(t, handler, "/soap", bytes.NewReader(payload), soap.WithAction(test.version, test.action))
+
+			require.NotNil(t, request)
+			assert.Equal(t, test.wantContentType, request.Header.Get("Content-Type"))
+			assert.Equal(t, test.wantSOAPAction, request.Header.Get("SOAPAction"))
+			assert.Equal(t, payload, body)
+		})
+	}
+}
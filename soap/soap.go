@@ -0,0 +1,222 @@
+// Package soap provides methods for testing SOAP 1.1 and 1.2 messages. It detects the version
+// of the envelope, scopes XML assertions (see assertxml package) to the header and the body
+// and asserts SOAP faults. Request helpers wrap the payload into the envelope and set the action.
+//
+// Example usage
+//
+//	import (
+//	    "bytes"
+//	    "net/http"
+//	    "testing"
+//	    "github.com/muonsoft/api-testing/apitest"
+//	    "github.com/muonsoft/api-testing/assertxml"
+//	    "github.com/muonsoft/api-testing/soap"
+//	 )
+//
+//	 func TestYourService(t *testing.T) {
+//	    handler := createHTTPHandler()
+//	    request := soap.Envelope(soap.Version12, `<m:GetPrice xmlns:m="urn:shop"><m:Item>Apple</m:Item></m:GetPrice>`)
+//
+//	    response := apitest.HandlePOST(t, handler, "/soap", bytes.NewReader(request),
+//	        soap.WithAction(soap.Version12, "urn:shop/GetPrice"))
+//
+//	    response.IsOK()
+//	    soap.Has(t, response.Recorder().Body.Bytes(), func(envelope *soap.AssertEnvelope) {
+//	        envelope.HasVersion(soap.Version12)
+//	        envelope.HasNoFault()
+//	        envelope.Body(func(body *assertxml.AssertXML) {
+//	            body.WithNamespace("m", "urn:shop")
+//	            body.Node("m:GetPriceResponse/m:Price").IsNumber().GreaterThan(0)
+//	        })
+//	    })
+//	 }
+package soap
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/muonsoft/api-testing/internal/xmlassert"
+	"github.com/muonsoft/api-testing/internal/xmldom"
+	"github.com/stretchr/testify/assert"
+)
+
+// Namespaces of SOAP envelopes.
+const (
+	Namespace11 = "http://schemas.xmlsoap.org/soap/envelope/"
+	Namespace12 = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+// Version of SOAP protocol. Request helpers (Envelope and WithAction) use SOAP 1.1
+// for unknown versions.
+type Version int
+
+// Supported versions of SOAP protocol.
+const (
+	Version11 Version = iota + 1
+	Version12
+)
+
+// String returns version number, for example "1.2".
+func (v Version) String() string {
+	switch v {
+	case Version11:
+		return "1.1"
+	case Version12:
+		return "1.2"
+	}
+	return "unknown"
+}
+
+// Namespace returns the namespace URI of the envelope.
+func (v Version) Namespace() string {
+	switch v {
+	case Version11:
+		return Namespace11
+	case Version12:
+		return Namespace12
+	}
+	return ""
+}
+
+// AssertEnvelope - structure for asserting SOAP envelope.
+type AssertEnvelope struct {
+	t        TestingT
+	version  Version
+	envelope *xmldom.Node
+	xml      *assertxml.AssertXML
+}
+
+// EnvelopeAssertFunc - callback function used for asserting SOAP envelope.
+type EnvelopeAssertFunc func(envelope *AssertEnvelope)
+
+// Has asserts that data is SOAP 1.1 or 1.2 envelope with the body and runs user callback for testing it.
+func Has(t TestingT, data []byte, assertEnvelope EnvelopeAssertFunc) {
+	t.Helper()
+	document, err := xmldom.Parse(bytes.NewReader(data))
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("data has invalid XML: %s", err.Error()))
+		return
+	}
+
+	root := firstElement(document)
+	version := versionOf(root)
+	if version == 0 {
+		assert.Fail(t, fmt.Sprintf(
+			`failed asserting that XML document is SOAP envelope, actual root element is "%s" with namespace "%s"`,
+			root.QualifiedName(), root.Name.Space,
+		))
+		return
+	}
+	if childElement(root, root.Name.Space, "Body") == nil {
+		assert.Fail(t, "failed asserting that SOAP envelope has body")
+		return
+	}
+
+	xml := xmlassert.New(t, document).(*assertxml.AssertXML)
+	assertEnvelope(&AssertEnvelope{t: t, version: version, envelope: root, xml: xml})
+}
+
+// HasBody asserts that data is SOAP envelope and runs XML assertions scoped to the body by callback function.
+func HasBody(t TestingT, data []byte, xmlAssert assertxml.XMLAssertFunc) {
+	t.Helper()
+	Has(t, data, func(envelope *AssertEnvelope) {
+		envelope.Body(xmlAssert)
+	})
+}
+
+// Version returns detected version of the envelope.
+func (e *AssertEnvelope) Version() Version {
+	return e.version
+}
+
+// HasVersion asserts that the envelope has the given version of SOAP protocol.
+func (e *AssertEnvelope) HasVersion(version Version) {
+	e.t.Helper()
+	if e.version != version {
+		assert.Fail(e.t, fmt.Sprintf(
+			`failed asserting that SOAP envelope has version %s, actual is %s`,
+			version, e.version,
+		))
+	}
+}
+
+// Header asserts that the envelope has the header and runs XML assertions scoped to it.
+// Relative XPath expressions are evaluated from the header element.
+func (e *AssertEnvelope) Header(xmlAssert assertxml.XMLAssertFunc) {
+	e.t.Helper()
+	header := childElement(e.envelope, e.envelope.Name.Space, "Header")
+	if header == nil {
+		assert.Fail(e.t, "failed asserting that SOAP envelope has header")
+		return
+	}
+	xmlAssert(e.scopeTo(header))
+}
+
+// Body runs XML assertions scoped to the body of the envelope.
+// Relative XPath expressions are evaluated from the body element.
+func (e *AssertEnvelope) Body(xmlAssert assertxml.XMLAssertFunc) {
+	e.t.Helper()
+	xmlAssert(e.scopeTo(e.body()))
+}
+
+func (e *AssertEnvelope) body() *xmldom.Node {
+	return childElement(e.envelope, e.envelope.Name.Space, "Body")
+}
+
+// scopeTo returns XML assertions scoped to the element of the envelope. The element is selected
+// by namespace URIs and local names, so the expression does not depend on the prefixes registered by user.
+func (e *AssertEnvelope) scopeTo(element *xmldom.Node) *assertxml.AssertXML {
+	var steps []string
+	for node := element; node.Type == xmldom.ElementNode; node = node.Parent {
+		steps = append([]string{step(node.Name.Space, node.Name.Local)}, steps...)
+	}
+	return e.xml.At("/" + strings.Join(steps, "/"))
+}
+
+func versionOf(root *xmldom.Node) Version {
+	if root == nil || root.Name.Local != "Envelope" {
+		return 0
+	}
+	switch root.Name.Space {
+	case Namespace11:
+		return Version11
+	case Namespace12:
+		return Version12
+	}
+	return 0
+}
+
+func firstElement(node *xmldom.Node) *xmldom.Node {
+	for _, child := range node.Children {
+		if child.Type == xmldom.ElementNode {
+			return child
+		}
+	}
+	return nil
+}
+
+func childElement(node *xmldom.Node, space, local string) *xmldom.Node {
+	for _, child := range node.Children {
+		if child.Type == xmldom.ElementNode && child.Name.Space == space && child.Name.Local == local {
+			return child
+		}
+	}
+	return nil
+}
+
+// step returns XPath location step selecting the child element by namespace URI and local name.
+func step(space, local string) string {
+	return fmt.Sprintf("*[namespace-uri() = '%s' and local-name() = '%s']", space, local)
+}
+
+// localName returns the local part of QName value, for example "Server" for "soap:Server".
+func localName(qname string) string {
+	qname = strings.TrimSpace(qname)
+	if i := strings.IndexByte(qname, ':'); i >= 0 {
+		return qname[i+1:]
+	}
+	return qname
+}
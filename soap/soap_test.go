@@ -0,0 +1,221 @@
+package soap_test
+
+import (
+	"testing"
+
+	"github.com/muonsoft/api-testing/assertxml"
+	"github.com/muonsoft/api-testing/internal/mock"
+	"github.com/muonsoft/api-testing/soap"
+)
+
+const (
+	response11 = `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+	<soap:Header>
+		<t:Transaction xmlns:t="urn:transaction">5</t:Transaction>
+	</soap:Header>
+	<soap:Body>
+		<m:GetPriceResponse xmlns:m="urn:shop">
+			<m:Price>1.90</m:Price>
+		</m:GetPriceResponse>
+	</soap:Body>
+</soap:Envelope>`
+	fault11 = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+	<soap:Body>
+		<soap:Fault>
+			<faultcode>soap:Client</faultcode>
+			<faultstring>Invalid item</faultstring>
+			<detail><e:Error xmlns:e="urn:errors"><e:Field>Item</e:Field></e:Error></detail>
+		</soap:Fault>
+	</soap:Body>
+</soap:Envelope>`
+	fault12 = `<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope">
+	<env:Body>
+		<env:Fault>
+			<env:Code>
+				<env:Value>env:Sender</env:Value>
+				<env:Subcode><env:Value xmlns:e="urn:errors">e:InvalidItem</env:Value></env:Subcode>
+			</env:Code>
+			<env:Reason>
+				<env:Text xml:lang="en">Invalid item</env:Text>
+				<env:Text xml:lang="de">Ungültiger Artikel</env:Text>
+			</env:Reason>
+		</env:Fault>
+	</env:Body>
+</env:Envelope>`
+)
+
+func TestHas(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		assert       func(envelope *soap.AssertEnvelope)
+		wantMessages []string
+	}{
+		{
+			name: "envelope assertions passed",
+			data: response11,
+			assert: func(envelope *soap.AssertEnvelope) {
+				envelope.HasVersion(soap.Version11)
+				envelope.HasNoFault()
+				envelope.Header(func(header *assertxml.AssertXML) {
+					header.WithNamespace("t", "urn:transaction")
+					header.Node("t:Transaction").IsInteger().EqualTo(5)
+				})
+				envelope.Body(func(body *assertxml.AssertXML) {
+					body.WithNamespace("m", "urn:shop")
+					body.Node("m:GetPriceResponse/m:Price").IsNumber().EqualTo(1.9)
+				})
+			},
+		},
+		{
+			name: "envelope assertions failed",
+			data: response11,
+			assert: func(envelope *soap.AssertEnvelope) {
+				envelope.HasVersion(soap.Version12)
+				envelope.Fault().WithCode().EqualTo("Server")
+				envelope.Body(func(body *assertxml.AssertXML) {
+					body.WithNamespace("m", "urn:shop")
+					body.Node("m:GetPriceResponse/m:Price").IsNumber().GreaterThan(2)
+					body.Node("m:Error").Exists()
+				})
+			},
+			wantMessages: []string{
+				`failed asserting that SOAP envelope has version 1.2, actual is 1.1`,
+				`failed asserting that SOAP body contains fault`,
+				`failed asserting that XML node "/soap:Envelope/soap:Body/m:GetPriceResponse/m:Price": greater than 2.000000, actual is 1.900000`,
+				`failed asserting that xml node '/soap:Envelope/soap:Body/m:Error' exists`,
+			},
+		},
+		{
+			name: "SOAP 1.1 fault passed",
+			data: fault11,
+			assert: func(envelope *soap.AssertEnvelope) {
+				fault := envelope.Fault()
+				fault.WithCode().EqualTo("Client")
+				fault.WithSubcode().IsEmpty()
+				fault.WithReason().EqualTo("Invalid item")
+				fault.Detail(func(detail *assertxml.AssertXML) {
+					detail.WithNamespace("e", "urn:errors")
+					detail.Node("e:Error/e:Field").EqualToTheString("Item")
+				})
+			},
+		},
+		{
+			name: "SOAP 1.1 fault failed",
+			data: fault11,
+			assert: func(envelope *soap.AssertEnvelope) {
+				envelope.HasNoFault()
+				envelope.Header(func(header *assertxml.AssertXML) {
+					header.Node("*").Exists()
+				})
+				fault := envelope.Fault()
+				fault.WithCode().EqualTo("Server")
+				fault.WithReason().Contains("price")
+				fault.Detail(func(detail *assertxml.AssertXML) {
+					detail.Node("Error").Exists()
+				})
+			},
+			wantMessages: []string{
+				`failed asserting that SOAP body does not contain fault, actual is "Client: Invalid item"`,
+				`failed asserting that SOAP envelope has header`,
+				`failed asserting that SOAP fault code equal to "Server", actual is "Client"`,
+				`failed asserting that SOAP fault reason contains "price"`,
+				`failed asserting that xml node '/soap:Envelope/soap:Body/soap:Fault/detail/Error' exists`,
+			},
+		},
+		{
+			name: "SOAP 1.2 fault passed",
+			data: fault12,
+			assert: func(envelope *soap.AssertEnvelope) {
+				envelope.HasVersion(soap.Version12)
+				fault := envelope.Fault()
+				fault.WithCode().EqualTo("Sender")
+				fault.WithSubcode().EqualTo("InvalidItem")
+				fault.WithReason().EqualTo("Invalid item")
+			},
+		},
+		{
+			name: "SOAP 1.2 fault failed",
+			data: fault12,
+			assert: func(envelope *soap.AssertEnvelope) {
+				fault := envelope.Fault()
+				fault.WithCode().EqualTo("Receiver")
+				fault.WithSubcode().EqualTo("OutOfStock")
+				fault.Detail(func(detail *assertxml.AssertXML) {})
+			},
+			wantMessages: []string{
+				`failed asserting that SOAP fault code equal to "Receiver", actual is "Sender"`,
+				`failed asserting that SOAP fault subcode equal to "OutOfStock", actual is "InvalidItem"`,
+				`failed asserting that SOAP fault has detail`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			soap.Has(tester, []byte(test.data), test.assert)
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestHas_WhenNotEnvelope_ExpectFailure(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		wantMessages []string
+	}{
+		{
+			name:         "invalid XML",
+			data:         `<soap:Envelope`,
+			wantMessages: []string{`data has invalid XML`},
+		},
+		{
+			name: "not envelope",
+			data: `<m:GetPriceResponse xmlns:m="urn:shop"/>`,
+			wantMessages: []string{
+				`failed asserting that XML document is SOAP envelope, actual root element is "m:GetPriceResponse" with namespace "urn:shop"`,
+			},
+		},
+		{
+			name: "unknown namespace",
+			data: `<Envelope xmlns="urn:envelope"><Body/></Envelope>`,
+			wantMessages: []string{
+				`failed asserting that XML document is SOAP envelope, actual root element is "Envelope" with namespace "urn:envelope"`,
+			},
+		},
+		{
+			name:         "missing body",
+			data:         `<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope"><env:Header/></env:Envelope>`,
+			wantMessages: []string{`failed asserting that SOAP envelope has body`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &mock.Tester{}
+
+			soap.Has(tester, []byte(test.data), func(envelope *soap.AssertEnvelope) {
+				t.Error("callback must not be called")
+			})
+
+			tester.AssertContains(t, test.wantMessages)
+		})
+	}
+}
+
+func TestHasBody(t *testing.T) {
+	tester := &mock.Tester{}
+
+	soap.HasBody(tester, []byte(response11), func(body *assertxml.AssertXML) {
+		body.WithNamespace("m", "urn:shop")
+		body.Node("m:GetPriceResponse/m:Price").EqualToTheString("1.90")
+		body.Node("/soap:Envelope").Exists()
+	})
+
+	tester.AssertContains(t, []string{
+		`failed to evaluate XPath "/soap:Envelope": namespace prefix "soap" is not registered`,
+	})
+}